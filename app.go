@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	domain "github.com/kavos113/desy/backend/domain"
@@ -17,13 +20,35 @@ import (
 	_ "modernc.org/sqlite"
 )
 
+// selectorConfigPath is an optional override of the embedded parser selectors, read from the working directory.
+const selectorConfigPath = "selectors.json"
+
 // App struct
 type App struct {
-	ctx              context.Context
-	db               *sql.DB
-	lectureUsecase   usecase.LectureUsecase
-	scraperUsecase   usecase.ScraperUsecase
-	timetableUsecase usecase.TimeTableUsecase
+	ctx                   context.Context
+	db                    *sql.DB
+	lectureUsecase        usecase.LectureUsecase
+	scraperUsecase        usecase.ScraperUsecase
+	timetableUsecase      usecase.TimeTableUsecase
+	roomUsecase           usecase.RoomUsecase
+	teacherUsecase        usecase.TeacherUsecase
+	researchUsecase       usecase.ResearchFilterUsecase
+	scrapeRunUsecase      usecase.ScrapeRunUsecase
+	graphUsecase          usecase.CourseGraphUsecase
+	graduationUsecase     usecase.GraduationUsecase
+	transcriptUsecase     usecase.TranscriptUsecase
+	userDataUsecase       usecase.UserDataUsecase
+	savedSearchUsecase    usecase.SavedSearchUsecase
+	recommendationUsecase usecase.RecommendationUsecase
+	exportUsecase         usecase.ExportUsecase
+	datasetUsecase        usecase.DatasetUsecase
+	siteUsecase           usecase.SiteUsecase
+	refreshUsecase        usecase.RefreshUsecase
+	// scrapeMu serializes manual scrapes with the scheduled refresh, which share the scraper.
+	scrapeMu      *sync.Mutex
+	refreshMu     sync.Mutex
+	refreshCancel context.CancelFunc
+	refreshDone   chan struct{}
 }
 
 // NewApp creates a new App application struct
@@ -43,15 +68,116 @@ func NewApp() *App {
 		panic(fmt.Errorf("init timetable repository: %w", err))
 	}
 
+	roomRepo, err := sqlite.NewRoomRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init room repository: %w", err))
+	}
+
+	teacherRepo, err := sqlite.NewTeacherRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init teacher repository: %w", err))
+	}
+
+	researchRepo, err := sqlite.NewResearchFilterRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init research filter repository: %w", err))
+	}
+
+	scrapeRunRepo, err := sqlite.NewScrapeRunRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init scrape run repository: %w", err))
+	}
+
+	graphRepo, err := sqlite.NewCourseGraphRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init course graph repository: %w", err))
+	}
+
+	requirementRepo, err := sqlite.NewRequirementSetRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init requirement set repository: %w", err))
+	}
+
+	transcriptRepo, err := sqlite.NewTranscriptRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init transcript repository: %w", err))
+	}
+
+	userDataRepo, err := sqlite.NewUserDataRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init user data repository: %w", err))
+	}
+
+	savedSearchRepo, err := sqlite.NewSavedSearchRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init saved search repository: %w", err))
+	}
+
+	recommendationRepo, err := sqlite.NewRecommendationRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init recommendation repository: %w", err))
+	}
+
+	datasetRepo, err := sqlite.NewDatasetRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init dataset repository: %w", err))
+	}
+
+	refreshSettingRepo, err := sqlite.NewRefreshSettingRepository(db)
+	if err != nil {
+		panic(fmt.Errorf("init refresh setting repository: %w", err))
+	}
+
 	fetcher := usecase.NewHTTPFetcher(&http.Client{Timeout: 15 * time.Second})
-	scraperUsecase := usecase.NewScraperUsecase(fetcher, lectureRepo, timetableRepo, scraper.NewParser(), 3*time.Second)
+	scraperUsecase := usecase.NewScraperUsecaseWithSites(fetcher, lectureRepo, timetableRepo, loadSiteRegistry(selectorConfigPath), 3*time.Second)
+	scraperUsecase.SetScrapeRunRepository(scrapeRunRepo)
+	scrapeMu := &sync.Mutex{}
 
 	return &App{
-		db:               db,
-		lectureUsecase:   usecase.NewLectureUsecase(lectureRepo),
-		scraperUsecase:   scraperUsecase,
-		timetableUsecase: usecase.NewTimeTableUsecase(timetableRepo),
+		db:                    db,
+		lectureUsecase:        usecase.NewLectureUsecase(lectureRepo),
+		scraperUsecase:        scraperUsecase,
+		timetableUsecase:      usecase.NewTimeTableUsecase(timetableRepo),
+		roomUsecase:           usecase.NewRoomUsecase(roomRepo),
+		teacherUsecase:        usecase.NewTeacherUsecase(teacherRepo, lectureRepo),
+		researchUsecase:       usecase.NewResearchFilterUsecase(researchRepo),
+		scrapeRunUsecase:      usecase.NewScrapeRunUsecase(scrapeRunRepo),
+		graphUsecase:          usecase.NewCourseGraphUsecase(graphRepo),
+		graduationUsecase:     usecase.NewGraduationUsecase(requirementRepo, lectureRepo),
+		transcriptUsecase:     usecase.NewTranscriptUsecase(transcriptRepo, lectureRepo),
+		userDataUsecase:       usecase.NewUserDataUsecase(userDataRepo, lectureRepo),
+		savedSearchUsecase:    usecase.NewSavedSearchUsecase(savedSearchRepo, lectureRepo),
+		recommendationUsecase: usecase.NewRecommendationUsecase(recommendationRepo, lectureRepo),
+		exportUsecase:         usecase.NewExportUsecase(lectureRepo),
+		datasetUsecase:        usecase.NewDatasetUsecase(lectureRepo, datasetRepo),
+		siteUsecase:           usecase.NewSiteUsecase(lectureRepo),
+		refreshUsecase:        usecase.NewRefreshUsecase(refreshSettingRepo, scraperUsecase, scrapeMu),
+		scrapeMu:              scrapeMu,
+	}
+}
+
+// loadSiteRegistry builds the site registry, parsing pages with the selector override at path when it exists.
+func loadSiteRegistry(path string) *scraper.Registry {
+	if _, err := os.Stat(path); err != nil {
+		return scraper.DefaultRegistry()
 	}
+
+	config, err := scraper.LoadSelectorConfig(path)
+	if err != nil {
+		log.Printf("load selector config %s: %v; using embedded selectors", path, err)
+		return scraper.DefaultRegistry()
+	}
+	parser, err := scraper.NewParserWithConfig(config)
+	if err != nil {
+		log.Printf("load selector config %s: %v; using embedded selectors", path, err)
+		return scraper.DefaultRegistry()
+	}
+	sites, err := scraper.NewRegistry(scraper.NewISCTSite(parser))
+	if err != nil {
+		log.Printf("register sites: %v; using embedded selectors", err)
+		return scraper.DefaultRegistry()
+	}
+	return sites
 }
 
 // startup is called when the app starts. The context is saved
@@ -64,6 +190,23 @@ func (a *App) startup(ctx context.Context) {
 			log.Printf("expand timetable ranges on startup: %v", err)
 		}
 	}
+
+	if a.roomUsecase != nil {
+		if _, err := a.roomUsecase.NormalizeRooms(ctx); err != nil {
+			log.Printf("normalize rooms on startup: %v", err)
+		}
+	}
+
+	if a.refreshUsecase != nil {
+		status, err := a.refreshUsecase.GetStatus()
+		if err != nil {
+			log.Printf("load refresh schedule on startup: %v", err)
+		} else if status.Setting != nil {
+			if err := a.startRefreshSchedule(*status.Setting); err != nil {
+				log.Printf("start refresh schedule: %v", err)
+			}
+		}
+	}
 }
 
 // Greet returns a greeting for the given name
@@ -71,11 +214,16 @@ func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
 }
 
-func (a *App) Scrape() error {
+func (a *App) Scrape(siteID string) error {
 	if a.scraperUsecase == nil {
 		return fmt.Errorf("scraper usecase is not configured")
 	}
 
+	if !a.scrapeMu.TryLock() {
+		return fmt.Errorf("a scrape is already running")
+	}
+	defer a.scrapeMu.Unlock()
+
 	ctx := a.ctx
 	if ctx == nil {
 		ctx = context.Background()
@@ -83,15 +231,23 @@ func (a *App) Scrape() error {
 	cleanup := a.attachProgressReporter(ctx)
 	defer cleanup()
 
-	_, err := a.scraperUsecase.ScrapeTopPageAndSave(ctx, time.Now().Year())
-	return err
+	if _, err := a.scraperUsecase.ScrapeTopPageAndSave(ctx, siteID, time.Now().Year()); err != nil {
+		return err
+	}
+	a.afterScrape(ctx)
+	return nil
 }
 
-func (a *App) ScrapeAll() error {
+func (a *App) ScrapeAll(siteID string) error {
 	if a.scraperUsecase == nil {
 		return fmt.Errorf("scraper usecase is not configured")
 	}
 
+	if !a.scrapeMu.TryLock() {
+		return fmt.Errorf("a scrape is already running")
+	}
+	defer a.scrapeMu.Unlock()
+
 	ctx := a.ctx
 	if ctx == nil {
 		ctx = context.Background()
@@ -101,20 +257,61 @@ func (a *App) ScrapeAll() error {
 
 	for year := 2020; year <= time.Now().Year(); year++ {
 		log.Printf("scraping year %d", year)
-		_, err := a.scraperUsecase.ScrapeTopPageAndSave(ctx, year)
+		_, err := a.scraperUsecase.ScrapeTopPageAndSave(ctx, siteID, year)
 		if err != nil {
 			return fmt.Errorf("scrape year %d: %w", year, err)
 		}
 	}
+	a.afterScrape(ctx)
 	return nil
 }
 
+func (a *App) CheckForUpdates(siteID string, dryRun bool) (*usecase.UpdateCheck, error) {
+	if a.scraperUsecase == nil {
+		return nil, fmt.Errorf("scraper usecase is not configured")
+	}
+
+	if !a.scrapeMu.TryLock() {
+		return nil, fmt.Errorf("a scrape is already running")
+	}
+	defer a.scrapeMu.Unlock()
+
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cleanup := a.attachProgressReporter(ctx)
+	defer cleanup()
+
+	check, err := a.scraperUsecase.CheckForUpdates(ctx, siteID, domain.AcademicYear(time.Now()), dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if len(check.Lectures) > 0 {
+		a.afterScrape(ctx)
+	}
+	return check, nil
+}
+
+func (a *App) ListSites() ([]usecase.SiteInfo, error) {
+	if a.scraperUsecase == nil {
+		return nil, fmt.Errorf("scraper usecase is not configured")
+	}
+
+	return a.scraperUsecase.Sites(), nil
+}
+
 func (a *App) ScrapeTest() error {
 	const testURL = "https://syllabus.s.isct.ac.jp/courses/2025/4/0-904-340000-120900-20927"
 	if a.scraperUsecase == nil {
 		return fmt.Errorf("scraper usecase is not configured")
 	}
 
+	if !a.scrapeMu.TryLock() {
+		return fmt.Errorf("a scrape is already running")
+	}
+	defer a.scrapeMu.Unlock()
+
 	ctx := a.ctx
 	if ctx == nil {
 		ctx = context.Background()
@@ -122,8 +319,11 @@ func (a *App) ScrapeTest() error {
 	cleanup := a.attachProgressReporter(ctx)
 	defer cleanup()
 
-	_, err := a.scraperUsecase.ScrapeCourseListAndSave(ctx, testURL, scraper.TopPageURL)
-	return err
+	if _, err := a.scraperUsecase.ScrapeCourseListAndSave(ctx, testURL, scraper.TopPageURL); err != nil {
+		return err
+	}
+	a.afterScrape(ctx)
+	return nil
 }
 
 func (a *App) MigrateRelatedCourses() (int, error) {
@@ -139,6 +339,19 @@ func (a *App) MigrateRelatedCourses() (int, error) {
 	return a.lectureUsecase.MigrateRelatedCourses(ctx)
 }
 
+func (a *App) MigrateCourseReferences() (int, error) {
+	if a.lectureUsecase == nil {
+		return 0, fmt.Errorf("lecture usecase is not configured")
+	}
+
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return a.lectureUsecase.MigrateCourseReferences(ctx)
+}
+
 func (a *App) SearchLectures(query domain.SearchQuery) ([]domain.LectureSummary, error) {
 	if a.lectureUsecase == nil {
 		return nil, fmt.Errorf("lecture usecase is not configured")
@@ -147,15 +360,131 @@ func (a *App) SearchLectures(query domain.SearchQuery) ([]domain.LectureSummary,
 	return a.lectureUsecase.SearchLectures(query)
 }
 
-func (a *App) GetLectureDetails(lectureID int) (*domain.Lecture, error) {
+func (a *App) GetLectureDetails(lectureID int, lang string) (*domain.Lecture, error) {
 	if a.lectureUsecase == nil {
 		return nil, fmt.Errorf("lecture usecase is not configured")
 	}
 
-	return a.lectureUsecase.GetLectureDetails(lectureID)
+	return a.lectureUsecase.GetLectureDetails(lectureID, lang)
+}
+
+func (a *App) GetOfficeHoursToday(lectureID int) ([]domain.OfficeHour, error) {
+	if a.lectureUsecase == nil {
+		return nil, fmt.Errorf("lecture usecase is not configured")
+	}
+
+	return a.lectureUsecase.GetOfficeHoursOn(lectureID, domain.WeekdayOf(time.Now().Weekday()))
+}
+
+func (a *App) GetCourseOfferings(code string) ([]domain.CourseOffering, error) {
+	if a.lectureUsecase == nil {
+		return nil, fmt.Errorf("lecture usecase is not configured")
+	}
+
+	return a.lectureUsecase.GetCourseOfferings(code)
+}
+
+func (a *App) CompareCourseOfferings(code string) ([]domain.OfferingComparison, error) {
+	if a.lectureUsecase == nil {
+		return nil, fmt.Errorf("lecture usecase is not configured")
+	}
+
+	return a.lectureUsecase.CompareCourseOfferings(code)
+}
+
+func (a *App) GetLectureSessions(lectureIDs []int, kinds []domain.LecturePlanKind) ([]domain.LectureSession, error) {
+	if a.lectureUsecase == nil {
+		return nil, fmt.Errorf("lecture usecase is not configured")
+	}
+
+	return a.lectureUsecase.GetLectureSessions(lectureIDs, kinds)
+}
+
+func (a *App) ListRooms() ([]domain.Room, error) {
+	if a.roomUsecase == nil {
+		return nil, fmt.Errorf("room usecase is not configured")
+	}
+
+	return a.roomUsecase.ListRooms()
+}
+
+func (a *App) FindFreeRooms(year int, semester domain.Semester, day domain.DayOfWeek, period domain.Period, buildingFilter string) ([]domain.Room, error) {
+	if a.roomUsecase == nil {
+		return nil, fmt.Errorf("room usecase is not configured")
+	}
+
+	return a.roomUsecase.FindFreeRooms(year, semester, day, period, buildingFilter)
+}
+
+func (a *App) GetRoomSchedule(roomID int, year int) (*domain.RoomSchedule, error) {
+	if a.roomUsecase == nil {
+		return nil, fmt.Errorf("room usecase is not configured")
+	}
+
+	return a.roomUsecase.GetRoomSchedule(roomID, year)
+}
+
+func (a *App) GetTeacherSchedule(teacher string, year int) (*domain.TeacherSchedule, error) {
+	if a.teacherUsecase == nil {
+		return nil, fmt.Errorf("teacher usecase is not configured")
+	}
+
+	return a.teacherUsecase.GetTeacherSchedule(teacher, year)
+}
+
+func (a *App) ListResearchFilterRules() ([]domain.ResearchFilterRule, error) {
+	if a.researchUsecase == nil {
+		return nil, fmt.Errorf("research filter usecase is not configured")
+	}
+
+	return a.researchUsecase.ListRules()
+}
+
+func (a *App) CreateResearchFilterRule(rule domain.ResearchFilterRule) (*domain.ResearchFilterRule, error) {
+	if a.researchUsecase == nil {
+		return nil, fmt.Errorf("research filter usecase is not configured")
+	}
+
+	return a.researchUsecase.CreateRule(rule)
+}
+
+func (a *App) UpdateResearchFilterRule(rule domain.ResearchFilterRule) error {
+	if a.researchUsecase == nil {
+		return fmt.Errorf("research filter usecase is not configured")
+	}
+
+	return a.researchUsecase.UpdateRule(rule)
+}
+
+func (a *App) DeleteResearchFilterRule(id int) error {
+	if a.researchUsecase == nil {
+		return fmt.Errorf("research filter usecase is not configured")
+	}
+
+	return a.researchUsecase.DeleteRule(id)
+}
+
+func (a *App) ListScrapeRuns(siteID string) ([]domain.ScrapeRun, error) {
+	if a.scrapeRunUsecase == nil {
+		return nil, fmt.Errorf("scrape run usecase is not configured")
+	}
+
+	if siteID == "" {
+		siteID = scraper.DefaultSiteID
+	}
+	return a.scrapeRunUsecase.ListRuns(siteID, 20)
+}
+
+func (a *App) GetScrapeRunReport(runID int) (*usecase.ScrapeRunReport, error) {
+	if a.scrapeRunUsecase == nil {
+		return nil, fmt.Errorf("scrape run usecase is not configured")
+	}
+
+	return a.scrapeRunUsecase.GetReport(runID)
 }
 
 func (a *App) shutdown(context.Context) {
+	a.stopRefreshSchedule()
 	if a.db != nil {
 		_ = a.db.Close()
 	}
@@ -182,3 +511,573 @@ func (r *wailsProgressReporter) Report(progress usecase.ScrapeProgress) {
 	}
 	runtime.EventsEmit(r.ctx, "fetch_status", progress)
 }
+
+func (r *wailsProgressReporter) ReportParseDrift(drift domain.ParseDrift) {
+	if r == nil || r.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(r.ctx, "parse_drift", drift)
+}
+
+// afterScrape updates the recommendation index and re-checks the saved searches after a scrape.
+// Failures are logged so that they do not fail the scrape.
+func (a *App) afterScrape(ctx context.Context) {
+	if a.recommendationUsecase != nil {
+		if _, err := a.recommendationUsecase.RefreshIndex(ctx); err != nil {
+			log.Printf("refresh recommendation index: %v", err)
+		}
+	}
+	a.notifySavedSearchChanges(ctx)
+}
+
+// notifySavedSearchChanges re-checks the saved searches and emits the changes found.
+func (a *App) notifySavedSearchChanges(ctx context.Context) {
+	if a.savedSearchUsecase == nil {
+		return
+	}
+	notifications, err := a.savedSearchUsecase.CheckSearches()
+	if err != nil {
+		log.Printf("check saved searches: %v", err)
+		return
+	}
+	emitSearchNotifications(ctx, notifications)
+}
+
+// emitSearchNotifications emits a saved_search_changes event for each search whose result changed.
+func emitSearchNotifications(ctx context.Context, notifications []domain.SearchNotification) {
+	if ctx == nil {
+		return
+	}
+	for _, notification := range notifications {
+		runtime.EventsEmit(ctx, "saved_search_changes", notification)
+	}
+}
+
+func (a *App) GetRefreshSchedule() (*usecase.RefreshStatus, error) {
+	if a.refreshUsecase == nil {
+		return nil, fmt.Errorf("refresh usecase is not configured")
+	}
+
+	return a.refreshUsecase.GetStatus()
+}
+
+func (a *App) SetRefreshSchedule(siteID, spec string) (*usecase.RefreshStatus, error) {
+	if a.refreshUsecase == nil {
+		return nil, fmt.Errorf("refresh usecase is not configured")
+	}
+
+	setting, err := a.refreshUsecase.SaveSetting(siteID, spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.startRefreshSchedule(*setting); err != nil {
+		return nil, err
+	}
+	return a.refreshUsecase.GetStatus()
+}
+
+func (a *App) DisableRefreshSchedule() error {
+	if a.refreshUsecase == nil {
+		return fmt.Errorf("refresh usecase is not configured")
+	}
+
+	a.stopRefreshSchedule()
+	return a.refreshUsecase.DeleteSetting()
+}
+
+// startRefreshSchedule replaces the running schedule with setting. Each refresh emits a scheduled_refresh
+// event with its outcome and, when lectures changed, runs the same follow-up as a manual scrape.
+func (a *App) startRefreshSchedule(setting domain.RefreshSetting) error {
+	schedule, err := domain.ParseRefreshSchedule(setting.Spec)
+	if err != nil {
+		return err
+	}
+
+	a.stopRefreshSchedule()
+
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	done := make(chan struct{})
+
+	a.refreshMu.Lock()
+	a.refreshCancel, a.refreshDone = cancel, done
+	a.refreshMu.Unlock()
+
+	go func() {
+		defer close(done)
+		err := a.refreshUsecase.RunSchedule(ctx, schedule, setting.SiteID, func(outcome usecase.RefreshOutcome) {
+			if outcome.Error != "" {
+				log.Printf("scheduled refresh of %d: %s", outcome.Year, outcome.Error)
+			}
+			if outcome.Updated > 0 {
+				a.afterScrape(ctx)
+			}
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, "scheduled_refresh", outcome)
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Printf("refresh schedule stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// stopRefreshSchedule cancels the running schedule, if any, and waits for it to return.
+func (a *App) stopRefreshSchedule() {
+	a.refreshMu.Lock()
+	cancel, done := a.refreshCancel, a.refreshDone
+	a.refreshCancel, a.refreshDone = nil, nil
+	a.refreshMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (a *App) GetPrerequisiteGraph(lectureID int, kinds []domain.CourseRelationKind) (*domain.CourseGraph, error) {
+	if a.graphUsecase == nil {
+		return nil, fmt.Errorf("course graph usecase is not configured")
+	}
+
+	return a.graphUsecase.GetPrerequisiteGraph(lectureID, kinds)
+}
+
+func (a *App) FindCoursePath(fromID, toID int, kinds []domain.CourseRelationKind) ([]domain.CourseNode, error) {
+	if a.graphUsecase == nil {
+		return nil, fmt.Errorf("course graph usecase is not configured")
+	}
+
+	return a.graphUsecase.FindPath(fromID, toID, kinds)
+}
+
+func (a *App) GetStudyOrder(lectureIDs []int, kinds []domain.CourseRelationKind) ([]domain.CourseNode, error) {
+	if a.graphUsecase == nil {
+		return nil, fmt.Errorf("course graph usecase is not configured")
+	}
+
+	return a.graphUsecase.GetStudyOrder(lectureIDs, kinds)
+}
+
+func (a *App) FindCourseCycles(year int, kinds []domain.CourseRelationKind) ([][]domain.CourseNode, error) {
+	if a.graphUsecase == nil {
+		return nil, fmt.Errorf("course graph usecase is not configured")
+	}
+
+	return a.graphUsecase.FindCycles(year, kinds)
+}
+
+func (a *App) ExportCourseGraph(format string, year int, lectureIDs []int, kinds []domain.CourseRelationKind) (string, error) {
+	if a.graphUsecase == nil {
+		return "", fmt.Errorf("course graph usecase is not configured")
+	}
+
+	var b strings.Builder
+	if err := a.graphUsecase.ExportGraph(&b, usecase.GraphFormat(format), year, lectureIDs, kinds); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (a *App) SaveRequirementSet(name, source string) (*domain.RequirementSet, error) {
+	if a.graduationUsecase == nil {
+		return nil, fmt.Errorf("graduation usecase is not configured")
+	}
+
+	return a.graduationUsecase.SaveRequirementSet(name, source)
+}
+
+func (a *App) ListRequirementSets() ([]domain.RequirementSet, error) {
+	if a.graduationUsecase == nil {
+		return nil, fmt.Errorf("graduation usecase is not configured")
+	}
+
+	return a.graduationUsecase.ListRequirementSets()
+}
+
+func (a *App) DeleteRequirementSet(name string) error {
+	if a.graduationUsecase == nil {
+		return fmt.Errorf("graduation usecase is not configured")
+	}
+
+	return a.graduationUsecase.DeleteRequirementSet(name)
+}
+
+func (a *App) CheckRequirements(name string, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error) {
+	if a.graduationUsecase == nil {
+		return nil, fmt.Errorf("graduation usecase is not configured")
+	}
+
+	return a.graduationUsecase.CheckRequirements(name, completedIDs, plannedIDs, year)
+}
+
+func (a *App) CheckRequirementSource(source string, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error) {
+	if a.graduationUsecase == nil {
+		return nil, fmt.Errorf("graduation usecase is not configured")
+	}
+
+	return a.graduationUsecase.CheckRequirementSource(source, completedIDs, plannedIDs, year)
+}
+
+func (a *App) ImportTranscript() (*usecase.TranscriptImportResult, error) {
+	if a.transcriptUsecase == nil {
+		return nil, fmt.Errorf("transcript usecase is not configured")
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "成績ファイルを選択",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "CSV / TSV", Pattern: "*.csv;*.tsv;*.txt"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open transcript: %w", err)
+	}
+	defer file.Close()
+
+	return a.transcriptUsecase.ImportTranscript(file)
+}
+
+func (a *App) ImportTranscriptText(content string) (*usecase.TranscriptImportResult, error) {
+	if a.transcriptUsecase == nil {
+		return nil, fmt.Errorf("transcript usecase is not configured")
+	}
+
+	return a.transcriptUsecase.ImportTranscript(strings.NewReader(content))
+}
+
+func (a *App) ListTranscriptEntries() ([]domain.TranscriptEntry, error) {
+	if a.transcriptUsecase == nil {
+		return nil, fmt.Errorf("transcript usecase is not configured")
+	}
+
+	return a.transcriptUsecase.ListEntries()
+}
+
+func (a *App) DeleteTranscriptEntry(id int) error {
+	if a.transcriptUsecase == nil {
+		return fmt.Errorf("transcript usecase is not configured")
+	}
+
+	return a.transcriptUsecase.DeleteEntry(id)
+}
+
+func (a *App) GetTranscriptSummary() (*domain.TranscriptSummary, error) {
+	if a.transcriptUsecase == nil {
+		return nil, fmt.Errorf("transcript usecase is not configured")
+	}
+
+	return a.transcriptUsecase.GetSummary()
+}
+
+func (a *App) SetBookmark(lectureID int, bookmarked bool) error {
+	if a.userDataUsecase == nil {
+		return fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.SetBookmark(lectureID, bookmarked)
+}
+
+func (a *App) ListBookmarks() ([]domain.LectureSummary, error) {
+	if a.userDataUsecase == nil {
+		return nil, fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.ListBookmarks()
+}
+
+func (a *App) AddLectureTag(lectureID int, tag string) error {
+	if a.userDataUsecase == nil {
+		return fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.AddTag(lectureID, tag)
+}
+
+func (a *App) RemoveLectureTag(lectureID int, tag string) error {
+	if a.userDataUsecase == nil {
+		return fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.RemoveTag(lectureID, tag)
+}
+
+func (a *App) GetLectureTags(lectureID int) ([]string, error) {
+	if a.userDataUsecase == nil {
+		return nil, fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.GetTags(lectureID)
+}
+
+func (a *App) ListTags() ([]string, error) {
+	if a.userDataUsecase == nil {
+		return nil, fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.ListTags()
+}
+
+func (a *App) SaveLectureNote(lectureID int, body string) error {
+	if a.userDataUsecase == nil {
+		return fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.SaveNote(lectureID, body)
+}
+
+func (a *App) GetLectureNote(lectureID int) (*domain.CourseNote, error) {
+	if a.userDataUsecase == nil {
+		return nil, fmt.Errorf("user data usecase is not configured")
+	}
+
+	return a.userDataUsecase.GetNote(lectureID)
+}
+
+func (a *App) SaveSearch(name string, query domain.SearchQuery) (*domain.SavedSearch, error) {
+	if a.savedSearchUsecase == nil {
+		return nil, fmt.Errorf("saved search usecase is not configured")
+	}
+
+	return a.savedSearchUsecase.SaveSearch(name, query)
+}
+
+func (a *App) ListSavedSearches() ([]domain.SavedSearch, error) {
+	if a.savedSearchUsecase == nil {
+		return nil, fmt.Errorf("saved search usecase is not configured")
+	}
+
+	return a.savedSearchUsecase.ListSearches()
+}
+
+func (a *App) DeleteSavedSearch(id int) error {
+	if a.savedSearchUsecase == nil {
+		return fmt.Errorf("saved search usecase is not configured")
+	}
+
+	return a.savedSearchUsecase.DeleteSearch(id)
+}
+
+func (a *App) RunSavedSearch(id int) ([]domain.LectureSummary, error) {
+	if a.savedSearchUsecase == nil {
+		return nil, fmt.Errorf("saved search usecase is not configured")
+	}
+
+	return a.savedSearchUsecase.RunSearch(id)
+}
+
+func (a *App) CheckSavedSearches() ([]domain.SearchNotification, error) {
+	if a.savedSearchUsecase == nil {
+		return nil, fmt.Errorf("saved search usecase is not configured")
+	}
+
+	notifications, err := a.savedSearchUsecase.CheckSearches()
+	if err != nil {
+		return nil, err
+	}
+	emitSearchNotifications(a.ctx, notifications)
+	return notifications, nil
+}
+
+func (a *App) ListSearchNotifications(unreadOnly bool) ([]domain.SearchNotification, error) {
+	if a.savedSearchUsecase == nil {
+		return nil, fmt.Errorf("saved search usecase is not configured")
+	}
+
+	return a.savedSearchUsecase.ListNotifications(unreadOnly)
+}
+
+func (a *App) MarkSearchNotificationRead(id int) error {
+	if a.savedSearchUsecase == nil {
+		return fmt.Errorf("saved search usecase is not configured")
+	}
+
+	return a.savedSearchUsecase.MarkNotificationRead(id)
+}
+
+func (a *App) RecommendLectures(lectureIDs []int, limit int, filters domain.SearchQuery) ([]domain.Recommendation, error) {
+	if a.recommendationUsecase == nil {
+		return nil, fmt.Errorf("recommendation usecase is not configured")
+	}
+
+	return a.recommendationUsecase.RecommendLectures(lectureIDs, limit, filters)
+}
+
+func (a *App) RefreshRecommendationIndex() (int, error) {
+	if a.recommendationUsecase == nil {
+		return 0, fmt.Errorf("recommendation usecase is not configured")
+	}
+
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return a.recommendationUsecase.RefreshIndex(ctx)
+}
+
+func (a *App) ListExportColumns() []domain.ExportColumn {
+	return domain.ExportColumns
+}
+
+func (a *App) ExportLectures(format string, request usecase.LectureExportRequest) (string, error) {
+	if a.exportUsecase == nil {
+		return "", fmt.Errorf("export usecase is not configured")
+	}
+
+	if format == "" {
+		format = string(usecase.ExportFormatCSV)
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "エクスポート先を選択",
+		DefaultFilename: "lectures." + format,
+		Filters: []runtime.FileFilter{
+			{DisplayName: strings.ToUpper(format), Pattern: "*." + format},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create export: %w", err)
+	}
+	if _, err := a.exportUsecase.ExportLectures(file, usecase.ExportFormat(format), request); err != nil {
+		file.Close()
+		_ = os.Remove(path)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("close export: %w", err)
+	}
+	return path, nil
+}
+
+func (a *App) ExportTimetableReport(request usecase.TimetableReportRequest) (string, error) {
+	if a.siteUsecase == nil {
+		return "", fmt.Errorf("site usecase is not configured")
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "レポートの保存先を選択",
+		DefaultFilename: "timetable.html",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "HTML", Pattern: "*.html"},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create report: %w", err)
+	}
+	if _, err := a.siteUsecase.WriteTimetableReport(file, request); err != nil {
+		file.Close()
+		_ = os.Remove(path)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("close report: %w", err)
+	}
+	return path, nil
+}
+
+func (a *App) ExportDataset(path string) (*domain.DatasetManifest, error) {
+	if a.datasetUsecase == nil {
+		return nil, fmt.Errorf("dataset usecase is not configured")
+	}
+
+	if path == "" {
+		selected, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           "データセットの保存先を選択",
+			DefaultFilename: "desy-dataset.ndjson.gz",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "Dataset", Pattern: "*.gz"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if selected == "" {
+			return nil, nil
+		}
+		path = selected
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create dataset: %w", err)
+	}
+	manifest, err := a.datasetUsecase.ExportDataset(file)
+	if err != nil {
+		file.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("close dataset: %w", err)
+	}
+	return manifest, nil
+}
+
+func (a *App) ImportDataset(path string) (*domain.DatasetImportResult, error) {
+	if a.datasetUsecase == nil {
+		return nil, fmt.Errorf("dataset usecase is not configured")
+	}
+
+	if path == "" {
+		selected, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "データセットを選択",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "Dataset", Pattern: "*.gz"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if selected == "" {
+			return nil, nil
+		}
+		path = selected
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open dataset: %w", err)
+	}
+	defer file.Close()
+
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, err := a.datasetUsecase.ImportDataset(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	if result.Imported > 0 || result.Updated > 0 {
+		a.afterScrape(ctx)
+	}
+	return result, nil
+}
@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContactKind tells how a contact entry is reached.
+type ContactKind string
+
+const (
+	ContactKindEmail ContactKind = "email"
+	ContactKindPhone ContactKind = "phone"
+	ContactKindURL   ContactKind = "url"
+)
+
+// ContactEntry is an address found in the contact section of a syllabus.
+// TeacherName is set when the entry could be attributed to one of the lecture's teachers.
+type ContactEntry struct {
+	Kind        ContactKind
+	Value       string
+	TeacherName string
+}
+
+// Link returns a mailto:, tel: or web link for the entry.
+func (c ContactEntry) Link() string {
+	switch c.Kind {
+	case ContactKindEmail:
+		return "mailto:" + c.Value
+	case ContactKindPhone:
+		return "tel:" + strings.NewReplacer("-", "", " ", "").Replace(c.Value)
+	default:
+		return c.Value
+	}
+}
+
+// OfficeHour is a weekly time range in which a teacher takes questions.
+// Start and End use the 24-hour "15:04" layout; Note keeps the line the range was read from.
+type OfficeHour struct {
+	DayOfWeek DayOfWeek
+	Start     string
+	End       string
+	Note      string
+}
+
+var (
+	contactWidthReplacer = strings.NewReplacer(
+		"０", "0", "１", "1", "２", "2", "３", "3", "４", "4",
+		"５", "5", "６", "6", "７", "7", "８", "8", "９", "9",
+		"－", "-", "‐", "-", "−", "-", "：", ":", "＠", "@", "．", ".",
+		"［", "[", "］", "]", "（", "(", "）", ")", "　", " ",
+	)
+	obfuscatedAtPattern  = regexp.MustCompile(`(?i)\s*(?:\[\s*at\s*\]|\(\s*at\s*\)|\{\s*at\s*\}|<\s*at\s*>)\s*`)
+	obfuscatedDotPattern = regexp.MustCompile(`(?i)\s*(?:\[\s*dot\s*\]|\(\s*dot\s*\))\s*`)
+	emailPattern         = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	urlPattern           = regexp.MustCompile(`https?://[^\s<>"'、，,)]+`)
+	phonePattern         = regexp.MustCompile(`(?:\+81[\s-]?|\b0)\d{1,4}[\s-]\d{1,4}[\s-]\d{3,4}\b`)
+	extensionPattern     = regexp.MustCompile(`(?:内線|ext\.?|Ext\.?)\s*:?\s*(\d{3,5})`)
+)
+
+// ParseContacts extracts e-mail addresses, phone numbers and URLs from a contact section.
+// Obfuscated addresses such as "name[at]example.ac.jp" are restored. Entries on a line naming
+// one of teachers are attributed to that teacher; with a single teacher every entry is theirs.
+func ParseContacts(text string, teachers []Teacher) []ContactEntry {
+	text = contactWidthReplacer.Replace(text)
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	entries := make([]ContactEntry, 0)
+	seen := make(map[ContactEntry]struct{})
+	add := func(entry ContactEntry) {
+		key := ContactEntry{Kind: entry.Kind, Value: strings.ToLower(entry.Value)}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		entries = append(entries, entry)
+	}
+
+	current := ""
+	for _, line := range strings.Split(text, "\n") {
+		if name := teacherInLine(line, teachers); name != "" {
+			current = name
+		}
+		owner := current
+		if len(teachers) == 1 {
+			owner = strings.TrimSpace(teachers[0].Name)
+		}
+
+		urls := urlPattern.FindAllString(line, -1)
+		for _, u := range urls {
+			add(ContactEntry{Kind: ContactKindURL, Value: strings.TrimRight(u, "."), TeacherName: owner})
+		}
+		rest := urlPattern.ReplaceAllString(line, " ")
+
+		deobfuscated := obfuscatedDotPattern.ReplaceAllString(obfuscatedAtPattern.ReplaceAllString(rest, "@"), ".")
+		for _, email := range emailPattern.FindAllString(deobfuscated, -1) {
+			add(ContactEntry{Kind: ContactKindEmail, Value: strings.TrimRight(email, "."), TeacherName: owner})
+		}
+		rest = emailPattern.ReplaceAllString(deobfuscated, " ")
+
+		for _, phone := range phonePattern.FindAllString(rest, -1) {
+			value := strings.Join(strings.FieldsFunc(phone, func(r rune) bool { return r == ' ' || r == '-' }), "-")
+			add(ContactEntry{Kind: ContactKindPhone, Value: value, TeacherName: owner})
+		}
+		for _, match := range extensionPattern.FindAllStringSubmatch(rest, -1) {
+			add(ContactEntry{Kind: ContactKindPhone, Value: "ext. " + match[1], TeacherName: owner})
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+	return entries
+}
+
+// teacherInLine returns the teacher whose name appears in line, ignoring spaces.
+func teacherInLine(line string, teachers []Teacher) string {
+	compact := strings.NewReplacer(" ", "", "　", "").Replace(line)
+	for _, teacher := range teachers {
+		name := strings.NewReplacer(" ", "", "　", "").Replace(teacher.Name)
+		if name != "" && strings.Contains(compact, name) {
+			return strings.TrimSpace(teacher.Name)
+		}
+	}
+	return ""
+}
+
+var (
+	officeDayPattern = regexp.MustCompile(`(?i)(月|火|水|木|金|土|日)(曜日|曜)?|\b(mon(?:day)?|tue(?:s|sday)?|wed(?:nesday)?|thu(?:rs?|rsday)?|fri(?:day)?|sat(?:urday)?|sun(?:day)?)\b\.?`)
+	// officeDayDelimiters may surround a weekday kanji written without 曜, as in "月・水" or "(金) 12:00"
+	officeDayDelimiters = " \t・、,/()[]~〜～-"
+	officeTimePattern   = regexp.MustCompile(`(\d{1,2})\s*(?::|時)\s*(\d{2})?\s*分?\s*(?:-|~|〜|～|から|to)\s*(\d{1,2})\s*(?::|時)\s*(\d{2})?\s*分?`)
+	officeDayMap        = map[string]DayOfWeek{
+		"月": DayOfWeekMonday, "火": DayOfWeekTuesday, "水": DayOfWeekWednesday, "木": DayOfWeekThursday,
+		"金": DayOfWeekFriday, "土": DayOfWeekSaturday, "日": DayOfWeekSunday,
+		"mon": DayOfWeekMonday, "tue": DayOfWeekTuesday, "wed": DayOfWeekWednesday, "thu": DayOfWeekThursday,
+		"fri": DayOfWeekFriday, "sat": DayOfWeekSaturday, "sun": DayOfWeekSunday,
+	}
+)
+
+// ParseOfficeHours reads weekly ranges such as "月・水 12:00-13:00" or "Tuesday 15:00-16:30".
+// Lines without both a weekday and a time range, like "メールで予約", are skipped.
+func ParseOfficeHours(text string) []OfficeHour {
+	text = contactWidthReplacer.Replace(text)
+	hours := make([]OfficeHour, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		times := officeTimePattern.FindAllStringSubmatchIndex(line, -1)
+		if len(times) == 0 {
+			continue
+		}
+
+		// Days are taken from the text before each range, so "月 10:00-11:00 木 15:00-16:00" yields two days.
+		from := 0
+		for _, loc := range times {
+			days := officeDays(line[from:loc[0]])
+			from = loc[1]
+			start, okStart := clockTime(line, loc[2], loc[3], loc[4], loc[5])
+			end, okEnd := clockTime(line, loc[6], loc[7], loc[8], loc[9])
+			if !okStart || !okEnd || end <= start {
+				continue
+			}
+			for _, day := range days {
+				hours = append(hours, OfficeHour{DayOfWeek: day, Start: start, End: end, Note: line})
+			}
+		}
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+	return hours
+}
+
+func officeDays(text string) []DayOfWeek {
+	days := make([]DayOfWeek, 0)
+	seen := make(map[DayOfWeek]struct{})
+	for _, loc := range officeDayPattern.FindAllStringSubmatchIndex(text, -1) {
+		var key string
+		if loc[2] >= 0 {
+			// a kanji without 曜 must stand alone: "4月10日" is a date and "前日" or "日本語" are no weekdays
+			if loc[4] < 0 && !(officeDayDelimited(text[:loc[0]], true) && officeDayDelimited(text[loc[1]:], false)) {
+				continue
+			}
+			key = text[loc[2]:loc[3]]
+		} else {
+			key = strings.ToLower(text[loc[6] : loc[6]+3])
+		}
+		day, ok := officeDayMap[key]
+		if !ok {
+			continue
+		}
+		if _, dup := seen[day]; dup {
+			continue
+		}
+		seen[day] = struct{}{}
+		days = append(days, day)
+	}
+	return days
+}
+
+// officeDayDelimited reports whether the text before (or after) a weekday kanji ends (or starts) at a
+// delimiter, the edge of the text, or, after the kanji, at the digits of a time.
+func officeDayDelimited(text string, before bool) bool {
+	var r rune
+	if before {
+		r, _ = utf8.DecodeLastRuneInString(text)
+	} else {
+		r, _ = utf8.DecodeRuneInString(text)
+	}
+	if r == utf8.RuneError {
+		return true
+	}
+	if !before && r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune(officeDayDelimiters, r)
+}
+
+// clockTime formats the hour and optional minute submatches at the given offsets as "15:04".
+func clockTime(line string, hourStart, hourEnd, minuteStart, minuteEnd int) (string, bool) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(line[hourStart:hourEnd], "%d", &hour); err != nil {
+		return "", false
+	}
+	if minuteStart >= 0 {
+		if _, err := fmt.Sscanf(line[minuteStart:minuteEnd], "%d", &minute); err != nil {
+			return "", false
+		}
+	}
+	if hour > 24 || minute > 59 {
+		return "", false
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
+
+// WeekdayOf converts a time.Weekday to the DayOfWeek used by timetables.
+func WeekdayOf(day time.Weekday) DayOfWeek {
+	switch day {
+	case time.Monday:
+		return DayOfWeekMonday
+	case time.Tuesday:
+		return DayOfWeekTuesday
+	case time.Wednesday:
+		return DayOfWeekWednesday
+	case time.Thursday:
+		return DayOfWeekThursday
+	case time.Friday:
+		return DayOfWeekFriday
+	case time.Saturday:
+		return DayOfWeekSaturday
+	default:
+		return DayOfWeekSunday
+	}
+}
+
+// OfficeHoursOn returns the office hours held on day.
+func OfficeHoursOn(hours []OfficeHour, day DayOfWeek) []OfficeHour {
+	result := make([]OfficeHour, 0)
+	for _, hour := range hours {
+		if hour.DayOfWeek == day {
+			result = append(result, hour)
+		}
+	}
+	return result
+}
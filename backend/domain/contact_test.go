@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseContacts(t *testing.T) {
+	teachers := []Teacher{{Name: "山田 太郎"}, {Name: "鈴木 花子"}}
+	text := "山田 太郎: yamada.t.aa[at]m.isct.ac.jp / 03-5734-1234\n" +
+		"鈴木　花子：suzuki (at) example.ac.jp 内線２３４５\n" +
+		"研究室: https://example.ac.jp/lab\n" +
+		"yamada.t.aa@m.isct.ac.jp"
+
+	got := ParseContacts(text, teachers)
+	want := []ContactEntry{
+		{Kind: ContactKindEmail, Value: "yamada.t.aa@m.isct.ac.jp", TeacherName: "山田 太郎"},
+		{Kind: ContactKindPhone, Value: "03-5734-1234", TeacherName: "山田 太郎"},
+		{Kind: ContactKindEmail, Value: "suzuki@example.ac.jp", TeacherName: "鈴木 花子"},
+		{Kind: ContactKindPhone, Value: "ext. 2345", TeacherName: "鈴木 花子"},
+		{Kind: ContactKindURL, Value: "https://example.ac.jp/lab", TeacherName: "鈴木 花子"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	single := ParseContacts("連絡先 tanaka[at]example[dot]jp", []Teacher{{Name: "田中 一郎"}})
+	if len(single) != 1 || single[0].Value != "tanaka@example.jp" || single[0].TeacherName != "田中 一郎" {
+		t.Errorf("unexpected single teacher contacts: %+v", single)
+	}
+
+	if entries := ParseContacts("授業中に質問してください", teachers); entries != nil {
+		t.Errorf("expected no entries, got %+v", entries)
+	}
+}
+
+func TestContactEntryLink(t *testing.T) {
+	cases := map[ContactEntry]string{
+		{Kind: ContactKindEmail, Value: "a@example.jp"}:         "mailto:a@example.jp",
+		{Kind: ContactKindPhone, Value: "03-5734-1234"}:         "tel:0357341234",
+		{Kind: ContactKindURL, Value: "https://example.jp/lab"}: "https://example.jp/lab",
+	}
+	for entry, want := range cases {
+		if got := entry.Link(); got != want {
+			t.Errorf("%+v: expected %q, got %q", entry, want, got)
+		}
+	}
+}
+
+func TestParseOfficeHours(t *testing.T) {
+	text := "月・水 12:00-13:00\n" +
+		"火曜日 15時〜16時30分\n" +
+		"4月10日は休み\n" +
+		"Thursday 10:00 to 11:00, Fri 15:00-16:30\n" +
+		"メールで予約"
+
+	got := ParseOfficeHours(text)
+	want := []struct {
+		day        DayOfWeek
+		start, end string
+	}{
+		{DayOfWeekMonday, "12:00", "13:00"},
+		{DayOfWeekWednesday, "12:00", "13:00"},
+		{DayOfWeekTuesday, "15:00", "16:30"},
+		{DayOfWeekThursday, "10:00", "11:00"},
+		{DayOfWeekFriday, "15:00", "16:30"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d office hours, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].DayOfWeek != w.day || got[i].Start != w.start || got[i].End != w.end {
+			t.Errorf("office hour %d: expected %v %s-%s, got %+v", i, w.day, w.start, w.end, got[i])
+		}
+	}
+
+	// a lone 日 and words starting with a weekday abbreviation are not weekdays
+	for _, text := range []string{
+		"授業前日までに予約 12:00-13:00",
+		"日本語で対応 12:00-13:00",
+		"Once a month, 10:00-11:00",
+		"Sundries 10:00-11:00",
+	} {
+		if hours := ParseOfficeHours(text); hours != nil {
+			t.Errorf("%s: expected no office hours, got %+v", text, hours)
+		}
+	}
+	abbreviated := ParseOfficeHours("Tues., Thurs 10:00-11:00\n(日)10:00-11:00")
+	if len(abbreviated) != 3 || abbreviated[0].DayOfWeek != DayOfWeekTuesday || abbreviated[1].DayOfWeek != DayOfWeekThursday || abbreviated[2].DayOfWeek != DayOfWeekSunday {
+		t.Errorf("unexpected abbreviated office hours: %+v", abbreviated)
+	}
+
+	today := OfficeHoursOn(got, WeekdayOf(time.Date(2025, time.October, 8, 0, 0, 0, 0, time.UTC).Weekday()))
+	if len(today) != 1 || today[0].DayOfWeek != DayOfWeekWednesday {
+		t.Errorf("unexpected office hours on wednesday: %+v", today)
+	}
+}
@@ -0,0 +1,297 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CourseNode is a lecture in the course graph.
+type CourseNode struct {
+	LectureID int
+	Code      string
+	Title     string
+	Level     Level
+	Year      int
+}
+
+// CourseEdge points from a course to one that builds on it: From is named by To as a prerequisite,
+// recommended or related course.
+type CourseEdge struct {
+	From int
+	To   int
+	Kind CourseRelationKind
+}
+
+// CourseGraph is a directed graph of lectures linked by their course relations.
+type CourseGraph struct {
+	Nodes []CourseNode
+	Edges []CourseEdge
+
+	index    map[int]int
+	outgoing map[int][]int
+	incoming map[int][]int
+}
+
+// CourseGraphRepository loads the lectures and relations that make up the course graph.
+// A zero year selects every year and an empty kinds every relation kind.
+type CourseGraphRepository interface {
+	FindCourseGraph(year int, kinds []CourseRelationKind) (*CourseGraph, error)
+}
+
+// EdgeFromRelation turns a stored relation of a lecture into the edge leading into that lecture.
+func EdgeFromRelation(relation CourseRelation) CourseEdge {
+	return CourseEdge{From: relation.RelatedLectureID, To: relation.LectureID, Kind: relation.Kind}
+}
+
+// NewCourseGraph builds a graph from nodes and edges. Edges touching unknown nodes and
+// duplicate edges of the same kind are dropped.
+func NewCourseGraph(nodes []CourseNode, edges []CourseEdge) *CourseGraph {
+	g := &CourseGraph{
+		Nodes:    make([]CourseNode, 0, len(nodes)),
+		Edges:    make([]CourseEdge, 0, len(edges)),
+		index:    make(map[int]int, len(nodes)),
+		outgoing: make(map[int][]int),
+		incoming: make(map[int][]int),
+	}
+	for _, node := range nodes {
+		if _, ok := g.index[node.LectureID]; ok {
+			continue
+		}
+		g.index[node.LectureID] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	seen := make(map[CourseEdge]struct{})
+	linked := make(map[[2]int]struct{})
+	for _, edge := range edges {
+		if _, ok := g.index[edge.From]; !ok {
+			continue
+		}
+		if _, ok := g.index[edge.To]; !ok {
+			continue
+		}
+		if _, ok := seen[edge]; ok {
+			continue
+		}
+		seen[edge] = struct{}{}
+		g.Edges = append(g.Edges, edge)
+
+		pair := [2]int{edge.From, edge.To}
+		if _, ok := linked[pair]; ok {
+			continue
+		}
+		linked[pair] = struct{}{}
+		g.outgoing[edge.From] = append(g.outgoing[edge.From], edge.To)
+		g.incoming[edge.To] = append(g.incoming[edge.To], edge.From)
+	}
+	return g
+}
+
+// Node returns the node of a lecture.
+func (g *CourseGraph) Node(lectureID int) (CourseNode, bool) {
+	i, ok := g.index[lectureID]
+	if !ok {
+		return CourseNode{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// Ancestors returns every lecture that leads into lectureID, nearest first.
+func (g *CourseGraph) Ancestors(lectureID int) []int {
+	return g.reach(lectureID, g.incoming)
+}
+
+// Descendants returns every lecture that builds on lectureID, nearest first.
+func (g *CourseGraph) Descendants(lectureID int) []int {
+	return g.reach(lectureID, g.outgoing)
+}
+
+func (g *CourseGraph) reach(start int, next map[int][]int) []int {
+	result := make([]int, 0)
+	visited := map[int]struct{}{start: {}}
+	queue := []int{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range next[current] {
+			if _, ok := visited[neighbor]; ok {
+				continue
+			}
+			visited[neighbor] = struct{}{}
+			result = append(result, neighbor)
+			queue = append(queue, neighbor)
+		}
+	}
+	return result
+}
+
+// ShortestPath returns the lectures on a shortest path along the edges from one lecture to another,
+// both included, or nil when to cannot be reached.
+func (g *CourseGraph) ShortestPath(from, to int) []int {
+	if _, ok := g.index[from]; !ok {
+		return nil
+	}
+	if _, ok := g.index[to]; !ok {
+		return nil
+	}
+	if from == to {
+		return []int{from}
+	}
+
+	previous := map[int]int{from: from}
+	queue := []int{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range g.outgoing[current] {
+			if _, ok := previous[neighbor]; ok {
+				continue
+			}
+			previous[neighbor] = current
+			if neighbor == to {
+				path := []int{to}
+				for step := current; step != from; step = previous[step] {
+					path = append(path, step)
+				}
+				path = append(path, from)
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			queue = append(queue, neighbor)
+		}
+	}
+	return nil
+}
+
+// Subgraph keeps the given lectures and the edges between them.
+func (g *CourseGraph) Subgraph(lectureIDs []int) *CourseGraph {
+	nodes := make([]CourseNode, 0, len(lectureIDs))
+	for _, id := range lectureIDs {
+		if node, ok := g.Node(id); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return NewCourseGraph(nodes, g.Edges)
+}
+
+// CourseCycleError reports the cycles that keep a graph from having a study order.
+type CourseCycleError struct {
+	Cycles [][]int
+}
+
+func (e *CourseCycleError) Error() string {
+	return fmt.Sprintf("course graph has %d cycle(s)", len(e.Cycles))
+}
+
+// TopologicalOrder returns a study order in which every lecture comes after the ones leading into it.
+// Among lectures that are free to come next, lower levels, then codes, go first. A cyclic graph
+// returns a *CourseCycleError.
+func (g *CourseGraph) TopologicalOrder() ([]CourseNode, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &CourseCycleError{Cycles: cycles}
+	}
+
+	indegree := make(map[int]int, len(g.Nodes))
+	for _, node := range g.Nodes {
+		indegree[node.LectureID] = len(g.incoming[node.LectureID])
+	}
+	ready := make([]CourseNode, 0)
+	for _, node := range g.Nodes {
+		if indegree[node.LectureID] == 0 {
+			ready = append(ready, node)
+		}
+	}
+
+	order := make([]CourseNode, 0, len(g.Nodes))
+	for len(ready) > 0 {
+		sort.SliceStable(ready, func(i, j int) bool { return studyBefore(ready[i], ready[j]) })
+		current := ready[0]
+		ready = ready[1:]
+		order = append(order, current)
+		for _, next := range g.outgoing[current.LectureID] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				node, _ := g.Node(next)
+				ready = append(ready, node)
+			}
+		}
+	}
+	return order, nil
+}
+
+func studyBefore(a, b CourseNode) bool {
+	if a.Level != b.Level {
+		return a.Level < b.Level
+	}
+	if a.Code != b.Code {
+		return a.Code < b.Code
+	}
+	return a.LectureID < b.LectureID
+}
+
+// Cycles returns the strongly connected groups of lectures that lead into each other, including
+// lectures naming themselves. Each cycle lists its lectures in ascending ID order.
+func (g *CourseGraph) Cycles() [][]int {
+	// Tarjan's algorithm
+	index := 0
+	indices := make(map[int]int)
+	lowlink := make(map[int]int)
+	onStack := make(map[int]bool)
+	stack := make([]int, 0)
+	cycles := make([][]int, 0)
+
+	var connect func(v int)
+	connect = func(v int) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.outgoing[v] {
+			if _, ok := indices[w]; !ok {
+				connect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] != indices[v] {
+			return
+		}
+		component := make([]int, 0)
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		if len(component) > 1 || g.hasSelfLoop(v) {
+			sort.Ints(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if _, ok := indices[node.LectureID]; !ok {
+			connect(node.LectureID)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func (g *CourseGraph) hasSelfLoop(id int) bool {
+	for _, next := range g.outgoing[id] {
+		if next == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// courseEdgeStyles sets how each relation kind is drawn in DOT output.
+var courseEdgeStyles = map[CourseRelationKind]string{
+	CourseRelationPrerequisite: "solid",
+	CourseRelationRecommended:  "dashed",
+	CourseRelationRelated:      "dotted",
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, drawing lectures of the same level in one rank.
+func (g *CourseGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph courses {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	levels := make(map[Level][]int)
+	order := make([]Level, 0)
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "  n%d [label=%s];\n", node.LectureID, dotQuote(courseNodeLabel(node)))
+		if _, ok := levels[node.Level]; !ok {
+			order = append(order, node.Level)
+		}
+		levels[node.Level] = append(levels[node.Level], node.LectureID)
+	}
+	for _, level := range order {
+		if level == 0 || len(levels[level]) < 2 {
+			continue
+		}
+		b.WriteString("  { rank=same;")
+		for _, id := range levels[level] {
+			fmt.Fprintf(&b, " n%d;", id)
+		}
+		b.WriteString(" }\n")
+	}
+	for _, edge := range g.Edges {
+		style, ok := courseEdgeStyles[edge.Kind]
+		if !ok {
+			style = "solid"
+		}
+		fmt.Fprintf(&b, "  n%d -> n%d [style=%s, label=%s];\n", edge.From, edge.To, style, dotQuote(string(edge.Kind)))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func courseNodeLabel(node CourseNode) string {
+	if node.Code == "" {
+		return node.Title
+	}
+	return node.Code + "\n" + node.Title
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+type courseGraphJSON struct {
+	Nodes []courseNodeJSON `json:"nodes"`
+	Edges []courseEdgeJSON `json:"edges"`
+}
+
+type courseNodeJSON struct {
+	ID    int    `json:"id"`
+	Code  string `json:"code"`
+	Title string `json:"title"`
+	Level int    `json:"level"`
+	Year  int    `json:"year"`
+}
+
+type courseEdgeJSON struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// WriteJSON writes the graph as {"nodes": [...], "edges": [...]} for curriculum map renderers.
+func (g *CourseGraph) WriteJSON(w io.Writer) error {
+	out := courseGraphJSON{
+		Nodes: make([]courseNodeJSON, 0, len(g.Nodes)),
+		Edges: make([]courseEdgeJSON, 0, len(g.Edges)),
+	}
+	for _, node := range g.Nodes {
+		out.Nodes = append(out.Nodes, courseNodeJSON{ID: node.LectureID, Code: node.Code, Title: node.Title, Level: int(node.Level), Year: node.Year})
+	}
+	for _, edge := range g.Edges {
+		out.Edges = append(out.Edges, courseEdgeJSON{From: edge.From, To: edge.To, Kind: string(edge.Kind)})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
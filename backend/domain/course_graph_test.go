@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestCourseGraph builds calculus(1) -> linear(2) -> algebra(4) <- probability(3), algebra(4) -> topology(5).
+func newTestCourseGraph(extra ...CourseEdge) *CourseGraph {
+	nodes := []CourseNode{
+		{LectureID: 1, Code: "LAS.M102", Title: "微分積分第一", Level: LevelBachelor1},
+		{LectureID: 2, Code: "LAS.M101", Title: "線形代数第一", Level: LevelBachelor1},
+		{LectureID: 3, Code: "MTH.B201", Title: "確率と統計", Level: LevelBachelor2},
+		{LectureID: 4, Code: "MTH.A201", Title: "代数学", Level: LevelBachelor2},
+		{LectureID: 5, Code: "MTH.C301", Title: "位相空間論", Level: LevelBachelor3},
+	}
+	edges := append([]CourseEdge{
+		{From: 1, To: 2, Kind: CourseRelationPrerequisite},
+		{From: 2, To: 4, Kind: CourseRelationPrerequisite},
+		{From: 3, To: 4, Kind: CourseRelationRecommended},
+		{From: 4, To: 5, Kind: CourseRelationPrerequisite},
+		{From: 4, To: 99, Kind: CourseRelationPrerequisite},
+	}, extra...)
+	return NewCourseGraph(nodes, edges)
+}
+
+func TestCourseGraphClosure(t *testing.T) {
+	graph := newTestCourseGraph()
+	if len(graph.Edges) != 4 {
+		t.Fatalf("edges to unknown lectures must be dropped, got %+v", graph.Edges)
+	}
+	if got := graph.Ancestors(4); !reflect.DeepEqual(got, []int{2, 3, 1}) {
+		t.Errorf("unexpected ancestors: %v", got)
+	}
+	if got := graph.Descendants(2); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("unexpected descendants: %v", got)
+	}
+}
+
+func TestCourseGraphShortestPath(t *testing.T) {
+	graph := newTestCourseGraph(CourseEdge{From: 1, To: 4, Kind: CourseRelationRelated})
+	if got := graph.ShortestPath(1, 5); !reflect.DeepEqual(got, []int{1, 4, 5}) {
+		t.Errorf("unexpected path: %v", got)
+	}
+	if got := graph.ShortestPath(5, 1); got != nil {
+		t.Errorf("expected no path against the edges, got %v", got)
+	}
+}
+
+func TestCourseGraphTopologicalOrder(t *testing.T) {
+	order, err := newTestCourseGraph().TopologicalOrder()
+	if err != nil {
+		t.Fatalf("TopologicalOrder returned error: %v", err)
+	}
+	ids := make([]int, 0, len(order))
+	for _, node := range order {
+		ids = append(ids, node.LectureID)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("unexpected order: %v", ids)
+	}
+
+	cyclic := newTestCourseGraph(CourseEdge{From: 5, To: 2, Kind: CourseRelationRelated})
+	_, err = cyclic.TopologicalOrder()
+	var cycleErr *CourseCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if !reflect.DeepEqual(cycleErr.Cycles, [][]int{{2, 4, 5}}) {
+		t.Errorf("unexpected cycles: %v", cycleErr.Cycles)
+	}
+}
+
+func TestCourseGraphExport(t *testing.T) {
+	graph := newTestCourseGraph().Subgraph([]int{2, 3, 4})
+
+	var dot bytes.Buffer
+	if err := graph.WriteDOT(&dot); err != nil {
+		t.Fatalf("WriteDOT returned error: %v", err)
+	}
+	for _, want := range []string{
+		`n4 [label="MTH.A201\n代数学"];`,
+		`n2 -> n4 [style=solid, label="prerequisite"];`,
+		`n3 -> n4 [style=dashed, label="recommended"];`,
+		`{ rank=same; n3; n4; }`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output misses %q:\n%s", want, dot.String())
+		}
+	}
+
+	var raw bytes.Buffer
+	if err := graph.WriteJSON(&raw); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+	var decoded struct {
+		Nodes []struct {
+			ID    int    `json:"id"`
+			Title string `json:"title"`
+		} `json:"nodes"`
+		Edges []struct {
+			From int    `json:"from"`
+			To   int    `json:"to"`
+			Kind string `json:"kind"`
+		} `json:"edges"`
+	}
+	if err := json.Unmarshal(raw.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Nodes) != 3 || len(decoded.Edges) != 2 || decoded.Edges[1].Kind != "recommended" {
+		t.Errorf("unexpected JSON graph: %+v", decoded)
+	}
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CourseKey identifies a course across years by its code and normalized title, so yearly
+// lectures of the same course share it while a reused code with a new title does not.
+func CourseKey(code, title string) string {
+	return strings.ToUpper(strings.TrimSpace(code)) + "|" + NormalizeCourseTitle(title)
+}
+
+// CourseOffering is one year's lecture of a course, reduced to what is compared between years.
+type CourseOffering struct {
+	CourseKey  string
+	LectureID  int
+	Year       int
+	Code       string
+	Title      string
+	Quarters   []Semester
+	Teachers   []string
+	Rooms      []string
+	Credit     int
+	Assessment string
+}
+
+var semesterOrder = map[Semester]int{SemesterSpring: 1, SemesterSummer: 2, SemesterFall: 3, SemesterWinter: 4}
+
+// NewCourseOffering reduces a lecture to an offering. Quarters, teachers and rooms are sorted and deduplicated.
+func NewCourseOffering(lecture Lecture) CourseOffering {
+	offering := CourseOffering{
+		CourseKey:  CourseKey(lecture.Code, lecture.Title),
+		LectureID:  lecture.ID,
+		Year:       lecture.Year,
+		Code:       strings.TrimSpace(lecture.Code),
+		Title:      strings.TrimSpace(lecture.Title),
+		Credit:     lecture.Credit,
+		Assessment: strings.TrimSpace(lecture.Assessment),
+	}
+
+	quarters := make(map[Semester]struct{})
+	rooms := make(map[string]struct{})
+	for _, timetable := range lecture.Timetables {
+		if timetable.Semester != "" {
+			quarters[timetable.Semester] = struct{}{}
+		}
+		if name := normalizeComparableText(timetable.Room.Name); name != "" {
+			rooms[name] = struct{}{}
+		}
+	}
+	for quarter := range quarters {
+		offering.Quarters = append(offering.Quarters, quarter)
+	}
+	sort.Slice(offering.Quarters, func(i, j int) bool {
+		return semesterOrder[offering.Quarters[i]] < semesterOrder[offering.Quarters[j]]
+	})
+	for room := range rooms {
+		offering.Rooms = append(offering.Rooms, room)
+	}
+	sort.Strings(offering.Rooms)
+
+	teachers := make(map[string]struct{})
+	for _, teacher := range lecture.Teachers {
+		if name := normalizeComparableText(teacher.Name); name != "" {
+			teachers[name] = struct{}{}
+		}
+	}
+	for teacher := range teachers {
+		offering.Teachers = append(offering.Teachers, teacher)
+	}
+	sort.Strings(offering.Teachers)
+
+	return offering
+}
+
+// OfferingChange is a field that differs between two offerings. Added and Removed are set for list fields.
+type OfferingChange struct {
+	Field   string
+	Before  string
+	After   string
+	Added   []string
+	Removed []string
+}
+
+// OfferingComparison lists what changed in a course from one year's offering to another's.
+type OfferingComparison struct {
+	CourseKey     string
+	FromYear      int
+	ToYear        int
+	FromLectureID int
+	ToLectureID   int
+	Changes       []OfferingChange
+}
+
+// CompareOfferings reports the teacher, room, quarter, credit and assessment changes from one offering to another.
+func CompareOfferings(from, to CourseOffering) OfferingComparison {
+	comparison := OfferingComparison{
+		CourseKey:     to.CourseKey,
+		FromYear:      from.Year,
+		ToYear:        to.Year,
+		FromLectureID: from.LectureID,
+		ToLectureID:   to.LectureID,
+		Changes:       make([]OfferingChange, 0),
+	}
+
+	addList := func(field string, before, after []string) {
+		if change, ok := compareLists(field, before, after); ok {
+			comparison.Changes = append(comparison.Changes, change)
+		}
+	}
+	addList("teachers", from.Teachers, to.Teachers)
+	addList("rooms", from.Rooms, to.Rooms)
+	addList("quarters", semesterStrings(from.Quarters), semesterStrings(to.Quarters))
+
+	if from.Credit != to.Credit {
+		comparison.Changes = append(comparison.Changes, OfferingChange{Field: "credit", Before: strconv.Itoa(from.Credit), After: strconv.Itoa(to.Credit)})
+	}
+	if normalizeComparableText(from.Assessment) != normalizeComparableText(to.Assessment) {
+		comparison.Changes = append(comparison.Changes, OfferingChange{Field: "assessment", Before: from.Assessment, After: to.Assessment})
+	}
+
+	return comparison
+}
+
+// CompareConsecutiveOfferings compares each offering with the previous one of the same course,
+// expecting offerings sorted by year.
+func CompareConsecutiveOfferings(offerings []CourseOffering) []OfferingComparison {
+	comparisons := make([]OfferingComparison, 0)
+	last := make(map[string]CourseOffering)
+	for _, offering := range offerings {
+		if previous, ok := last[offering.CourseKey]; ok {
+			comparisons = append(comparisons, CompareOfferings(previous, offering))
+		}
+		last[offering.CourseKey] = offering
+	}
+	return comparisons
+}
+
+func compareLists(field string, before, after []string) (OfferingChange, bool) {
+	change := OfferingChange{Field: field, Before: strings.Join(before, ", "), After: strings.Join(after, ", ")}
+	inBefore := make(map[string]struct{}, len(before))
+	for _, item := range before {
+		inBefore[item] = struct{}{}
+	}
+	inAfter := make(map[string]struct{}, len(after))
+	for _, item := range after {
+		inAfter[item] = struct{}{}
+		if _, ok := inBefore[item]; !ok {
+			change.Added = append(change.Added, item)
+		}
+	}
+	for _, item := range before {
+		if _, ok := inAfter[item]; !ok {
+			change.Removed = append(change.Removed, item)
+		}
+	}
+	return change, len(change.Added) > 0 || len(change.Removed) > 0
+}
+
+func semesterStrings(semesters []Semester) []string {
+	result := make([]string, 0, len(semesters))
+	for _, semester := range semesters {
+		result = append(result, string(semester))
+	}
+	return result
+}
+
+// normalizeComparableText ignores whitespace differences between scraped texts.
+func normalizeComparableText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCourseKey(t *testing.T) {
+	if CourseKey("las.s101 ", "哲学Ａ　入門") != CourseKey("LAS.S101", "哲学A入門") {
+		t.Errorf("expected the same key for spacing and case differences")
+	}
+	if CourseKey("LAS.S101", "哲学A") == CourseKey("LAS.S101", "倫理学A") {
+		t.Errorf("expected a reused code with a new title to get a new key")
+	}
+}
+
+func TestCompareConsecutiveOfferings(t *testing.T) {
+	lecture := func(id, year, credit int, assessment string, teachers []string, room string, semesters ...Semester) Lecture {
+		l := Lecture{ID: id, Code: "LAH.S101", Title: "哲学A", Year: year, Credit: credit, Assessment: assessment}
+		for _, name := range teachers {
+			l.Teachers = append(l.Teachers, Teacher{Name: name})
+		}
+		for _, semester := range semesters {
+			l.Timetables = append(l.Timetables, TimeTable{Semester: semester, Room: Room{Name: room}})
+		}
+		return l
+	}
+
+	offerings := []CourseOffering{
+		NewCourseOffering(lecture(1, 2023, 2, "期末試験 100%", []string{"山田 太郎"}, "W9-321", SemesterSummer, SemesterSpring)),
+		NewCourseOffering(lecture(2, 2024, 2, "期末試験  100%", []string{"山田　太郎"}, "W9-321", SemesterSpring, SemesterSummer)),
+		NewCourseOffering(lecture(3, 2025, 1, "レポート 100%", []string{"山田 太郎", "鈴木 花子"}, "M-110", SemesterFall)),
+	}
+	if !reflect.DeepEqual(offerings[0].Quarters, []Semester{SemesterSpring, SemesterSummer}) {
+		t.Errorf("unexpected quarters: %v", offerings[0].Quarters)
+	}
+
+	comparisons := CompareConsecutiveOfferings(offerings)
+	if len(comparisons) != 2 {
+		t.Fatalf("expected 2 comparisons, got %+v", comparisons)
+	}
+	if len(comparisons[0].Changes) != 0 {
+		t.Errorf("expected no changes from 2023 to 2024, got %+v", comparisons[0].Changes)
+	}
+
+	changes := make(map[string]OfferingChange)
+	for _, change := range comparisons[1].Changes {
+		changes[change.Field] = change
+	}
+	if comparisons[1].FromYear != 2024 || comparisons[1].ToYear != 2025 || len(changes) != 5 {
+		t.Fatalf("unexpected comparison: %+v", comparisons[1])
+	}
+	if !reflect.DeepEqual(changes["teachers"].Added, []string{"鈴木 花子"}) || changes["teachers"].Removed != nil {
+		t.Errorf("unexpected teacher change: %+v", changes["teachers"])
+	}
+	if !reflect.DeepEqual(changes["quarters"].Removed, []string{"spring", "summer"}) {
+		t.Errorf("unexpected quarter change: %+v", changes["quarters"])
+	}
+	if changes["credit"].Before != "2" || changes["credit"].After != "1" {
+		t.Errorf("unexpected credit change: %+v", changes["credit"])
+	}
+	if changes["rooms"].After != "M-110" || changes["assessment"].After != "レポート 100%" {
+		t.Errorf("unexpected room or assessment change: %+v %+v", changes["rooms"], changes["assessment"])
+	}
+}
@@ -0,0 +1,318 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CourseRelationKind tells how a lecture refers to another course.
+type CourseRelationKind string
+
+const (
+	// CourseRelationRelated comes from the 関連する科目 list.
+	CourseRelationRelated CourseRelationKind = "related"
+	// CourseRelationPrerequisite is a course that must be taken first.
+	CourseRelationPrerequisite CourseRelationKind = "prerequisite"
+	// CourseRelationRecommended is a course that is desirable but not required.
+	CourseRelationRecommended CourseRelationKind = "recommended"
+)
+
+// CourseReference is a course mentioned in the prerequisite text, by code, by title or both.
+type CourseReference struct {
+	Kind  CourseRelationKind
+	Code  string
+	Title string
+}
+
+// CourseRelation links a lecture to another stored lecture.
+type CourseRelation struct {
+	LectureID        int
+	RelatedLectureID int
+	Kind             CourseRelationKind
+}
+
+var (
+	referenceCodePattern  = regexp.MustCompile(`\b[A-Z]{2,5}\.[A-Z]\d{3}(?:-\d{1,2})?\b`)
+	referenceTitlePattern = regexp.MustCompile(`[「『“"]([^」』”"]{2,60})[」』”"]`)
+	// clauses that merely exclude students, e.g. "〇〇を履修済みの者は履修不可", do not name prerequisites
+	exclusionPattern       = regexp.MustCompile(`(?i)不可|できない|できません|not (?:be )?(?:allowed|permitted)|cannot`)
+	recommendationPattern  = regexp.MustCompile(`(?i)望まし|推奨|勧め|すすめ|recommend|desirabl|preferabl|encourag`)
+	referenceSentenceSplit = regexp.MustCompile(`[。\n]|\.\s`)
+	// what is left of a clause that only lists courses, as in "「線形代数第一」、「線形代数第二」を…"
+	referenceListGlue   = regexp.MustCompile(`(?i)^(?:および|及び|ならびに|並びに|または|又は|もしくは|と|や|and|or|[\s()])*$`)
+	titleOrdinalPattern = regexp.MustCompile(`(?:第([一二三四五六七八九十]+)|(\d+)|\b([a-d]))\)?$`)
+	titleWidthReplacer  = strings.NewReplacer(
+		"（", "(", "）", ")", "　", "", " ", "", "・", "", "･", "",
+		"Ⅰ", "1", "Ⅱ", "2", "Ⅲ", "3", "Ⅳ", "4", "１", "1", "２", "2", "３", "3", "４", "4",
+	)
+)
+
+// ExtractCourseReferences reads the courses named in a prerequisite section.
+// Course codes such as "MTH.A201" and titles in 「」 or 『』 are picked up; a sentence saying the
+// course is desirable makes them recommended. Courses named in a clause that excludes students, such as
+// "MTH.B301と同時に履修することはできない", are skipped while the other clauses of the sentence count.
+func ExtractCourseReferences(text string) []CourseReference {
+	text = contactWidthReplacer.Replace(text)
+	references := make([]CourseReference, 0)
+	seen := make(map[CourseReference]struct{})
+	add := func(reference CourseReference) {
+		key := CourseReference{Code: reference.Code, Title: NormalizeCourseTitle(reference.Title)}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		references = append(references, reference)
+	}
+
+	for _, sentence := range referenceSentenceSplit.Split(text, -1) {
+		if strings.TrimSpace(sentence) == "" {
+			continue
+		}
+		kind := CourseRelationPrerequisite
+		if recommendationPattern.MatchString(sentence) {
+			kind = CourseRelationRecommended
+		}
+		for _, clause := range referenceClauses(sentence) {
+			if exclusionPattern.MatchString(clause) {
+				continue
+			}
+			for _, reference := range clauseReferences(clause) {
+				reference.Kind = kind
+				add(reference)
+			}
+		}
+	}
+
+	if len(references) == 0 {
+		return nil
+	}
+	return references
+}
+
+// clauseReferences returns the courses named in a clause, without their kind.
+func clauseReferences(clause string) []CourseReference {
+	references := make([]CourseReference, 0)
+	titled := make(map[string]struct{})
+	for _, match := range referenceTitlePattern.FindAllStringSubmatchIndex(clause, -1) {
+		title := strings.TrimSpace(clause[match[2]:match[3]])
+		// "「線形代数第一」(LAS.M101)" names the code right after the title
+		code := ""
+		after := strings.TrimLeft(clause[match[1]:], " (")
+		if loc := referenceCodePattern.FindStringIndex(after); loc != nil && loc[0] == 0 {
+			code = after[:loc[1]]
+		} else {
+			code = referenceCodePattern.FindString(title)
+			if code != "" {
+				title = strings.TrimSpace(strings.Trim(strings.Replace(title, code, "", 1), " ()"))
+			}
+		}
+		if code != "" {
+			titled[code] = struct{}{}
+		}
+		references = append(references, CourseReference{Code: code, Title: title})
+	}
+	for _, code := range referenceCodePattern.FindAllString(clause, -1) {
+		if _, ok := titled[code]; ok {
+			continue
+		}
+		references = append(references, CourseReference{Code: code})
+	}
+	return references
+}
+
+// referenceClauses splits a sentence at commas and semicolons outside quotes and parentheses. A clause
+// that only lists courses is joined to the next one, so "「A」、「B」を履修済みの者は履修不可" stays whole.
+func referenceClauses(sentence string) []string {
+	clauses := make([]string, 0)
+	var current strings.Builder
+	depth := 0
+	flush := func() {
+		clause := current.String()
+		current.Reset()
+		stripped := referenceCodePattern.ReplaceAllString(referenceTitlePattern.ReplaceAllString(clause, ""), "")
+		if strings.TrimSpace(clause) != "" && referenceListGlue.MatchString(stripped) {
+			// keep listing; the predicate of the listed courses comes later
+			current.WriteString(clause)
+			current.WriteString("、")
+			return
+		}
+		clauses = append(clauses, clause)
+	}
+	for _, r := range sentence {
+		switch r {
+		case '「', '『', '“', '(':
+			depth++
+		case '」', '』', '”', ')':
+			if depth > 0 {
+				depth--
+			}
+		case '、', ',', ';', '；':
+			if depth == 0 {
+				flush()
+				continue
+			}
+		}
+		current.WriteRune(r)
+	}
+	if rest := current.String(); strings.TrimSpace(rest) != "" {
+		clauses = append(clauses, rest)
+	}
+	return clauses
+}
+
+// NormalizeCourseTitle folds widths, spaces and roman numerals so titles written differently compare equal.
+func NormalizeCourseTitle(title string) string {
+	folded := strings.Map(func(r rune) rune {
+		// full-width ASCII such as "Ａ" or "（"
+		if r >= '！' && r <= '～' {
+			return r - 0xFEE0
+		}
+		return r
+	}, strings.TrimSpace(title))
+	return strings.ToLower(titleWidthReplacer.Replace(folded))
+}
+
+// CourseTitleSimilarity is the Dice coefficient of the character bigrams of two normalized titles, from 0 to 1.
+func CourseTitleSimilarity(a, b string) float64 {
+	ra := []rune(NormalizeCourseTitle(a))
+	rb := []rune(NormalizeCourseTitle(b))
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	if string(ra) == string(rb) {
+		return 1
+	}
+	// "線形代数第一" and "線形代数第二" share most bigrams but are different courses
+	if oa, ob := courseTitleOrdinal(string(ra)), courseTitleOrdinal(string(rb)); oa != "" && ob != "" && oa != ob {
+		return 0
+	}
+	if len(ra) < 2 || len(rb) < 2 {
+		return 0
+	}
+
+	bigrams := make(map[string]int)
+	for i := 0; i+1 < len(ra); i++ {
+		bigrams[string(ra[i:i+2])]++
+	}
+	shared := 0
+	for i := 0; i+1 < len(rb); i++ {
+		key := string(rb[i : i+2])
+		if bigrams[key] > 0 {
+			bigrams[key]--
+			shared++
+		}
+	}
+	return float64(2*shared) / float64(len(ra)-1+len(rb)-1)
+}
+
+func courseTitleOrdinal(normalized string) string {
+	match := titleOrdinalPattern.FindStringSubmatch(normalized)
+	if match == nil {
+		return ""
+	}
+	return match[1] + match[2] + match[3]
+}
+
+// CourseTitleMatchThreshold is the similarity above which a referenced title is taken to name a lecture.
+const CourseTitleMatchThreshold = 0.8
+
+// CourseTitleCandidate is a stored lecture a referenced title may name, under any of its titles.
+type CourseTitleCandidate struct {
+	LectureID int
+	Titles    []string
+}
+
+// MatchCourseTitle returns the lecture whose title is most similar to title, if any reaches
+// CourseTitleMatchThreshold. Ties keep the earlier candidate.
+func MatchCourseTitle(title string, candidates []CourseTitleCandidate) (int, bool) {
+	best, bestScore := 0, 0.0
+	for _, candidate := range candidates {
+		for _, candidateTitle := range candidate.Titles {
+			if score := CourseTitleSimilarity(title, candidateTitle); score > bestScore {
+				best, bestScore = candidate.LectureID, score
+			}
+		}
+	}
+	if bestScore < CourseTitleMatchThreshold {
+		return 0, false
+	}
+	return best, true
+}
+
+// CourseTitleIndex matches titles against many candidates. Only candidates with the same normalized title
+// or a character bigram in common can reach the threshold, so Match scores just those and returns what
+// MatchCourseTitle would return for the whole list.
+type CourseTitleIndex struct {
+	candidates []CourseTitleCandidate
+	exact      map[string][]int
+	bigrams    map[string][]int
+}
+
+// NewCourseTitleIndex indexes candidates in order; ties still keep the earlier candidate.
+func NewCourseTitleIndex(candidates []CourseTitleCandidate) *CourseTitleIndex {
+	index := &CourseTitleIndex{exact: make(map[string][]int), bigrams: make(map[string][]int)}
+	for _, candidate := range candidates {
+		index.Add(candidate)
+	}
+	return index
+}
+
+// Add appends a candidate to the index.
+func (i *CourseTitleIndex) Add(candidate CourseTitleCandidate) {
+	position := len(i.candidates)
+	i.candidates = append(i.candidates, candidate)
+	for _, title := range candidate.Titles {
+		normalized := NormalizeCourseTitle(title)
+		if normalized == "" {
+			continue
+		}
+		i.exact[normalized] = appendPosition(i.exact[normalized], position)
+		runes := []rune(normalized)
+		for j := 0; j+1 < len(runes); j++ {
+			key := string(runes[j : j+2])
+			i.bigrams[key] = appendPosition(i.bigrams[key], position)
+		}
+	}
+}
+
+// Match returns the candidate MatchCourseTitle would pick for title.
+func (i *CourseTitleIndex) Match(title string) (int, bool) {
+	if i == nil {
+		return 0, false
+	}
+	normalized := NormalizeCourseTitle(title)
+	if normalized == "" {
+		return 0, false
+	}
+	positions := make(map[int]struct{})
+	for _, position := range i.exact[normalized] {
+		positions[position] = struct{}{}
+	}
+	runes := []rune(normalized)
+	for j := 0; j+1 < len(runes); j++ {
+		for _, position := range i.bigrams[string(runes[j:j+2])] {
+			positions[position] = struct{}{}
+		}
+	}
+
+	ordered := make([]int, 0, len(positions))
+	for position := range positions {
+		ordered = append(ordered, position)
+	}
+	sort.Ints(ordered)
+	candidates := make([]CourseTitleCandidate, len(ordered))
+	for j, position := range ordered {
+		candidates[j] = i.candidates[position]
+	}
+	return MatchCourseTitle(title, candidates)
+}
+
+// appendPosition appends position unless it was the last one appended, which is how a candidate with a
+// bigram repeated across its titles would show up.
+func appendPosition(positions []int, position int) []int {
+	if n := len(positions); n > 0 && positions[n-1] == position {
+		return positions
+	}
+	return append(positions, position)
+}
@@ -0,0 +1,113 @@
+package domain
+
+import "testing"
+
+func TestExtractCourseReferences(t *testing.T) {
+	text := "「線形代数第一」(LAS.M101)および微分積分第一（LAS.M102）を履修していること。" +
+		"『確率と統計』を履修していることが望ましい。" +
+		"MTH.B301を履修済みの者は履修不可。\n" +
+		"Students are recommended to take \"Group Theory\"."
+
+	got := ExtractCourseReferences(text)
+	want := []CourseReference{
+		{Kind: CourseRelationPrerequisite, Code: "LAS.M101", Title: "線形代数第一"},
+		{Kind: CourseRelationPrerequisite, Code: "LAS.M102"},
+		{Kind: CourseRelationRecommended, Title: "確率と統計"},
+		{Kind: CourseRelationRecommended, Title: "Group Theory"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d references, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("reference %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	if refs := ExtractCourseReferences("特になし"); refs != nil {
+		t.Errorf("expected no references, got %+v", refs)
+	}
+}
+
+func TestExtractCourseReferencesExclusionClauses(t *testing.T) {
+	cases := []struct {
+		text string
+		want []CourseReference
+	}{
+		{
+			text: "「線形代数第一」を履修していること、ただしMTH.B301と同時に履修することはできない。",
+			want: []CourseReference{{Kind: CourseRelationPrerequisite, Title: "線形代数第一"}},
+		},
+		{
+			text: "「線形代数第一」、「線形代数第二」を履修済みの者は履修不可。",
+			want: nil,
+		},
+		{
+			text: "Students must have completed LAS.M101; students cannot take this course together with MTH.B301.",
+			want: []CourseReference{{Kind: CourseRelationPrerequisite, Code: "LAS.M101"}},
+		},
+		{
+			text: "「代数学(群と環)」を履修していることが望ましいが、MTH.A301を履修済みの者は履修できません。",
+			want: []CourseReference{{Kind: CourseRelationRecommended, Title: "代数学(群と環)"}},
+		},
+	}
+	for _, c := range cases {
+		got := ExtractCourseReferences(c.text)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: expected %+v, got %+v", c.text, c.want, got)
+			continue
+		}
+		for i := range c.want {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: reference %d: expected %+v, got %+v", c.text, i, c.want[i], got[i])
+			}
+		}
+	}
+}
+
+func TestMatchCourseTitle(t *testing.T) {
+	candidates := []CourseTitleCandidate{
+		{LectureID: 1, Titles: []string{"線形代数第二", "Linear Algebra II"}},
+		{LectureID: 2, Titles: []string{"線形代数 第一", "Linear Algebra I"}},
+		{LectureID: 3, Titles: []string{"確率論と統計学", ""}},
+	}
+
+	cases := []struct {
+		title string
+		want  int
+		ok    bool
+	}{
+		{"線形代数第一", 2, true},
+		{"線形代数Ⅱ", 0, false},
+		{"linear algebra ii", 1, true},
+		{"確率と統計", 0, false},
+	}
+	index := NewCourseTitleIndex(candidates)
+	for _, c := range cases {
+		got, ok := MatchCourseTitle(c.title, candidates)
+		if got != c.want || ok != c.ok {
+			t.Errorf("%s: expected (%d, %v), got (%d, %v)", c.title, c.want, c.ok, got, ok)
+		}
+		if got, ok := index.Match(c.title); got != c.want || ok != c.ok {
+			t.Errorf("%s: expected the index to return (%d, %v), got (%d, %v)", c.title, c.want, c.ok, got, ok)
+		}
+	}
+}
+
+func TestCourseTitleIndexKeepsEarlierCandidate(t *testing.T) {
+	index := NewCourseTitleIndex([]CourseTitleCandidate{
+		{LectureID: 4, Titles: []string{"情報理論"}},
+		{LectureID: 5, Titles: []string{"プログラミング"}},
+		{LectureID: 6, Titles: []string{"情報 理論"}},
+		{LectureID: 7, Titles: []string{"A"}},
+	})
+	if got, ok := index.Match("情報理論"); got != 4 || !ok {
+		t.Errorf("expected the first of the equal titles, got (%d, %v)", got, ok)
+	}
+	if got, ok := index.Match("ａ"); got != 7 || !ok {
+		t.Errorf("expected a one-letter title to match by its normalized form, got (%d, %v)", got, ok)
+	}
+	if _, ok := index.Match("線形代数"); ok {
+		t.Errorf("expected no match without a shared bigram")
+	}
+}
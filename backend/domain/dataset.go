@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatasetSchemaVersion is the version of the dataset layout written by WriteDataset. Lectures are stored
+// under the field names of Lecture, so renaming or retyping a Lecture field requires a new version.
+const DatasetSchemaVersion = 1
+
+// DatasetManifest describes a dataset: where and when it was scraped and what it contains.
+// It is the first line of the dataset file, so the json names are part of the dataset layout.
+type DatasetManifest struct {
+	SchemaVersion int       `json:"schema_version"`
+	Sites         []string  `json:"sites"`
+	Universities  []string  `json:"universities"`
+	Years         []int     `json:"years"`
+	ScrapedAt     time.Time `json:"scraped_at"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Lectures      int       `json:"lectures"`
+}
+
+// DatasetImportResult reports how a dataset was merged into the database. Updated counts lectures that
+// were already stored but whose dataset copy is newer; they are stored again like a re-scrape would.
+type DatasetImportResult struct {
+	Manifest DatasetManifest
+	Imported int
+	Updated  int
+	Skipped  int
+}
+
+// DatasetRepository provides what dataset export and import need beyond the lectures themselves.
+// FindDatasetKeys maps the DatasetLectureKey of every stored lecture to the stamp of its newest row.
+type DatasetRepository interface {
+	FindDatasetKeys() (map[string]LectureUpdateStamp, error)
+	FindDatasetSources() (sites []string, scrapedAt time.Time, err error)
+}
+
+// DatasetLectureKey identifies a lecture when merging datasets: its code, title, open term and year.
+func DatasetLectureKey(code, title, openTerm string, year int) string {
+	return strings.Join([]string{
+		strings.ToUpper(strings.TrimSpace(code)),
+		normalizeComparableText(title),
+		normalizeComparableText(openTerm),
+		strconv.Itoa(year),
+	}, "|")
+}
+
+// PortableLecture clears the ids that only mean something in the database the lecture was read from.
+// Relations between lectures are kept as course codes and resolved again on import.
+func PortableLecture(lecture Lecture) Lecture {
+	lecture.ID = 0
+	lecture.RelatedCourses = nil
+	lecture.CourseRelations = nil
+	timetables := make([]TimeTable, len(lecture.Timetables))
+	for i, timetable := range lecture.Timetables {
+		timetable.LectureID = 0
+		timetable.Room.ID = 0
+		timetables[i] = timetable
+	}
+	lecture.Timetables = timetables
+	teachers := make([]Teacher, len(lecture.Teachers))
+	for i, teacher := range lecture.Teachers {
+		teacher.ID = 0
+		teachers[i] = teacher
+	}
+	lecture.Teachers = teachers
+	return lecture
+}
+
+// datasetLine is one line of a dataset: the manifest on the first line, then one lecture per line.
+type datasetLine struct {
+	Manifest *DatasetManifest `json:"manifest,omitempty"`
+	Lecture  *Lecture         `json:"lecture,omitempty"`
+}
+
+// DatasetWriter writes a gzip-compressed NDJSON dataset.
+type DatasetWriter struct {
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+}
+
+// NewDatasetWriter starts a dataset by writing its manifest.
+func NewDatasetWriter(w io.Writer, manifest DatasetManifest) (*DatasetWriter, error) {
+	compressed := gzip.NewWriter(w)
+	writer := &DatasetWriter{gzip: compressed, encoder: json.NewEncoder(compressed)}
+	writer.encoder.SetEscapeHTML(false)
+	manifest.SchemaVersion = DatasetSchemaVersion
+	if err := writer.encoder.Encode(datasetLine{Manifest: &manifest}); err != nil {
+		return nil, fmt.Errorf("write dataset manifest: %w", err)
+	}
+	return writer, nil
+}
+
+// Write appends the portable form of a lecture.
+func (w *DatasetWriter) Write(lecture Lecture) error {
+	portable := PortableLecture(lecture)
+	if err := w.encoder.Encode(datasetLine{Lecture: &portable}); err != nil {
+		return fmt.Errorf("write dataset lecture: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the compressed stream; it does not close the underlying writer.
+func (w *DatasetWriter) Close() error {
+	return w.gzip.Close()
+}
+
+// ErrDatasetVersion is returned for datasets written by a newer version of the application.
+var ErrDatasetVersion = errors.New("dataset was written by a newer version; update the application to import it")
+
+// DatasetReader reads a dataset written by DatasetWriter.
+type DatasetReader struct {
+	Manifest DatasetManifest
+	gzip     *gzip.Reader
+	decoder  *json.Decoder
+}
+
+// NewDatasetReader reads and checks the manifest of a dataset.
+func NewDatasetReader(r io.Reader) (*DatasetReader, error) {
+	compressed, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("open dataset: %w", err)
+	}
+	reader := &DatasetReader{gzip: compressed, decoder: json.NewDecoder(compressed)}
+
+	var line datasetLine
+	if err := reader.decoder.Decode(&line); err != nil {
+		return nil, fmt.Errorf("read dataset manifest: %w", err)
+	}
+	if line.Manifest == nil {
+		return nil, errors.New("dataset has no manifest")
+	}
+	if line.Manifest.SchemaVersion > DatasetSchemaVersion {
+		return nil, ErrDatasetVersion
+	}
+	reader.Manifest = *line.Manifest
+	return reader, nil
+}
+
+// Next returns the next lecture, or io.EOF after the last one.
+func (r *DatasetReader) Next() (*Lecture, error) {
+	for {
+		var line datasetLine
+		if err := r.decoder.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("read dataset lecture: %w", err)
+		}
+		if line.Lecture != nil {
+			return line.Lecture, nil
+		}
+	}
+}
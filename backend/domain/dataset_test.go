@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestDatasetRoundTrip(t *testing.T) {
+	manifest := DatasetManifest{
+		Sites:        []string{"isct"},
+		Universities: []string{"Test University"},
+		Years:        []int{2024, 2025},
+		ScrapedAt:    time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC),
+		Lectures:     1,
+	}
+	lecture := Lecture{
+		ID:             12,
+		University:     "Test University",
+		Title:          "情報理論",
+		Code:           "CSC.T351",
+		Year:           2025,
+		Teachers:       []Teacher{{ID: 4, Name: "山田 太郎"}},
+		Timetables:     []TimeTable{{LectureID: 12, Semester: SemesterFall, DayOfWeek: DayOfWeekTuesday, Period: 5, Room: Room{ID: 9, Name: "W241"}}},
+		Keywords:       []string{"符号 & <情報>"},
+		RelatedCourses: []int{3},
+	}
+
+	var b bytes.Buffer
+	writer, err := NewDatasetWriter(&b, manifest)
+	if err != nil {
+		t.Fatalf("NewDatasetWriter returned error: %v", err)
+	}
+	if err := writer.Write(lecture); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if lecture.Teachers[0].ID != 4 {
+		t.Fatalf("expected the written lecture to be left unchanged")
+	}
+
+	reader, err := NewDatasetReader(&b)
+	if err != nil {
+		t.Fatalf("NewDatasetReader returned error: %v", err)
+	}
+	if reader.Manifest.SchemaVersion != DatasetSchemaVersion || !reader.Manifest.ScrapedAt.Equal(manifest.ScrapedAt) || len(reader.Manifest.Years) != 2 {
+		t.Errorf("unexpected manifest: %+v", reader.Manifest)
+	}
+
+	read, err := reader.Next()
+	if err != nil {
+		t.Fatalf("Next returned error: %v", err)
+	}
+	if read.ID != 0 || read.RelatedCourses != nil || read.Teachers[0].ID != 0 || read.Timetables[0].LectureID != 0 || read.Timetables[0].Room.ID != 0 {
+		t.Errorf("expected database ids to be cleared, got %+v", read)
+	}
+	if read.Code != "CSC.T351" || read.Keywords[0] != "符号 & <情報>" || read.Timetables[0].Room.Name != "W241" {
+		t.Errorf("unexpected lecture: %+v", read)
+	}
+	if _, err := reader.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestDatasetReaderRejectsNewerVersion(t *testing.T) {
+	var b bytes.Buffer
+	compressed := gzip.NewWriter(&b)
+	_, _ = io.WriteString(compressed, `{"manifest":{"schema_version":99}}`+"\n")
+	_ = compressed.Close()
+
+	if _, err := NewDatasetReader(&b); !errors.Is(err, ErrDatasetVersion) {
+		t.Errorf("expected ErrDatasetVersion, got %v", err)
+	}
+}
+
+func TestDatasetLectureKey(t *testing.T) {
+	if DatasetLectureKey(" csc.t351", "情報  理論", "3Q", 2025) != DatasetLectureKey("CSC.T351", "情報 理論", " 3Q ", 2025) {
+		t.Errorf("expected keys to ignore case and whitespace")
+	}
+	if DatasetLectureKey("CSC.T351", "情報理論", "3Q", 2025) == DatasetLectureKey("CSC.T351", "情報理論", "3Q", 2024) {
+		t.Errorf("expected different years to have different keys")
+	}
+}
@@ -0,0 +1,438 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RequirementKind tells what a graduation requirement counts.
+type RequirementKind string
+
+const (
+	// RequirementKindCredits needs a minimum number of credits from matching courses.
+	RequirementKindCredits RequirementKind = "credits"
+	// RequirementKindCourses needs a minimum number of matching courses.
+	RequirementKindCourses RequirementKind = "courses"
+	// RequirementKindMandatory needs every listed course code.
+	RequirementKindMandatory RequirementKind = "mandatory"
+)
+
+// RequirementCondition restricts the courses a requirement counts. Field is "code", "level" or
+// "department"; Op is "=", "^=" (prefix), ">=" or "<=". A course matches when any value matches.
+type RequirementCondition struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+// Requirement is one line of a requirement set.
+type Requirement struct {
+	Name       string
+	Kind       RequirementKind
+	Minimum    int
+	Courses    []string
+	Conditions []RequirementCondition
+}
+
+// RequirementSet is a named set of graduation requirements together with the source it was parsed from.
+type RequirementSet struct {
+	Name         string
+	Source       string
+	Requirements []Requirement
+}
+
+// RequirementSetRepository stores requirement sets by name.
+type RequirementSetRepository interface {
+	Save(set *RequirementSet) error
+	FindByName(name string) (*RequirementSet, error)
+	FindAll() ([]RequirementSet, error)
+	Delete(name string) error
+}
+
+// ParseRequirementSet reads the requirement DSL. Each non-empty line not starting with "#" is one of
+//
+//	requirement "総単位" credits >= 124
+//	requirement "数学系専門" credits >= 60 where code ^= "MTH."
+//	requirement "300番台" courses >= 4 where code ^= "MTH." | "MCS." and level >= 3
+//	requirement "必修" mandatory "MTH.A201" "MTH.A202"
+//
+// Conditions are joined by "and"; "|" lists alternatives of one condition. Levels use the Level numbers.
+func ParseRequirementSet(name, source string) (*RequirementSet, error) {
+	set := &RequirementSet{Name: strings.TrimSpace(name), Source: source}
+	names := make(map[string]struct{})
+	for number, line := range strings.Split(source, "\n") {
+		tokens, err := tokenizeRequirement(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		requirement, err := parseRequirement(tokens)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		if _, ok := names[requirement.Name]; ok {
+			return nil, fmt.Errorf("line %d: duplicate requirement %q", number+1, requirement.Name)
+		}
+		names[requirement.Name] = struct{}{}
+		set.Requirements = append(set.Requirements, requirement)
+	}
+	if len(set.Requirements) == 0 {
+		return nil, fmt.Errorf("requirement set %q has no requirements", set.Name)
+	}
+	return set, nil
+}
+
+type requirementToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeRequirement(line string) ([]requirementToken, error) {
+	tokens := make([]requirementToken, 0)
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '#':
+			return tokens, nil
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, requirementToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		case strings.ContainsRune("<>=^|", r):
+			end := i + 1
+			for end < len(runes) && strings.ContainsRune("<>=", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, requirementToken{text: string(runes[i:end])})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("\"#<>=^|", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, requirementToken{text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+func parseRequirement(tokens []requirementToken) (Requirement, error) {
+	var requirement Requirement
+	if tokens[0].quoted || tokens[0].text != "requirement" {
+		return requirement, fmt.Errorf("expected \"requirement\", got %q", tokens[0].text)
+	}
+	if len(tokens) < 3 || !tokens[1].quoted || strings.TrimSpace(tokens[1].text) == "" {
+		return requirement, fmt.Errorf("expected a quoted requirement name")
+	}
+	requirement.Name = strings.TrimSpace(tokens[1].text)
+
+	rest := tokens[2:]
+	switch kind := RequirementKind(rest[0].text); kind {
+	case RequirementKindCredits, RequirementKindCourses:
+		if len(rest) < 3 || rest[1].text != ">=" {
+			return requirement, fmt.Errorf("expected %q >= <number>", kind)
+		}
+		minimum, err := strconv.Atoi(rest[2].text)
+		if err != nil || minimum <= 0 {
+			return requirement, fmt.Errorf("invalid minimum %q", rest[2].text)
+		}
+		requirement.Kind = kind
+		requirement.Minimum = minimum
+		rest = rest[3:]
+	case RequirementKindMandatory:
+		requirement.Kind = kind
+		rest = rest[1:]
+		for len(rest) > 0 && rest[0].quoted {
+			requirement.Courses = append(requirement.Courses, strings.ToUpper(strings.TrimSpace(rest[0].text)))
+			rest = rest[1:]
+		}
+		if len(requirement.Courses) == 0 {
+			return requirement, fmt.Errorf("mandatory requirement %q lists no courses", requirement.Name)
+		}
+		requirement.Minimum = len(requirement.Courses)
+	default:
+		return requirement, fmt.Errorf("unknown requirement kind %q", rest[0].text)
+	}
+
+	if len(rest) == 0 {
+		return requirement, nil
+	}
+	if rest[0].quoted || rest[0].text != "where" {
+		return requirement, fmt.Errorf("expected \"where\", got %q", rest[0].text)
+	}
+	conditions, err := parseRequirementConditions(rest[1:])
+	if err != nil {
+		return requirement, err
+	}
+	requirement.Conditions = conditions
+	return requirement, nil
+}
+
+func parseRequirementConditions(tokens []requirementToken) ([]RequirementCondition, error) {
+	conditions := make([]RequirementCondition, 0)
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete condition")
+		}
+		condition := RequirementCondition{Field: tokens[0].text, Op: tokens[1].text}
+		switch condition.Field {
+		case "code", "department":
+			if condition.Op != "=" && condition.Op != "^=" {
+				return nil, fmt.Errorf("%s supports = and ^=, got %q", condition.Field, condition.Op)
+			}
+		case "level":
+			if condition.Op != "=" && condition.Op != ">=" && condition.Op != "<=" {
+				return nil, fmt.Errorf("level supports =, >= and <=, got %q", condition.Op)
+			}
+		default:
+			return nil, fmt.Errorf("unknown condition field %q", condition.Field)
+		}
+
+		tokens = tokens[2:]
+		for {
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("expected a value for %s", condition.Field)
+			}
+			value := tokens[0]
+			if condition.Field == "level" {
+				if _, err := strconv.Atoi(value.text); err != nil {
+					return nil, fmt.Errorf("invalid level %q", value.text)
+				}
+			} else if !value.quoted {
+				return nil, fmt.Errorf("expected a quoted value for %s, got %q", condition.Field, value.text)
+			}
+			condition.Values = append(condition.Values, value.text)
+			tokens = tokens[1:]
+			if len(tokens) == 0 || tokens[0].quoted || tokens[0].text != "|" {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		conditions = append(conditions, condition)
+
+		if len(tokens) == 0 {
+			break
+		}
+		if tokens[0].quoted || tokens[0].text != "and" {
+			return nil, fmt.Errorf("expected \"and\", got %q", tokens[0].text)
+		}
+		tokens = tokens[1:]
+	}
+	return conditions, nil
+}
+
+// RequirementCourse is the part of a lecture that requirements look at.
+type RequirementCourse struct {
+	LectureID  int
+	Code       string
+	Title      string
+	Department string
+	Level      Level
+	Credit     int
+}
+
+// CourseFromLecture reduces a lecture to what requirements look at.
+func CourseFromLecture(lecture Lecture) RequirementCourse {
+	return RequirementCourse{LectureID: lecture.ID, Code: lecture.Code, Title: lecture.Title, Department: lecture.Department, Level: lecture.Level, Credit: lecture.Credit}
+}
+
+// CourseFromSummary reduces a lecture summary to what requirements look at.
+func CourseFromSummary(summary LectureSummary) RequirementCourse {
+	return RequirementCourse{LectureID: summary.ID, Code: summary.Code, Title: summary.Title, Department: summary.Department, Level: summary.Level, Credit: summary.Credit}
+}
+
+// Matches reports whether the course satisfies every condition of the requirement.
+// Mandatory requirements match their listed codes only.
+func (r Requirement) Matches(course RequirementCourse) bool {
+	code := strings.ToUpper(strings.TrimSpace(course.Code))
+	if r.Kind == RequirementKindMandatory {
+		for _, mandatory := range r.Courses {
+			if mandatory == code {
+				return true
+			}
+		}
+		return false
+	}
+	for _, condition := range r.Conditions {
+		if !condition.matches(course, code) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c RequirementCondition) matches(course RequirementCourse, code string) bool {
+	for _, value := range c.Values {
+		switch c.Field {
+		case "code":
+			value = strings.ToUpper(strings.TrimSpace(value))
+			if (c.Op == "=" && code == value) || (c.Op == "^=" && strings.HasPrefix(code, value)) {
+				return true
+			}
+		case "department":
+			department := strings.TrimSpace(course.Department)
+			if (c.Op == "=" && department == value) || (c.Op == "^=" && strings.HasPrefix(department, value)) {
+				return true
+			}
+		case "level":
+			level, _ := strconv.Atoi(value)
+			current := int(course.Level)
+			if current == 0 {
+				continue
+			}
+			if (c.Op == "=" && current == level) || (c.Op == ">=" && current >= level) || (c.Op == "<=" && current <= level) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequirementResult is the evaluation of one requirement. Completed and Planned are credits, courses
+// or mandatory courses depending on Kind.
+type RequirementResult struct {
+	Name           string
+	Kind           RequirementKind
+	Minimum        int
+	Completed      int
+	Planned        int
+	Met            bool
+	MetByCompleted bool
+	CountedCourses []int
+	MissingCourses []string
+	Suggestions    []RequirementCourse
+}
+
+// Remaining is how much the completed and planned courses still fall short.
+func (r RequirementResult) Remaining() int {
+	return max(0, r.Minimum-r.Completed-r.Planned)
+}
+
+// RequirementReport is the evaluation of a requirement set.
+type RequirementReport struct {
+	SetName string
+	Met     bool
+	Results []RequirementResult
+}
+
+// Unmet returns the results still short after counting planned courses.
+func (r RequirementReport) Unmet() []RequirementResult {
+	unmet := make([]RequirementResult, 0)
+	for _, result := range r.Results {
+		if !result.Met {
+			unmet = append(unmet, result)
+		}
+	}
+	return unmet
+}
+
+// EvaluateRequirements checks completed and planned courses against set. A course code is counted once,
+// as completed when it is in both lists. For unmet requirements up to maxSuggestions candidates that would
+// count toward them are suggested, lower levels first.
+func EvaluateRequirements(set *RequirementSet, completed, planned, candidates []RequirementCourse, maxSuggestions int) RequirementReport {
+	report := RequirementReport{Met: true}
+	if set == nil {
+		return report
+	}
+	report.SetName = set.Name
+
+	taken := make(map[string]bool)
+	courses := make([]RequirementCourse, 0, len(completed)+len(planned))
+	add := func(list []RequirementCourse, done bool) {
+		for _, course := range list {
+			key := takenKey(course)
+			if _, ok := taken[key]; ok {
+				continue
+			}
+			taken[key] = done
+			courses = append(courses, course)
+		}
+	}
+	add(completed, true)
+	add(planned, false)
+
+	sortedCandidates := append([]RequirementCourse(nil), candidates...)
+	sort.SliceStable(sortedCandidates, func(i, j int) bool {
+		if sortedCandidates[i].Level != sortedCandidates[j].Level {
+			return sortedCandidates[i].Level < sortedCandidates[j].Level
+		}
+		return sortedCandidates[i].Code < sortedCandidates[j].Code
+	})
+
+	for _, requirement := range set.Requirements {
+		result := RequirementResult{Name: requirement.Name, Kind: requirement.Kind, Minimum: requirement.Minimum}
+		matchedCodes := make(map[string]struct{})
+		for _, course := range courses {
+			if !requirement.Matches(course) {
+				continue
+			}
+			amount := 1
+			if requirement.Kind == RequirementKindCredits {
+				amount = course.Credit
+			}
+			if taken[takenKey(course)] {
+				result.Completed += amount
+			} else {
+				result.Planned += amount
+			}
+			result.CountedCourses = append(result.CountedCourses, course.LectureID)
+			matchedCodes[strings.ToUpper(strings.TrimSpace(course.Code))] = struct{}{}
+		}
+		if requirement.Kind == RequirementKindMandatory {
+			for _, code := range requirement.Courses {
+				if _, ok := matchedCodes[code]; !ok {
+					result.MissingCourses = append(result.MissingCourses, code)
+				}
+			}
+		}
+		result.Met = result.Completed+result.Planned >= result.Minimum
+		result.MetByCompleted = result.Completed >= result.Minimum
+
+		if !result.Met && maxSuggestions > 0 {
+			suggested := make(map[string]struct{})
+			for _, candidate := range sortedCandidates {
+				key := takenKey(candidate)
+				if _, ok := taken[key]; ok {
+					continue
+				}
+				if _, ok := suggested[key]; ok || !requirement.Matches(candidate) {
+					continue
+				}
+				suggested[key] = struct{}{}
+				result.Suggestions = append(result.Suggestions, candidate)
+				if len(result.Suggestions) >= maxSuggestions {
+					break
+				}
+			}
+		}
+
+		if !result.Met {
+			report.Met = false
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// takenKey identifies a course across years by its code, or by its lecture when it has none.
+func takenKey(course RequirementCourse) string {
+	if code := strings.ToUpper(strings.TrimSpace(course.Code)); code != "" {
+		return code
+	}
+	return "#" + strconv.Itoa(course.LectureID)
+}
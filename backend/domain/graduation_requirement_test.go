@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testRequirementSource = `# 数学系 学士課程
+requirement "総単位" credits >= 8
+requirement "数学系専門" credits >= 4 where code ^= "MTH." | "MCS."
+requirement "300番台" courses >= 1 where code ^= "MTH." and level >= 3
+requirement "必修" mandatory "MTH.A201" "mth.a202"
+requirement "数学系開講" credits >= 2 where department = "数学系"
+`
+
+func TestParseRequirementSet(t *testing.T) {
+	set, err := ParseRequirementSet("数学系", testRequirementSource)
+	if err != nil {
+		t.Fatalf("ParseRequirementSet returned error: %v", err)
+	}
+	if len(set.Requirements) != 5 {
+		t.Fatalf("expected 5 requirements, got %+v", set.Requirements)
+	}
+
+	specialized := set.Requirements[1]
+	if specialized.Kind != RequirementKindCredits || specialized.Minimum != 4 {
+		t.Errorf("unexpected requirement: %+v", specialized)
+	}
+	wantConditions := []RequirementCondition{{Field: "code", Op: "^=", Values: []string{"MTH.", "MCS."}}}
+	if !reflect.DeepEqual(specialized.Conditions, wantConditions) {
+		t.Errorf("unexpected conditions: %+v", specialized.Conditions)
+	}
+	if len(set.Requirements[2].Conditions) != 2 || set.Requirements[2].Kind != RequirementKindCourses {
+		t.Errorf("unexpected level requirement: %+v", set.Requirements[2])
+	}
+	mandatory := set.Requirements[3]
+	if !reflect.DeepEqual(mandatory.Courses, []string{"MTH.A201", "MTH.A202"}) || mandatory.Minimum != 2 {
+		t.Errorf("unexpected mandatory requirement: %+v", mandatory)
+	}
+}
+
+func TestParseRequirementSetErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                             "no requirements",
+		`requirement "a" credits >= x`: "line 1: invalid minimum",
+		`requirement "a" credits >= 2 where room = "W9"`:                 "unknown condition field",
+		`requirement "a" mandatory`:                                      "lists no courses",
+		`requirement "a" credits >= 2 where code ^= MTH`:                 "expected a quoted value",
+		"requirement \"a\" credits >= 2\nrequirement \"a\" courses >= 1": "line 2: duplicate requirement",
+		`requirement "a credits >= 2`:                                    "unterminated string",
+		`requirement "a" credits >= 2 where level >= 3 or level <= 1`:    `expected "and"`,
+	}
+	for source, want := range cases {
+		_, err := ParseRequirementSet("test", source)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected error containing %q, got %v", source, want, err)
+		}
+	}
+}
+
+func TestEvaluateRequirements(t *testing.T) {
+	set, err := ParseRequirementSet("数学系", testRequirementSource)
+	if err != nil {
+		t.Fatalf("ParseRequirementSet returned error: %v", err)
+	}
+
+	completed := []RequirementCourse{
+		{LectureID: 1, Code: "MTH.A201", Department: "数学系", Level: LevelBachelor2, Credit: 2},
+		{LectureID: 2, Code: "LAH.S101", Department: "文系教養科目", Level: LevelBachelor1, Credit: 2},
+	}
+	planned := []RequirementCourse{
+		{LectureID: 3, Code: "MCS.T201", Department: "情報理工学系", Level: LevelBachelor2, Credit: 2},
+		// the same course in another year counts once, as completed
+		{LectureID: 4, Code: "mth.a201", Department: "数学系", Level: LevelBachelor2, Credit: 2},
+	}
+	candidates := []RequirementCourse{
+		{LectureID: 10, Code: "MTH.C301", Department: "数学系", Level: LevelBachelor3, Credit: 2},
+		{LectureID: 11, Code: "MTH.A202", Department: "数学系", Level: LevelBachelor2, Credit: 2},
+		{LectureID: 12, Code: "MTH.A201", Department: "数学系", Level: LevelBachelor2, Credit: 2},
+	}
+
+	report := EvaluateRequirements(set, completed, planned, candidates, 5)
+	if report.Met {
+		t.Fatalf("expected unmet requirements")
+	}
+	results := make(map[string]RequirementResult)
+	for _, result := range report.Results {
+		results[result.Name] = result
+	}
+
+	total := results["総単位"]
+	if total.Completed != 4 || total.Planned != 2 || total.Met || total.Remaining() != 2 {
+		t.Errorf("unexpected total: %+v", total)
+	}
+	specialized := results["数学系専門"]
+	if !specialized.Met || specialized.MetByCompleted || !reflect.DeepEqual(specialized.CountedCourses, []int{1, 3}) {
+		t.Errorf("unexpected specialized result: %+v", specialized)
+	}
+	upper := results["300番台"]
+	if upper.Met || len(upper.Suggestions) != 1 || upper.Suggestions[0].LectureID != 10 {
+		t.Errorf("unexpected 300-level result: %+v", upper)
+	}
+	mandatory := results["必修"]
+	if mandatory.Met || !reflect.DeepEqual(mandatory.MissingCourses, []string{"MTH.A202"}) ||
+		len(mandatory.Suggestions) != 1 || mandatory.Suggestions[0].LectureID != 11 {
+		t.Errorf("unexpected mandatory result: %+v", mandatory)
+	}
+	if !results["数学系開講"].MetByCompleted {
+		t.Errorf("expected department requirement to be met: %+v", results["数学系開講"])
+	}
+
+	unmet := report.Unmet()
+	if len(unmet) != 3 {
+		t.Errorf("expected 3 unmet requirements, got %+v", unmet)
+	}
+	if len(total.Suggestions) == 0 || total.Suggestions[0].LectureID != 11 {
+		t.Errorf("expected lower levels to be suggested first, got %+v", total.Suggestions)
+	}
+}
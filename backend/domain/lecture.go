@@ -38,6 +38,22 @@ type Lecture struct {
 	Keywords           []string
 	RelatedCourseCodes []string
 	RelatedCourses     []int
+	// CourseReferences are the courses named in Prerequisite; CourseRelations are the stored lectures
+	// they and RelatedCourses resolve to, typed by how they are referred to.
+	CourseReferences []CourseReference
+	CourseRelations  []CourseRelation
+	// Contacts and OfficeHourSlots are read from the Contact and OfficeHours text.
+	Contacts        []ContactEntry
+	OfficeHourSlots []OfficeHour
+
+	// English fields hold the English version of the syllabus; they are empty when it was not scraped.
+	EnglishDepartment   string
+	EnglishAbstract     string
+	EnglishGoal         string
+	EnglishFlow         string
+	EnglishAssessment   string
+	EnglishKeywords     []string
+	EnglishLecturePlans []LecturePlan
 }
 
 type LectureSummary struct {
@@ -49,8 +65,10 @@ type LectureSummary struct {
 	Level      Level
 	Credit     int
 	Year       int
+	OpenTerm   string
 	Timetables []TimeTable
 	Teachers   []Teacher
+	Bookmarked bool
 }
 
 type LectureType string
@@ -97,9 +115,39 @@ type LecturePlan struct {
 	Count      int
 	Plan       string
 	Assignment string
+	Goal       string
+	Date       time.Time
+	Kind       LecturePlanKind
+	Exam       ExamType
+}
+
+type LecturePlanKind string
+
+const (
+	LecturePlanKindLecture      LecturePlanKind = "lecture"
+	LecturePlanKindExam         LecturePlanKind = "exam"
+	LecturePlanKindPresentation LecturePlanKind = "presentation"
+	LecturePlanKindLab          LecturePlanKind = "lab"
+)
+
+type ExamType string
+
+const (
+	ExamTypeMidterm ExamType = "midterm"
+	ExamTypeFinal   ExamType = "final"
+)
+
+// LectureSession is a single lecture plan entry together with the lecture it belongs to.
+type LectureSession struct {
+	LectureID int
+	Code      string
+	Title     string
+	Year      int
+	Plan      LecturePlan
 }
 
 type SearchQuery struct {
+	University        string
 	Title             string
 	Keywords          []string
 	Departments       []string
@@ -108,17 +156,30 @@ type SearchQuery struct {
 	Room              string
 	Semester          []Semester
 	TimeTables        []TimeTable
+	TimeTableKinds    []TimeTableKind
+	ExcludeKinds      []TimeTableKind
 	Levels            []Level
+	PlanTopic         string
 	FilterNotResearch bool
+	// ExcludePassed hides lectures whose course is passed on the stored transcript.
+	ExcludePassed bool
+	// BookmarkedOnly hides lectures the user has not bookmarked.
+	BookmarkedOnly bool
+	// Tags keeps lectures the user has given any of the tags.
+	Tags []string
 }
 
 type LectureRepository interface {
 	FindByID(id int) (*Lecture, error)
 	FindByCode(code, title, openTerm string) (*Lecture, error)
+	FindAllByCode(code string) ([]Lecture, error)
 	Search(query SearchQuery) ([]LectureSummary, error)
 	Create(lecture *Lecture) error
 	Creates(lectures []Lecture) error
 	Update(lecture *Lecture) error
 	Delete(id int) error
 	MigrateRelatedCourses(ctx context.Context) (int, error)
+	MigrateCourseReferences(ctx context.Context) (int, error)
+	FindSessions(lectureIDs []int, kinds []LecturePlanKind) ([]LectureSession, error)
+	FindByTeacher(teacherID int, year int) ([]LectureSummary, error)
 }
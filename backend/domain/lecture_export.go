@@ -0,0 +1,367 @@
+package domain
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportColumn describes a column of exported lectures. ID names it in column selections and JSON keys;
+// Header is the spreadsheet header.
+type ExportColumn struct {
+	ID     string
+	Header string
+	value  func(lecture Lecture) any
+}
+
+// exportTimetable is the JSON form of a timetable entry; spreadsheets get its String form.
+type exportTimetable struct {
+	Kind      string `json:"kind,omitempty"`
+	Semester  string `json:"semester"`
+	DayOfWeek string `json:"day_of_week"`
+	Period    int    `json:"period"`
+	Room      string `json:"room,omitempty"`
+}
+
+func (t exportTimetable) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %d %s", t.Semester, t.DayOfWeek, t.Period, t.Room))
+}
+
+// exportLecturePlan is the JSON form of a lecture plan entry; spreadsheets get one String line per entry.
+type exportLecturePlan struct {
+	Count      int    `json:"count"`
+	Plan       string `json:"plan"`
+	Assignment string `json:"assignment,omitempty"`
+}
+
+func (p exportLecturePlan) String() string {
+	if p.Assignment == "" {
+		return fmt.Sprintf("%d: %s", p.Count, p.Plan)
+	}
+	return fmt.Sprintf("%d: %s / %s", p.Count, p.Plan, p.Assignment)
+}
+
+func planColumn(id, header string, value func(lecture Lecture) []LecturePlan) ExportColumn {
+	return ExportColumn{ID: id, Header: header, value: func(lecture Lecture) any {
+		plans := make([]exportLecturePlan, 0)
+		for _, plan := range value(lecture) {
+			plans = append(plans, exportLecturePlan{
+				Count:      plan.Count,
+				Plan:       strings.TrimSpace(plan.Plan),
+				Assignment: strings.TrimSpace(plan.Assignment),
+			})
+		}
+		return plans
+	}}
+}
+
+func textColumn(id, header string, value func(lecture Lecture) string) ExportColumn {
+	return ExportColumn{ID: id, Header: header, value: func(lecture Lecture) any { return value(lecture) }}
+}
+
+func numberColumn(id, header string, value func(lecture Lecture) int) ExportColumn {
+	return ExportColumn{ID: id, Header: header, value: func(lecture Lecture) any { return value(lecture) }}
+}
+
+func listColumn(id, header string, value func(lecture Lecture) []string) ExportColumn {
+	return ExportColumn{ID: id, Header: header, value: func(lecture Lecture) any {
+		if items := value(lecture); items != nil {
+			return items
+		}
+		return []string{}
+	}}
+}
+
+// ExportColumns lists every exportable column in the default order.
+var ExportColumns = []ExportColumn{
+	numberColumn("id", "ID", func(l Lecture) int { return l.ID }),
+	textColumn("university", "大学", func(l Lecture) string { return l.University }),
+	numberColumn("year", "年度", func(l Lecture) int { return l.Year }),
+	textColumn("code", "科目コード", func(l Lecture) string { return l.Code }),
+	textColumn("title", "科目名", func(l Lecture) string { return l.Title }),
+	textColumn("english_title", "英語科目名", func(l Lecture) string { return l.EnglishTitle }),
+	textColumn("department", "開講元", func(l Lecture) string { return l.Department }),
+	textColumn("lecture_type", "授業形態", func(l Lecture) string { return string(l.LectureType) }),
+	numberColumn("level", "レベル", func(l Lecture) int { return int(l.Level) }),
+	numberColumn("credit", "単位数", func(l Lecture) int { return l.Credit }),
+	textColumn("open_term", "開講時期", func(l Lecture) string { return l.OpenTerm }),
+	textColumn("language", "使用言語", func(l Lecture) string { return l.Language }),
+	listColumn("teachers", "担当教員", func(l Lecture) []string {
+		names := make([]string, 0, len(l.Teachers))
+		for _, teacher := range l.Teachers {
+			names = append(names, teacher.Name)
+		}
+		return names
+	}),
+	{ID: "timetables", Header: "曜日・時限", value: func(l Lecture) any {
+		timetables := make([]exportTimetable, 0, len(l.Timetables))
+		for _, timetable := range l.Timetables {
+			timetables = append(timetables, exportTimetable{
+				Kind:      string(timetable.Kind),
+				Semester:  string(timetable.Semester),
+				DayOfWeek: string(timetable.DayOfWeek),
+				Period:    int(timetable.Period),
+				Room:      timetable.Room.Name,
+			})
+		}
+		return timetables
+	}},
+	listColumn("keywords", "キーワード", func(l Lecture) []string { return l.Keywords }),
+	listColumn("related_courses", "関連科目", func(l Lecture) []string { return l.RelatedCourseCodes }),
+	textColumn("url", "URL", func(l Lecture) string { return l.Url }),
+	textColumn("abstract", "講義の概要とねらい", func(l Lecture) string { return l.Abstract }),
+	textColumn("goal", "到達目標", func(l Lecture) string { return l.Goal }),
+	textColumn("experience", "実務経験", func(l Lecture) string { return l.Experience }),
+	textColumn("flow", "授業の進め方", func(l Lecture) string { return l.Flow }),
+	planColumn("lecture_plans", "授業計画・課題", func(l Lecture) []LecturePlan { return l.LecturePlans }),
+	textColumn("out_of_class_work", "授業時間外学修", func(l Lecture) string { return l.OutOfClassWork }),
+	textColumn("textbook", "教科書", func(l Lecture) string { return l.Textbook }),
+	textColumn("reference_book", "参考書", func(l Lecture) string { return l.ReferenceBook }),
+	textColumn("assessment", "成績評価の方法及び基準", func(l Lecture) string { return l.Assessment }),
+	textColumn("prerequisite", "履修の条件", func(l Lecture) string { return l.Prerequisite }),
+	textColumn("contact", "連絡先", func(l Lecture) string { return l.Contact }),
+	textColumn("office_hours", "オフィスアワー", func(l Lecture) string { return l.OfficeHours }),
+	textColumn("note", "その他", func(l Lecture) string { return l.Note }),
+	textColumn("updated_at", "更新日", func(l Lecture) string {
+		if l.UpdatedAt.IsZero() {
+			return ""
+		}
+		return l.UpdatedAt.Format(time.DateOnly)
+	}),
+	textColumn("english_department", "Department", func(l Lecture) string { return l.EnglishDepartment }),
+	textColumn("english_abstract", "Course description and aims", func(l Lecture) string { return l.EnglishAbstract }),
+	textColumn("english_goal", "Student learning outcomes", func(l Lecture) string { return l.EnglishGoal }),
+	textColumn("english_assessment", "Assessment criteria and methods", func(l Lecture) string { return l.EnglishAssessment }),
+	planColumn("english_lecture_plans", "Course schedule/Required learning", func(l Lecture) []LecturePlan { return l.EnglishLecturePlans }),
+	listColumn("english_keywords", "Keywords", func(l Lecture) []string { return l.EnglishKeywords }),
+}
+
+// DefaultExportColumns are exported when no columns are selected.
+var DefaultExportColumns = []string{"year", "code", "title", "department", "level", "credit", "teachers", "timetables", "keywords", "url"}
+
+// SelectExportColumns returns the columns with the given ids in the given order, or the default columns
+// when ids is empty. Unknown ids are an error.
+func SelectExportColumns(ids []string) ([]ExportColumn, error) {
+	if len(ids) == 0 {
+		ids = DefaultExportColumns
+	}
+	byID := make(map[string]ExportColumn, len(ExportColumns))
+	for _, column := range ExportColumns {
+		byID[column.ID] = column
+	}
+
+	columns := make([]ExportColumn, 0, len(ids))
+	for _, id := range ids {
+		column, ok := byID[strings.ToLower(strings.TrimSpace(id))]
+		if !ok {
+			return nil, fmt.Errorf("unknown export column %q", id)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// cell returns the spreadsheet form of a column value: lists are joined with "; " and lecture plans
+// take a line each.
+func (c ExportColumn) cell(lecture Lecture) string {
+	switch value := c.value(lecture).(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case []string:
+		return strings.Join(value, "; ")
+	case []exportTimetable:
+		slots := make([]string, 0, len(value))
+		for _, timetable := range value {
+			slots = append(slots, timetable.String())
+		}
+		return strings.Join(slots, "; ")
+	case []exportLecturePlan:
+		lines := make([]string, 0, len(value))
+		for _, plan := range value {
+			lines = append(lines, plan.String())
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// WriteLecturesCSV writes lectures as UTF-8 CSV with a byte order mark, so that Excel detects the encoding.
+func WriteLecturesCSV(w io.Writer, lectures []Lecture, columns []ExportColumn) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.Header)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, lecture := range lectures {
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			record = append(record, column.cell(lecture))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteLecturesJSONL writes one JSON object per lecture, keyed by column id. Lists stay arrays and
+// timetables are objects.
+func WriteLecturesJSONL(w io.Writer, lectures []Lecture, columns []ExportColumn) error {
+	for _, lecture := range lectures {
+		var b strings.Builder
+		b.WriteByte('{')
+		for i, column := range columns {
+			key, err := json.Marshal(column.ID)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(column.value(lecture))
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(value)
+		}
+		b.WriteString("}\n")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteLecturesXLSX writes lectures as a single-sheet Office Open XML workbook. Numeric columns are
+// written as numbers and everything else as inline strings, so no shared string table is needed.
+func WriteLecturesXLSX(w io.Writer, lectures []Lecture, columns []ExportColumn) error {
+	archive := zip.NewWriter(w)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	} {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if err := writeXLSXSheet(sheet, lectures, columns); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func writeXLSXSheet(w io.Writer, lectures []Lecture, columns []ExportColumn) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	writeRow := func(row int, cells func(index int, ref string)) {
+		fmt.Fprintf(&b, `<row r="%d">`, row)
+		for index := range columns {
+			cells(index, xlsxColumnName(index)+strconv.Itoa(row))
+		}
+		b.WriteString(`</row>`)
+	}
+	writeString := func(ref, value string) {
+		fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		_ = xml.EscapeText(&b, []byte(xlsxSafeText(value)))
+		b.WriteString(`</t></is></c>`)
+	}
+
+	writeRow(1, func(index int, ref string) { writeString(ref, columns[index].Header) })
+	for i, lecture := range lectures {
+		writeRow(i+2, func(index int, ref string) {
+			if number, ok := columns[index].value(lecture).(int); ok {
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, number)
+				return
+			}
+			writeString(ref, columns[index].cell(lecture))
+		})
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// xlsxColumnName converts a zero-based column index to its spreadsheet letters: 0 is A, 26 is AA.
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxSafeText drops control characters that XML 1.0 cannot carry and caps cells at Excel's 32767 characters.
+func xlsxSafeText(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, value)
+	if runes := []rune(value); len(runes) > 32767 {
+		value = string(runes[:32767])
+	}
+	return value
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="lectures" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="1"><fill><patternFill patternType="none"/></fill></fills>` +
+	`<borders count="1"><border/></borders>` +
+	`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+	`<cellXfs count="1"><xf/></cellXfs>` +
+	`</styleSheet>`
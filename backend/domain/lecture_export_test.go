@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func exportTestLectures() []Lecture {
+	return []Lecture{{
+		ID:       7,
+		Year:     2025,
+		Code:     "CSC.T351",
+		Title:    "情報理論",
+		Credit:   2,
+		Teachers: []Teacher{{Name: "山田 太郎"}, {Name: "佐藤 花子"}},
+		Timetables: []TimeTable{
+			{Semester: SemesterFall, DayOfWeek: DayOfWeekTuesday, Period: 5, Room: Room{Name: "W241"}},
+		},
+		Keywords: []string{"符号", "エントロピー"},
+		Abstract: "a \"quoted\", multi-line\nabstract & <tag>",
+		LecturePlans: []LecturePlan{
+			{Count: 1, Plan: "イントロダクション", Assignment: "シラバスを読む"},
+			{Count: 2, Plan: "情報量"},
+		},
+	}}
+}
+
+func TestSelectExportColumns(t *testing.T) {
+	columns, err := SelectExportColumns(nil)
+	if err != nil || len(columns) != len(DefaultExportColumns) {
+		t.Fatalf("expected default columns, got %d, %v", len(columns), err)
+	}
+	if _, err := SelectExportColumns([]string{"code", "unknown"}); err == nil {
+		t.Errorf("expected an unknown column error")
+	}
+}
+
+func TestWriteLecturesCSV(t *testing.T) {
+	columns, _ := SelectExportColumns([]string{"code", "teachers", "timetables", "abstract"})
+	var b bytes.Buffer
+	if err := WriteLecturesCSV(&b, exportTestLectures(), columns); err != nil {
+		t.Fatalf("WriteLecturesCSV returned error: %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "\ufeff") {
+		t.Fatalf("expected a byte order mark")
+	}
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(b.String(), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := []string{"CSC.T351", "山田 太郎; 佐藤 花子", "fall tuesday 5 W241", "a \"quoted\", multi-line\nabstract & <tag>"}
+	if len(records) != 2 || records[0][0] != "科目コード" {
+		t.Fatalf("unexpected records: %q", records)
+	}
+	for i, value := range want {
+		if records[1][i] != value {
+			t.Errorf("column %d: expected %q, got %q", i, value, records[1][i])
+		}
+	}
+}
+
+func TestWriteLecturesCSVLecturePlans(t *testing.T) {
+	columns, _ := SelectExportColumns([]string{"flow", "lecture_plans", "english_lecture_plans"})
+	var b bytes.Buffer
+	if err := WriteLecturesCSV(&b, exportTestLectures(), columns); err != nil {
+		t.Fatalf("WriteLecturesCSV returned error: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(b.String(), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if records[0][0] != "授業の進め方" || records[0][1] != "授業計画・課題" {
+		t.Errorf("unexpected headers: %q", records[0])
+	}
+	if want := "1: イントロダクション / シラバスを読む\n2: 情報量"; records[1][1] != want {
+		t.Errorf("expected %q, got %q", want, records[1][1])
+	}
+	if records[1][2] != "" {
+		t.Errorf("expected no English plans, got %q", records[1][2])
+	}
+}
+
+func TestWriteLecturesJSONL(t *testing.T) {
+	columns, _ := SelectExportColumns([]string{"id", "keywords", "timetables", "english_keywords", "lecture_plans"})
+	var b bytes.Buffer
+	if err := WriteLecturesJSONL(&b, append(exportTestLectures(), Lecture{ID: 8}), columns); err != nil {
+		t.Fatalf("WriteLecturesJSONL returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", b.String())
+	}
+	if !strings.HasPrefix(lines[0], `{"id":7,`) {
+		t.Errorf("expected keys in column order, got %s", lines[0])
+	}
+
+	var row struct {
+		ID              int
+		Keywords        []string
+		EnglishKeywords []string `json:"english_keywords"`
+		Timetables      []struct {
+			DayOfWeek string `json:"day_of_week"`
+			Period    int
+		}
+		LecturePlans []struct {
+			Count      int
+			Plan       string
+			Assignment string
+		} `json:"lecture_plans"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &row); err != nil {
+		t.Fatalf("decode line: %v", err)
+	}
+	if row.ID != 7 || len(row.Keywords) != 2 || row.EnglishKeywords == nil || row.Timetables[0].DayOfWeek != "tuesday" || row.Timetables[0].Period != 5 {
+		t.Errorf("unexpected row: %+v", row)
+	}
+	if len(row.LecturePlans) != 2 || row.LecturePlans[0].Assignment != "シラバスを読む" || row.LecturePlans[1].Plan != "情報量" {
+		t.Errorf("unexpected lecture plans: %+v", row.LecturePlans)
+	}
+}
+
+func TestWriteLecturesXLSX(t *testing.T) {
+	columns, _ := SelectExportColumns([]string{"credit", "title", "abstract"})
+	var b bytes.Buffer
+	if err := WriteLecturesXLSX(&b, exportTestLectures(), columns); err != nil {
+		t.Fatalf("WriteLecturesXLSX returned error: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("open workbook: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		parts[file.Name] = string(content)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, fragment := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">単位数</t></is></c>`,
+		`<c r="A2"><v>2</v></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">情報理論</t></is></c>`,
+		`abstract &amp; &lt;tag&gt;`,
+	} {
+		if !strings.Contains(sheet, fragment) {
+			t.Errorf("expected %s in sheet:\n%s", fragment, sheet)
+		}
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumnName(index); got != want {
+			t.Errorf("%d: expected %s, got %s", index, want, got)
+		}
+	}
+}
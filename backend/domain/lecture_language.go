@@ -0,0 +1,61 @@
+package domain
+
+import "strings"
+
+// Syllabus languages accepted by Lecture.Localized.
+const (
+	SyllabusLanguageJapanese = "ja"
+	SyllabusLanguageEnglish  = "en"
+)
+
+// Localized returns a copy of the lecture whose main fields are shown in lang.
+// For English, every field with an English version is replaced by it and the rest stay Japanese;
+// any other language returns the lecture unchanged.
+func (l Lecture) Localized(lang string) Lecture {
+	if !strings.EqualFold(strings.TrimSpace(lang), SyllabusLanguageEnglish) {
+		return l
+	}
+
+	pick := func(japanese, english string) string {
+		if strings.TrimSpace(english) != "" {
+			return english
+		}
+		return japanese
+	}
+	l.Title = pick(l.Title, l.EnglishTitle)
+	l.Department = pick(l.Department, l.EnglishDepartment)
+	l.Abstract = pick(l.Abstract, l.EnglishAbstract)
+	l.Goal = pick(l.Goal, l.EnglishGoal)
+	l.Flow = pick(l.Flow, l.EnglishFlow)
+	l.Assessment = pick(l.Assessment, l.EnglishAssessment)
+	if len(l.EnglishKeywords) > 0 {
+		l.Keywords = l.EnglishKeywords
+	}
+	if len(l.EnglishLecturePlans) > 0 {
+		l.LecturePlans = mergeEnglishPlans(l.LecturePlans, l.EnglishLecturePlans)
+	}
+	return l
+}
+
+// mergeEnglishPlans takes the text of the English plans and fills dates and kinds
+// the English page lacks from the Japanese plan of the same count.
+func mergeEnglishPlans(japanese, english []LecturePlan) []LecturePlan {
+	byCount := make(map[int]LecturePlan, len(japanese))
+	for _, plan := range japanese {
+		byCount[plan.Count] = plan
+	}
+	merged := make([]LecturePlan, 0, len(english))
+	for _, plan := range english {
+		if original, ok := byCount[plan.Count]; ok {
+			if plan.Date.IsZero() {
+				plan.Date = original.Date
+			}
+			if original.Kind != "" {
+				plan.Kind = original.Kind
+				plan.Exam = original.Exam
+			}
+		}
+		merged = append(merged, plan)
+	}
+	return merged
+}
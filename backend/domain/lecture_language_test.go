@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLectureLocalized(t *testing.T) {
+	date := time.Date(2025, time.October, 3, 0, 0, 0, 0, time.UTC)
+	lecture := Lecture{
+		Title:        "代数学",
+		EnglishTitle: "Algebra",
+		Department:   "数学系",
+		Abstract:     "群論の基礎",
+		Goal:         "群を理解する",
+		Keywords:     []string{"群"},
+		LecturePlans: []LecturePlan{
+			{Count: 1, Plan: "群の定義", Date: date},
+			{Count: 2, Plan: "期末試験", Kind: LecturePlanKindExam, Exam: ExamTypeFinal},
+		},
+		EnglishAbstract: "Basics of group theory",
+		EnglishKeywords: []string{"group"},
+		EnglishLecturePlans: []LecturePlan{
+			{Count: 1, Plan: "Definition of groups", Kind: LecturePlanKindLecture},
+			{Count: 2, Plan: "Final examination", Kind: LecturePlanKindExam},
+		},
+	}
+
+	if got := lecture.Localized("ja"); got.Title != "代数学" || got.Abstract != "群論の基礎" {
+		t.Errorf("japanese lecture must be unchanged: %+v", got)
+	}
+
+	english := lecture.Localized("EN")
+	if english.Title != "Algebra" || english.Abstract != "Basics of group theory" {
+		t.Errorf("unexpected english fields: %+v", english)
+	}
+	if english.Department != "数学系" || english.Goal != "群を理解する" {
+		t.Errorf("fields without english version must fall back to japanese: %+v", english)
+	}
+	if len(english.Keywords) != 1 || english.Keywords[0] != "group" {
+		t.Errorf("unexpected keywords: %v", english.Keywords)
+	}
+	if len(english.LecturePlans) != 2 || english.LecturePlans[0].Plan != "Definition of groups" || !english.LecturePlans[0].Date.Equal(date) {
+		t.Errorf("unexpected first plan: %+v", english.LecturePlans)
+	}
+	if english.LecturePlans[1].Exam != ExamTypeFinal {
+		t.Errorf("exam type must come from the japanese plan: %+v", english.LecturePlans[1])
+	}
+	if lecture.LecturePlans[0].Plan != "群の定義" {
+		t.Errorf("Localized must not modify the receiver")
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// LectureUpdateStamp is the identity and update date of a stored lecture, enough to decide whether its
+// detail page has to be fetched again without loading the lecture.
+type LectureUpdateStamp struct {
+	LectureID int
+	Code      string
+	Title     string
+	OpenTerm  string
+	Year      int
+	UpdatedAt time.Time
+}
+
+// LectureUpdateStampRepository is optionally implemented by a LectureRepository to load the stamps of
+// every stored lecture in one query.
+type LectureUpdateStampRepository interface {
+	FindUpdateStamps() ([]LectureUpdateStamp, error)
+}
+
+// LectureUpdateIndex looks up stored lectures by the code, title and open term of a course list row.
+type LectureUpdateIndex struct {
+	stamps map[string]LectureUpdateStamp
+}
+
+// NewLectureUpdateIndex indexes stamps. A lookup matches the code case-insensitively and the title and
+// open term with whitespace collapsed. Re-scrapes store a lecture again under a new id, so the stamp with
+// the highest lecture id wins; the older rows would otherwise keep reporting the lecture stale.
+func NewLectureUpdateIndex(stamps []LectureUpdateStamp) *LectureUpdateIndex {
+	index := &LectureUpdateIndex{stamps: make(map[string]LectureUpdateStamp, len(stamps))}
+	for _, stamp := range stamps {
+		key := lectureUpdateKey(stamp.Code, stamp.Title, stamp.OpenTerm)
+		if existing, ok := index.stamps[key]; !ok || stamp.LectureID > existing.LectureID {
+			index.stamps[key] = stamp
+		}
+	}
+	return index
+}
+
+// Find returns the stamp of the stored lecture matching a list row, or nil when it is not stored.
+func (i *LectureUpdateIndex) Find(code, title, openTerm string) *LectureUpdateStamp {
+	if i == nil || strings.TrimSpace(code) == "" {
+		return nil
+	}
+	stamp, ok := i.stamps[lectureUpdateKey(code, title, openTerm)]
+	if !ok {
+		return nil
+	}
+	return &stamp
+}
+
+// Len returns the number of indexed lectures.
+func (i *LectureUpdateIndex) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.stamps)
+}
+
+func lectureUpdateKey(code, title, openTerm string) string {
+	return strings.ToUpper(strings.TrimSpace(code)) + "\x00" + strings.Join(strings.Fields(title), " ") + "\x00" + strings.Join(strings.Fields(openTerm), " ")
+}
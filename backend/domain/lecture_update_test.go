@@ -0,0 +1,27 @@
+package domain
+
+import "testing"
+
+func TestLectureUpdateIndexFind(t *testing.T) {
+	index := NewLectureUpdateIndex([]LectureUpdateStamp{
+		{LectureID: 1, Code: "lah.s101", Title: "法学 A", OpenTerm: "2024 3Q", Year: 2024},
+		{LectureID: 2, Code: "LAH.S101", Title: "法学  A", OpenTerm: "2024 3Q", Year: 2025},
+		{LectureID: 3, Code: "LAH.S101", Title: "法学 B", OpenTerm: "", Year: 2025},
+	})
+
+	if stamp := index.Find(" LAH.S101 ", "法学   A", "2024  3Q"); stamp == nil || stamp.LectureID != 2 {
+		t.Fatalf("expected the newest matching lecture, got %+v", stamp)
+	}
+	if stamp := index.Find("LAH.S101", "法学 B", ""); stamp == nil || stamp.LectureID != 3 {
+		t.Fatalf("expected the lecture without open term, got %+v", stamp)
+	}
+	if stamp := index.Find("LAH.S101", "法学 B", "2025 3Q"); stamp != nil {
+		t.Fatalf("open term should be compared, got %+v", stamp)
+	}
+	if stamp := index.Find("", "法学 A", "2024 3Q"); stamp != nil {
+		t.Fatalf("rows without a code should not match, got %+v", stamp)
+	}
+	if index.Len() != 2 {
+		t.Fatalf("expected two indexed keys, got %d", index.Len())
+	}
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ParseIssueKind classifies something the parser could not read from a syllabus page.
+type ParseIssueKind string
+
+const (
+	ParseIssueMissingField        ParseIssueKind = "missing_field"
+	ParseIssueUnknownTerm         ParseIssueKind = "unknown_term"
+	ParseIssueUnknownSection      ParseIssueKind = "unknown_section"
+	ParseIssueUnparsableTimetable ParseIssueKind = "unparsable_timetable"
+)
+
+// ParseIssue is one problem found on a page; Detail names the field, heading or timetable line.
+type ParseIssue struct {
+	Kind   ParseIssueKind
+	Detail string
+}
+
+// ParseDiagnostic collects the issues found while parsing one lecture page.
+type ParseDiagnostic struct {
+	URL    string
+	Code   string
+	Title  string
+	Issues []ParseIssue
+}
+
+// HasMissingFields reports whether a required field could not be read.
+func (d ParseDiagnostic) HasMissingFields() bool {
+	for _, issue := range d.Issues {
+		if issue.Kind == ParseIssueMissingField {
+			return true
+		}
+	}
+	return false
+}
+
+// ScrapeRun records one scrape and the diagnostics of the lectures it parsed.
+// Lectures counts every parsed page, MissingLectures those with at least one missing field.
+type ScrapeRun struct {
+	ID              int
+	SiteID          string
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	Lectures        int
+	MissingLectures int
+	Diagnostics     []ParseDiagnostic
+}
+
+// Add records the diagnostic of a parsed lecture.
+func (r *ScrapeRun) Add(diagnostic ParseDiagnostic) {
+	r.Lectures++
+	if diagnostic.HasMissingFields() {
+		r.MissingLectures++
+	}
+	if len(diagnostic.Issues) > 0 {
+		r.Diagnostics = append(r.Diagnostics, diagnostic)
+	}
+}
+
+// MissingFieldRate is the share of parsed lectures with a missing required field.
+func (r ScrapeRun) MissingFieldRate() float64 {
+	if r.Lectures == 0 {
+		return 0
+	}
+	return float64(r.MissingLectures) / float64(r.Lectures)
+}
+
+const (
+	// parseDriftMinLectures keeps tiny runs, such as a single detail page, from raising warnings.
+	parseDriftMinLectures = 10
+	// parseDriftMinIncrease is the rise of the missing field rate over the baseline that counts as drift.
+	parseDriftMinIncrease = 0.2
+)
+
+// ParseDrift describes a jump of the missing field rate compared with earlier runs.
+type ParseDrift struct {
+	RunID         int
+	SiteID        string
+	Rate          float64
+	Baseline      float64
+	MissingFields map[string]int
+}
+
+// DetectParseDrift compares the missing field rate of current with the average of previous runs.
+// It needs at least one comparable earlier run, so the first scrape of a site never warns.
+func DetectParseDrift(current ScrapeRun, previous []ScrapeRun) (ParseDrift, bool) {
+	if current.Lectures < parseDriftMinLectures {
+		return ParseDrift{}, false
+	}
+
+	var sum float64
+	var runs int
+	for _, run := range previous {
+		if run.ID == current.ID || run.Lectures < parseDriftMinLectures {
+			continue
+		}
+		sum += run.MissingFieldRate()
+		runs++
+	}
+	if runs == 0 {
+		return ParseDrift{}, false
+	}
+
+	baseline := sum / float64(runs)
+	rate := current.MissingFieldRate()
+	if rate-baseline < parseDriftMinIncrease {
+		return ParseDrift{}, false
+	}
+
+	return ParseDrift{
+		RunID:         current.ID,
+		SiteID:        current.SiteID,
+		Rate:          rate,
+		Baseline:      baseline,
+		MissingFields: countIssues(current.Diagnostics, ParseIssueMissingField),
+	}, true
+}
+
+func countIssues(diagnostics []ParseDiagnostic, kind ParseIssueKind) map[string]int {
+	counts := make(map[string]int)
+	for _, diagnostic := range diagnostics {
+		for _, issue := range diagnostic.Issues {
+			if issue.Kind == kind {
+				counts[issue.Detail]++
+			}
+		}
+	}
+	return counts
+}
+
+// ParseIssueSummary counts how often an issue occurred during a run.
+type ParseIssueSummary struct {
+	Kind   ParseIssueKind
+	Detail string
+	Count  int
+}
+
+// SummarizeParseIssues groups the issues of a run, most frequent first.
+func SummarizeParseIssues(diagnostics []ParseDiagnostic) []ParseIssueSummary {
+	counts := make(map[ParseIssue]int)
+	for _, diagnostic := range diagnostics {
+		for _, issue := range diagnostic.Issues {
+			counts[issue]++
+		}
+	}
+	summaries := make([]ParseIssueSummary, 0, len(counts))
+	for issue, count := range counts {
+		summaries = append(summaries, ParseIssueSummary{Kind: issue.Kind, Detail: issue.Detail, Count: count})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		if summaries[i].Kind != summaries[j].Kind {
+			return summaries[i].Kind < summaries[j].Kind
+		}
+		return summaries[i].Detail < summaries[j].Detail
+	})
+	return summaries
+}
+
+// ScrapeRunRepository persists scrape runs and their parse diagnostics.
+type ScrapeRunRepository interface {
+	// Create stores a started run and assigns its ID.
+	Create(run *ScrapeRun) error
+	// Finish stores the counts and diagnostics of a completed run.
+	Finish(run *ScrapeRun) error
+	// FindRecent returns the latest finished runs of a site, newest first, without diagnostics.
+	FindRecent(siteID string, limit int) ([]ScrapeRun, error)
+	// FindByID returns a run with its diagnostics, or nil when it does not exist.
+	FindByID(id int) (*ScrapeRun, error)
+}
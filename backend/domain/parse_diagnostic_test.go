@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+func scrapeRunWithRate(id, lectures, missing int) ScrapeRun {
+	run := ScrapeRun{ID: id, SiteID: "isct"}
+	for i := 0; i < lectures; i++ {
+		diagnostic := ParseDiagnostic{URL: "u"}
+		if i < missing {
+			diagnostic.Issues = []ParseIssue{{Kind: ParseIssueMissingField, Detail: "teachers"}}
+		}
+		run.Add(diagnostic)
+	}
+	return run
+}
+
+func TestDetectParseDrift(t *testing.T) {
+	previous := []ScrapeRun{scrapeRunWithRate(1, 20, 1), scrapeRunWithRate(2, 20, 3)}
+
+	current := scrapeRunWithRate(3, 20, 12)
+	drift, ok := DetectParseDrift(current, append([]ScrapeRun{current}, previous...))
+	if !ok {
+		t.Fatalf("expected drift")
+	}
+	if drift.RunID != 3 || drift.Rate != 0.6 || drift.Baseline != 0.1 {
+		t.Errorf("unexpected drift: %+v", drift)
+	}
+	if drift.MissingFields["teachers"] != 12 {
+		t.Errorf("unexpected missing fields: %v", drift.MissingFields)
+	}
+
+	if _, ok := DetectParseDrift(scrapeRunWithRate(3, 20, 4), previous); ok {
+		t.Errorf("small increase must not count as drift")
+	}
+	if _, ok := DetectParseDrift(scrapeRunWithRate(3, 5, 5), previous); ok {
+		t.Errorf("small runs must not count as drift")
+	}
+	if _, ok := DetectParseDrift(current, nil); ok {
+		t.Errorf("first run must not count as drift")
+	}
+}
+
+func TestSummarizeParseIssues(t *testing.T) {
+	diagnostics := []ParseDiagnostic{
+		{URL: "a", Issues: []ParseIssue{{Kind: ParseIssueUnknownTerm, Detail: "x"}, {Kind: ParseIssueMissingField, Detail: "code"}}},
+		{URL: "b", Issues: []ParseIssue{{Kind: ParseIssueMissingField, Detail: "code"}}},
+	}
+	summaries := SummarizeParseIssues(diagnostics)
+	if len(summaries) != 2 {
+		t.Fatalf("unexpected summaries: %+v", summaries)
+	}
+	if summaries[0].Detail != "code" || summaries[0].Count != 2 || summaries[1].Detail != "x" {
+		t.Errorf("unexpected order: %+v", summaries)
+	}
+}
@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// RecommendationDocument is what the recommendation index knows about a lecture. Terms are the counts
+// of the words and character bigrams of its syllabus text; Related lists the lectures it is linked to
+// in the course graph, in either direction.
+type RecommendationDocument struct {
+	LectureID int
+	Code      string
+	Title     string
+	Year      int
+	Terms     map[string]int
+	Keywords  []string
+	Teachers  []string
+	Related   []int
+}
+
+// RecommendationReasonKind names why a lecture was suggested.
+type RecommendationReasonKind string
+
+const (
+	RecommendationReasonText    RecommendationReasonKind = "text"
+	RecommendationReasonKeyword RecommendationReasonKind = "keyword"
+	RecommendationReasonTeacher RecommendationReasonKind = "teacher"
+	RecommendationReasonRelated RecommendationReasonKind = "related"
+)
+
+// RecommendationReason is one contribution to a suggestion's score. Detail is the shared keyword or
+// teacher, or the title of the related seed lecture; it is empty for text similarity.
+type RecommendationReason struct {
+	Kind   RecommendationReasonKind
+	Detail string
+	Score  float64
+}
+
+// Recommendation is a suggested lecture with the reasons it was suggested, strongest first.
+type Recommendation struct {
+	LectureID int
+	Code      string
+	Title     string
+	Year      int
+	Score     float64
+	Reasons   []RecommendationReason
+}
+
+// Weights of the non-text reasons. Text similarity is a cosine from 0 to 1 and is used as is.
+const (
+	recommendationKeywordWeight = 0.15
+	recommendationTeacherWeight = 0.2
+	recommendationRelatedWeight = 0.4
+	// recommendationMinTextScore drops text similarity that only comes from common phrasing.
+	recommendationMinTextScore = 0.05
+)
+
+// RecommendationTerms counts the terms of syllabus texts for the recommendation index. Latin words of two
+// or more letters are lower-cased; runs of Japanese text are split into character bigrams, skipping
+// bigrams of only hiragana, which are mostly particles and inflections.
+func RecommendationTerms(texts ...string) map[string]int {
+	terms := make(map[string]int)
+	for _, text := range texts {
+		var word []rune
+		var run []rune
+		flushWord := func() {
+			if len(word) >= 2 {
+				terms[strings.ToLower(string(word))]++
+			}
+			word = word[:0]
+		}
+		flushRun := func() {
+			for i := 0; i+1 < len(run); i++ {
+				if isHiragana(run[i]) && isHiragana(run[i+1]) {
+					continue
+				}
+				terms[string(run[i:i+2])]++
+			}
+			run = run[:0]
+		}
+		for _, r := range text {
+			// full-width ASCII such as "Ａ" or "１"
+			if r >= '！' && r <= '～' {
+				r -= 0xFEE0
+			}
+			switch {
+			case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+				flushRun()
+				word = append(word, r)
+			case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー':
+				flushWord()
+				run = append(run, r)
+			default:
+				flushWord()
+				flushRun()
+			}
+		}
+		flushWord()
+		flushRun()
+	}
+	return terms
+}
+
+func isHiragana(r rune) bool {
+	return unicode.Is(unicode.Hiragana, r)
+}
+
+// RecommendationIndex ranks lectures by their similarity to a set of seed lectures.
+type RecommendationIndex struct {
+	documents map[int]RecommendationDocument
+	frequency map[string]int
+}
+
+// NewRecommendationIndex builds an index over the documents.
+func NewRecommendationIndex(documents []RecommendationDocument) *RecommendationIndex {
+	index := &RecommendationIndex{
+		documents: make(map[int]RecommendationDocument, len(documents)),
+		frequency: make(map[string]int),
+	}
+	for _, document := range documents {
+		index.documents[document.LectureID] = document
+		for term := range document.Terms {
+			index.frequency[term]++
+		}
+	}
+	return index
+}
+
+// Len returns the number of indexed lectures.
+func (idx *RecommendationIndex) Len() int {
+	return len(idx.documents)
+}
+
+// Recommend returns up to limit lectures most similar to the seeds, strongest first. Only lectures
+// accepted by allow are suggested, or every lecture when allow is nil; the seeds and other years of the
+// seed courses are never suggested.
+func (idx *RecommendationIndex) Recommend(seedIDs []int, limit int, allow func(lectureID int) bool) []Recommendation {
+	seeds := make([]RecommendationDocument, 0, len(seedIDs))
+	seedCodes := make(map[string]struct{})
+	for _, id := range seedIDs {
+		if document, ok := idx.documents[id]; ok {
+			seeds = append(seeds, document)
+			if code := strings.ToUpper(strings.TrimSpace(document.Code)); code != "" {
+				seedCodes[code] = struct{}{}
+			}
+		}
+	}
+	if len(seeds) == 0 || limit <= 0 {
+		return []Recommendation{}
+	}
+
+	centroid := make(map[string]float64)
+	keywords := make(map[string]struct{})
+	teachers := make(map[string]struct{})
+	related := make(map[int]string)
+	isSeed := make(map[int]struct{}, len(seeds))
+	for _, seed := range seeds {
+		isSeed[seed.LectureID] = struct{}{}
+		for term, weight := range idx.vector(seed) {
+			centroid[term] += weight
+		}
+		for _, keyword := range seed.Keywords {
+			keywords[NormalizeTag(keyword)] = struct{}{}
+		}
+		for _, teacher := range seed.Teachers {
+			teachers[teacher] = struct{}{}
+		}
+		for _, id := range seed.Related {
+			if _, ok := related[id]; !ok {
+				related[id] = seed.Title
+			}
+		}
+	}
+	normalize(centroid)
+
+	recommendations := make([]Recommendation, 0)
+	for id, document := range idx.documents {
+		if _, ok := isSeed[id]; ok {
+			continue
+		}
+		if _, ok := seedCodes[strings.ToUpper(strings.TrimSpace(document.Code))]; ok {
+			continue
+		}
+		if allow != nil && !allow(id) {
+			continue
+		}
+
+		recommendation := Recommendation{LectureID: id, Code: document.Code, Title: document.Title, Year: document.Year}
+		if similarity := cosine(centroid, idx.vector(document)); similarity >= recommendationMinTextScore {
+			recommendation.Reasons = append(recommendation.Reasons, RecommendationReason{Kind: RecommendationReasonText, Score: similarity})
+		}
+		for _, keyword := range document.Keywords {
+			if _, ok := keywords[NormalizeTag(keyword)]; ok {
+				recommendation.Reasons = append(recommendation.Reasons, RecommendationReason{Kind: RecommendationReasonKeyword, Detail: keyword, Score: recommendationKeywordWeight})
+			}
+		}
+		for _, teacher := range document.Teachers {
+			if _, ok := teachers[teacher]; ok {
+				recommendation.Reasons = append(recommendation.Reasons, RecommendationReason{Kind: RecommendationReasonTeacher, Detail: teacher, Score: recommendationTeacherWeight})
+			}
+		}
+		if title, ok := related[id]; ok {
+			recommendation.Reasons = append(recommendation.Reasons, RecommendationReason{Kind: RecommendationReasonRelated, Detail: title, Score: recommendationRelatedWeight})
+		}
+		if len(recommendation.Reasons) == 0 {
+			continue
+		}
+
+		sort.SliceStable(recommendation.Reasons, func(i, j int) bool {
+			return recommendation.Reasons[i].Score > recommendation.Reasons[j].Score
+		})
+		for _, reason := range recommendation.Reasons {
+			recommendation.Score += reason.Score
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		if recommendations[i].Year != recommendations[j].Year {
+			return recommendations[i].Year > recommendations[j].Year
+		}
+		return recommendations[i].LectureID < recommendations[j].LectureID
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+// vector returns the normalized TF-IDF vector of a document.
+func (idx *RecommendationIndex) vector(document RecommendationDocument) map[string]float64 {
+	vector := make(map[string]float64, len(document.Terms))
+	total := float64(len(idx.documents))
+	for term, count := range document.Terms {
+		idf := math.Log((total+1)/float64(idx.frequency[term]+1)) + 1
+		vector[term] = (1 + math.Log(float64(count))) * idf
+	}
+	normalize(vector)
+	return vector
+}
+
+func normalize(vector map[string]float64) {
+	var sum float64
+	for _, weight := range vector {
+		sum += weight * weight
+	}
+	if sum == 0 {
+		return
+	}
+	norm := math.Sqrt(sum)
+	for term := range vector {
+		vector[term] /= norm
+	}
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	return dot
+}
+
+// RecommendationRepository stores the term counts of the recommendation index. RefreshRecommendationIndex
+// re-tokenizes only lectures that are new or were updated since they were indexed and drops lectures that no
+// longer exist, returning the number of lectures it changed.
+type RecommendationRepository interface {
+	RefreshRecommendationIndex(ctx context.Context) (int, error)
+	FindRecommendationDocuments() ([]RecommendationDocument, error)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecommendationTerms(t *testing.T) {
+	terms := RecommendationTerms("機械学習の基礎を学ぶ", "Deep Learning と ＡＩ")
+	for _, term := range []string{"機械", "械学", "学習", "習の", "基礎", "deep", "learning", "ai"} {
+		if terms[term] == 0 {
+			t.Errorf("expected term %q in %v", term, terms)
+		}
+	}
+	for _, term := range []string{"を学", "学ぶ"} {
+		if terms[term] == 0 {
+			t.Errorf("expected mixed bigram %q in %v", term, terms)
+		}
+	}
+	if terms["ぶ"] != 0 || terms["と"] != 0 {
+		t.Errorf("expected no unigrams, got %v", terms)
+	}
+}
+
+func TestRecommendationIndexRecommend(t *testing.T) {
+	documents := []RecommendationDocument{
+		{LectureID: 1, Code: "CSC.T371", Title: "機械学習", Year: 2025, Terms: RecommendationTerms("機械学習 ニューラルネットワーク 深層学習"), Keywords: []string{"機械学習"}, Teachers: []string{"山田 太郎"}, Related: []int{4}},
+		{LectureID: 2, Code: "CSC.T372", Title: "深層学習", Year: 2025, Terms: RecommendationTerms("深層学習 ニューラルネットワーク 画像認識"), Keywords: []string{"機械学習"}},
+		{LectureID: 3, Code: "MTH.A201", Title: "代数学", Year: 2025, Terms: RecommendationTerms("群 環 体 代数学")},
+		{LectureID: 4, Code: "MTH.B201", Title: "確率と統計", Year: 2025, Terms: RecommendationTerms("確率 統計 推定"), Teachers: []string{"山田 太郎"}, Related: []int{1}},
+		// another year of the seed course is not suggested
+		{LectureID: 5, Code: "CSC.T371", Title: "機械学習", Year: 2024, Terms: RecommendationTerms("機械学習 ニューラルネットワーク 深層学習")},
+	}
+	index := NewRecommendationIndex(documents)
+
+	recommendations := index.Recommend([]int{1}, 10, nil)
+	ids := make([]int, 0)
+	for _, recommendation := range recommendations {
+		ids = append(ids, recommendation.LectureID)
+	}
+	if !reflect.DeepEqual(ids, []int{2, 4}) {
+		t.Fatalf("expected the similar then the related lecture, got %+v", recommendations)
+	}
+
+	kinds := func(reasons []RecommendationReason) []RecommendationReasonKind {
+		result := make([]RecommendationReasonKind, 0, len(reasons))
+		for _, reason := range reasons {
+			result = append(result, reason.Kind)
+		}
+		return result
+	}
+	if got := kinds(recommendations[1].Reasons); !reflect.DeepEqual(got, []RecommendationReasonKind{RecommendationReasonRelated, RecommendationReasonTeacher}) {
+		t.Errorf("unexpected reasons for the related lecture: %+v", recommendations[1].Reasons)
+	}
+	if recommendations[1].Reasons[0].Detail != "機械学習" || recommendations[1].Reasons[1].Detail != "山田 太郎" {
+		t.Errorf("expected the seed title and teacher as details, got %+v", recommendations[1].Reasons)
+	}
+	if got := kinds(recommendations[0].Reasons); !reflect.DeepEqual(got, []RecommendationReasonKind{RecommendationReasonText, RecommendationReasonKeyword}) {
+		t.Errorf("unexpected reasons for the similar lecture: %+v", recommendations[0].Reasons)
+	}
+
+	filtered := index.Recommend([]int{1}, 10, func(id int) bool { return id != 4 })
+	if len(filtered) != 1 || filtered[0].LectureID != 2 {
+		t.Errorf("expected the filter to drop lecture 4, got %+v", filtered)
+	}
+	if limited := index.Recommend([]int{1}, 1, nil); len(limited) != 1 {
+		t.Errorf("expected one recommendation, got %+v", limited)
+	}
+	if none := index.Recommend([]int{99}, 10, nil); len(none) != 0 {
+		t.Errorf("expected no recommendations for an unknown seed, got %+v", none)
+	}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RefreshSchedule is a cron-style schedule of the background syllabus refresh. It is written as the five
+// cron fields minute, hour, day of month, month and day of week, e.g. "0 3 * * *" for 3am every day,
+// or as one of @hourly, @daily, @weekly and @monthly.
+type RefreshSchedule struct {
+	Spec     string
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	// as in cron, a day matches either restricted day field when both are restricted
+	anyDay     bool
+	anyWeekday bool
+}
+
+// RefreshSetting is the stored schedule of the background refresh of a site.
+type RefreshSetting struct {
+	SiteID    string
+	Spec      string
+	UpdatedAt time.Time
+}
+
+type RefreshSettingRepository interface {
+	Find() (*RefreshSetting, error)
+	Save(setting RefreshSetting) error
+	Delete() error
+}
+
+var refreshScheduleMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseRefreshSchedule parses a cron-style schedule. Fields accept *, numbers, ranges (1-5), steps
+// (*/15, 1-10/2) and comma separated lists of those; day of week counts from Sunday as 0 or 7.
+func ParseRefreshSchedule(spec string) (RefreshSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	expanded := spec
+	if macro, ok := refreshScheduleMacros[strings.ToLower(spec)]; ok {
+		expanded = macro
+	}
+
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return RefreshSchedule{}, fmt.Errorf("parse schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	bounds := []struct {
+		name     string
+		min, max int
+	}{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day of month", 1, 31},
+		{"month", 1, 12},
+		{"day of week", 0, 7},
+	}
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseScheduleField(field, bounds[i].min, bounds[i].max)
+		if err != nil {
+			return RefreshSchedule{}, fmt.Errorf("parse schedule %q: %s: %w", spec, bounds[i].name, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be written as 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return RefreshSchedule{
+		Spec:       spec,
+		minutes:    sets[0],
+		hours:      sets[1],
+		days:       sets[2],
+		months:     sets[3],
+		weekdays:   sets[4],
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}, nil
+}
+
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			value, err := strconv.Atoi(part[i+1:])
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], value
+		}
+
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			start, end = value, value
+			if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for value := start; value <= end; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first time after after, truncated to the minute, that matches the schedule in the
+// location of after. It returns the zero time when nothing matches within five years, e.g. for "0 0 31 2 *".
+func (s RefreshSchedule) Next(after time.Time) time.Time {
+	if s.minutes == 0 {
+		return time.Time{}
+	}
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s RefreshSchedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshScheduleNext(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	after := time.Date(2025, 4, 30, 3, 0, 30, 0, jst) // Wednesday
+
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"0 3 * * *", time.Date(2025, 5, 1, 3, 0, 0, 0, jst)},
+		{"@daily", time.Date(2025, 5, 1, 0, 0, 0, 0, jst)},
+		{"*/15 * * * *", time.Date(2025, 4, 30, 3, 15, 0, 0, jst)},
+		{"30 9 * * 1-5", time.Date(2025, 4, 30, 9, 30, 0, 0, jst)},
+		{"0 0 * * 7", time.Date(2025, 5, 4, 0, 0, 0, 0, jst)},
+		{"0 12 1,15 * *", time.Date(2025, 5, 1, 12, 0, 0, 0, jst)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, jst)},
+		// either restricted day field matches: the 10th, or the next Friday
+		{"0 0 10 * 5", time.Date(2025, 5, 2, 0, 0, 0, 0, jst)},
+	}
+	for _, tc := range cases {
+		schedule, err := ParseRefreshSchedule(tc.spec)
+		if err != nil {
+			t.Fatalf("ParseRefreshSchedule(%q) returned error: %v", tc.spec, err)
+		}
+		if got := schedule.Next(after); !got.Equal(tc.want) {
+			t.Fatalf("%q: expected %v, got %v", tc.spec, tc.want, got)
+		}
+	}
+
+	never, err := ParseRefreshSchedule("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("ParseRefreshSchedule returned error: %v", err)
+	}
+	if got := never.Next(after); !got.IsZero() {
+		t.Fatalf("expected no match, got %v", got)
+	}
+}
+
+func TestParseRefreshScheduleRejectsInvalidSpecs(t *testing.T) {
+	for _, spec := range []string{"", "0 3 * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@yearly"} {
+		if _, err := ParseRefreshSchedule(spec); err == nil {
+			t.Fatalf("expected %q to be rejected", spec)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResearchFilterField is the lecture attribute a research filter rule inspects.
+type ResearchFilterField string
+
+const (
+	ResearchFilterFieldTitle      ResearchFilterField = "title"
+	ResearchFilterFieldDepartment ResearchFilterField = "department"
+	ResearchFilterFieldTeacher    ResearchFilterField = "teacher"
+	ResearchFilterFieldCode       ResearchFilterField = "code"
+)
+
+// ResearchFilterMatch is how a research filter rule compares its pattern.
+type ResearchFilterMatch string
+
+const (
+	ResearchFilterMatchContains ResearchFilterMatch = "contains"
+	ResearchFilterMatchPrefix   ResearchFilterMatch = "prefix"
+	ResearchFilterMatchRegex    ResearchFilterMatch = "regex"
+)
+
+// ResearchFilterRule marks lectures as research or project work that FilterNotResearch hides.
+type ResearchFilterRule struct {
+	ID      int
+	Field   ResearchFilterField
+	Match   ResearchFilterMatch
+	Pattern string
+	Enabled bool
+}
+
+// Validate reports whether the rule can be compiled into a search condition.
+func (r ResearchFilterRule) Validate() error {
+	switch r.Field {
+	case ResearchFilterFieldTitle, ResearchFilterFieldDepartment, ResearchFilterFieldTeacher, ResearchFilterFieldCode:
+	default:
+		return fmt.Errorf("invalid research filter field: %q", r.Field)
+	}
+
+	if strings.TrimSpace(r.Pattern) == "" {
+		return errors.New("research filter pattern is empty")
+	}
+
+	switch r.Match {
+	case ResearchFilterMatchContains, ResearchFilterMatchPrefix:
+	case ResearchFilterMatchRegex:
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid research filter regex: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid research filter match: %q", r.Match)
+	}
+
+	return nil
+}
+
+// DefaultResearchFilterRules returns the rules a new database starts with.
+func DefaultResearchFilterRules() []ResearchFilterRule {
+	titles := []string{
+		"特定課題プロジェクト",
+		"特定課題研究",
+		"講究",
+		"B2D",
+		"リカレント研修",
+		"オフキャンパスプロジェクト",
+		"国際派遣プロジェクト",
+		"インターンシップ",
+		"学外研修",
+		"論文研究計画論",
+		"物理学プレゼンテーション実践",
+		"数理・計算科学プレゼンテーション実践",
+		"国際プレゼンテーション",
+		"エンジニアリングデザインプレゼンテーション",
+		"チュートリアル",
+		"留学",
+		"国際研究",
+		"キャリアディベロップメント",
+		"キャリア開発",
+		"キャリア特別",
+		"派遣プロジェクト",
+		"企画実践",
+		"先端研究",
+	}
+
+	rules := make([]ResearchFilterRule, 0, len(titles)+1)
+	for _, title := range titles {
+		rules = append(rules, ResearchFilterRule{Field: ResearchFilterFieldTitle, Match: ResearchFilterMatchContains, Pattern: title, Enabled: true})
+	}
+	rules = append(rules, ResearchFilterRule{Field: ResearchFilterFieldTeacher, Match: ResearchFilterMatchContains, Pattern: "教員", Enabled: true})
+
+	return rules
+}
+
+type ResearchFilterRepository interface {
+	FindAll() ([]ResearchFilterRule, error)
+	Create(rule *ResearchFilterRule) error
+	Update(rule *ResearchFilterRule) error
+	Delete(id int) error
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestResearchFilterRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ResearchFilterRule
+		wantErr bool
+	}{
+		{name: "title contains", rule: ResearchFilterRule{Field: ResearchFilterFieldTitle, Match: ResearchFilterMatchContains, Pattern: "講究"}},
+		{name: "code prefix", rule: ResearchFilterRule{Field: ResearchFilterFieldCode, Match: ResearchFilterMatchPrefix, Pattern: "LAW."}},
+		{name: "teacher regex", rule: ResearchFilterRule{Field: ResearchFilterFieldTeacher, Match: ResearchFilterMatchRegex, Pattern: "^各?教員$"}},
+		{name: "empty pattern", rule: ResearchFilterRule{Field: ResearchFilterFieldTitle, Match: ResearchFilterMatchContains, Pattern: " "}, wantErr: true},
+		{name: "broken regex", rule: ResearchFilterRule{Field: ResearchFilterFieldTitle, Match: ResearchFilterMatchRegex, Pattern: "(研究"}, wantErr: true},
+		{name: "unknown field", rule: ResearchFilterRule{Field: "room", Match: ResearchFilterMatchContains, Pattern: "W5"}, wantErr: true},
+		{name: "unknown match", rule: ResearchFilterRule{Field: ResearchFilterFieldTitle, Match: "glob", Pattern: "研究*"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
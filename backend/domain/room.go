@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Room struct {
+	ID       int
+	Name     string
+	Building string
+	Campus   Campus
+	Number   string
+	Virtual  bool
+}
+
+type Campus string
+
+const (
+	CampusOokayama    Campus = "ookayama"
+	CampusSuzukakedai Campus = "suzukakedai"
+	CampusTamachi     Campus = "tamachi"
+	CampusYushima     Campus = "yushima"
+	CampusOnline      Campus = "online"
+)
+
+// CanonicalName returns the building and room number in the "W5-104" form, or the raw name when the room could not be normalized.
+func (r Room) CanonicalName() string {
+	switch {
+	case r.Building != "" && r.Number != "":
+		return r.Building + "-" + r.Number
+	case r.Building != "":
+		return r.Building
+	default:
+		return strings.TrimSpace(r.Name)
+	}
+}
+
+var virtualRoomWords = []string{"zoom", "teams", "webex", "google meet", "online", "オンライン", "遠隔", "オンデマンド", "ondemand", "on-demand"}
+
+var buildingAliases = []struct {
+	alias  string
+	letter string
+}{
+	{alias: "石川台", letter: "I"},
+	{alias: "緑が丘", letter: "M"},
+	{alias: "本館", letter: "H"},
+	{alias: "西", letter: "W"},
+	{alias: "南", letter: "S"},
+	{alias: "東", letter: "E"},
+	{alias: "北", letter: "N"},
+}
+
+var campusByLetter = map[string]Campus{
+	"W": CampusOokayama,
+	"S": CampusOokayama,
+	"I": CampusOokayama,
+	"H": CampusOokayama,
+	"M": CampusOokayama,
+	"E": CampusOokayama,
+	"N": CampusOokayama,
+	"J": CampusSuzukakedai,
+	"G": CampusSuzukakedai,
+	"B": CampusSuzukakedai,
+	"R": CampusSuzukakedai,
+}
+
+var campusByBuilding = map[string]Campus{
+	"CIC": CampusTamachi,
+}
+
+var campusWords = []struct {
+	word   string
+	campus Campus
+}{
+	{word: "大岡山", campus: CampusOokayama},
+	{word: "すずかけ台", campus: CampusSuzukakedai},
+	{word: "田町", campus: CampusTamachi},
+	{word: "湯島", campus: CampusYushima},
+}
+
+var (
+	// buildingRoomRegexp matches a numbered building followed by a room number, as in "W5-104" or "W5号館104".
+	buildingRoomRegexp = regexp.MustCompile(`^([A-Z]{1,3})(\d{1,2}[A-Z]?)(?:号館|棟|\s*[-_]\s*|\s+)([0-9]{2,4}[A-Z]?)`)
+	// letterRoomRegexp matches an unnumbered building followed by a room number, as in "H121" or "M-110".
+	letterRoomRegexp = regexp.MustCompile(`^([A-Z]{1,3})\s*[-_]?\s*([0-9]{2,4}[A-Z]?)`)
+	// buildingOnlyRegexp matches a building without a room number, as in "W5" or "W5号館".
+	buildingOnlyRegexp = regexp.MustCompile(`^([A-Z]{1,3})(\d{1,2}[A-Z]?)(?:号館|棟)?$`)
+)
+
+// NormalizeRoom maps a room name as written in the syllabus onto its campus, building and room number.
+// Variants such as "W5-104" and "西5号館104" normalize to the same building and number,
+// and online rooms such as "Zoom" are marked as virtual.
+func NormalizeRoom(name string) Room {
+	room := Room{Name: strings.TrimSpace(name)}
+	folded := foldRoomName(room.Name)
+	if folded == "" {
+		return room
+	}
+
+	lower := strings.ToLower(folded)
+	for _, word := range virtualRoomWords {
+		if strings.Contains(lower, word) {
+			room.Campus = CampusOnline
+			room.Virtual = true
+			return room
+		}
+	}
+
+	for _, cw := range campusWords {
+		if strings.Contains(folded, cw.word) {
+			room.Campus = cw.campus
+			folded = strings.TrimSpace(strings.ReplaceAll(folded, cw.word, ""))
+			break
+		}
+	}
+
+	for _, alias := range buildingAliases {
+		if strings.HasPrefix(folded, alias.alias) {
+			folded = alias.letter + strings.TrimPrefix(folded, alias.alias)
+			break
+		}
+	}
+
+	var letter string
+	if matches := buildingRoomRegexp.FindStringSubmatch(folded); len(matches) > 0 {
+		letter = matches[1]
+		room.Building = matches[1] + matches[2]
+		room.Number = matches[3]
+	} else if matches := letterRoomRegexp.FindStringSubmatch(folded); len(matches) > 0 {
+		letter = matches[1]
+		room.Building = matches[1]
+		room.Number = matches[2]
+	} else if matches := buildingOnlyRegexp.FindStringSubmatch(folded); len(matches) > 0 {
+		letter = matches[1]
+		room.Building = matches[1] + matches[2]
+	}
+	if room.Campus == "" {
+		if campus, ok := campusByBuilding[room.Building]; ok {
+			room.Campus = campus
+		} else if letter != "" {
+			room.Campus = campusByLetter[letter[:1]]
+		}
+	}
+
+	return room
+}
+
+// foldRoomName converts full-width alphanumerics and dashes to their ASCII forms and upper-cases letters.
+func foldRoomName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case r >= '！' && r <= '～':
+			r -= 0xFEE0
+		case r == '　':
+			r = ' '
+		case r == '‐' || r == '–' || r == '—':
+			r = '-'
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToUpper(strings.TrimSpace(sb.String()))
+}
+
+// RoomBooking is a lecture that occupies a room in one weekly slot.
+type RoomBooking struct {
+	LectureID int
+	Code      string
+	Title     string
+	Year      int
+	Semester  Semester
+	DayOfWeek DayOfWeek
+	Period    Period
+}
+
+// RoomSlot groups the bookings of a room that share a semester, day and period.
+type RoomSlot struct {
+	Semester     Semester
+	DayOfWeek    DayOfWeek
+	Period       Period
+	Bookings     []RoomBooking
+	DoubleBooked bool
+}
+
+// RoomSchedule is the weekly occupancy grid of a room for one academic year.
+type RoomSchedule struct {
+	Room           Room
+	Year           int
+	Slots          []RoomSlot
+	DoubleBookings int
+}
+
+// NewRoomSchedule groups bookings into slots ordered by semester, day and period.
+// A slot is double-booked when it holds lectures with different titles; cross-listed
+// lectures share a title and a room, so they are not reported as conflicts.
+func NewRoomSchedule(room Room, year int, bookings []RoomBooking) RoomSchedule {
+	schedule := RoomSchedule{Room: room, Year: year, Slots: make([]RoomSlot, 0)}
+
+	type slotKey struct {
+		semester Semester
+		day      DayOfWeek
+		period   Period
+	}
+	index := make(map[slotKey]int)
+	for _, booking := range bookings {
+		key := slotKey{semester: booking.Semester, day: booking.DayOfWeek, period: booking.Period}
+		idx, ok := index[key]
+		if !ok {
+			idx = len(schedule.Slots)
+			index[key] = idx
+			schedule.Slots = append(schedule.Slots, RoomSlot{Semester: key.semester, DayOfWeek: key.day, Period: key.period})
+		}
+		slot := &schedule.Slots[idx]
+		duplicate := false
+		for _, existing := range slot.Bookings {
+			if existing.LectureID == booking.LectureID {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			slot.Bookings = append(slot.Bookings, booking)
+		}
+	}
+
+	for i := range schedule.Slots {
+		titles := make(map[string]struct{})
+		for _, booking := range schedule.Slots[i].Bookings {
+			titles[strings.TrimSpace(booking.Title)] = struct{}{}
+		}
+		if len(titles) > 1 {
+			schedule.Slots[i].DoubleBooked = true
+			schedule.DoubleBookings++
+		}
+	}
+
+	sort.SliceStable(schedule.Slots, func(i, j int) bool {
+		a, b := schedule.Slots[i], schedule.Slots[j]
+		if a.Semester.Index() != b.Semester.Index() {
+			return a.Semester.Index() < b.Semester.Index()
+		}
+		if a.DayOfWeek.Index() != b.DayOfWeek.Index() {
+			return a.DayOfWeek.Index() < b.DayOfWeek.Index()
+		}
+		return a.Period < b.Period
+	})
+
+	return schedule
+}
+
+type RoomRepository interface {
+	FindAll() ([]Room, error)
+	FindByID(id int) (*Room, error)
+	FindFree(year int, semester Semester, day DayOfWeek, period Period, building string) ([]Room, error)
+	FindBookings(roomID int, year int) ([]RoomBooking, error)
+	FindBookedYears() ([]int, error)
+	NormalizeRooms(ctx context.Context) (int, error)
+}
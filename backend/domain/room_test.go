@@ -0,0 +1,96 @@
+package domain
+
+import "testing"
+
+func TestNormalizeRoom(t *testing.T) {
+	tests := []struct {
+		name     string
+		building string
+		number   string
+		campus   Campus
+		virtual  bool
+	}{
+		{name: "W5-104", building: "W5", number: "104", campus: CampusOokayama},
+		{name: "西5号館104", building: "W5", number: "104", campus: CampusOokayama},
+		{name: "Ｗ５－１０４", building: "W5", number: "104", campus: CampusOokayama},
+		{name: "S3-215(S321)", building: "S3", number: "215", campus: CampusOokayama},
+		{name: "H121", building: "H", number: "121", campus: CampusOokayama},
+		{name: "M-110", building: "M", number: "110", campus: CampusOokayama},
+		{name: "W8E-101", building: "W8E", number: "101", campus: CampusOokayama},
+		{name: "J2-204", building: "J2", number: "204", campus: CampusSuzukakedai},
+		{name: "西5号館", building: "W5", campus: CampusOokayama},
+		{name: "田町CIC 604", building: "CIC", number: "604", campus: CampusTamachi},
+		{name: "CIC-912", building: "CIC", number: "912", campus: CampusTamachi},
+		{name: "Zoom", campus: CampusOnline, virtual: true},
+		{name: "オンライン", campus: CampusOnline, virtual: true},
+		{name: "別館202ラボ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeRoom(tt.name)
+			if got.Name != tt.name {
+				t.Errorf("unexpected name: got %s, want %s", got.Name, tt.name)
+			}
+			if got.Building != tt.building || got.Number != tt.number {
+				t.Errorf("unexpected building/number: got %s/%s, want %s/%s", got.Building, got.Number, tt.building, tt.number)
+			}
+			if got.Campus != tt.campus {
+				t.Errorf("unexpected campus: got %s, want %s", got.Campus, tt.campus)
+			}
+			if got.Virtual != tt.virtual {
+				t.Errorf("unexpected virtual flag: got %t, want %t", got.Virtual, tt.virtual)
+			}
+		})
+	}
+}
+
+func TestRoomCanonicalName(t *testing.T) {
+	if got := NormalizeRoom("西5号館104").CanonicalName(); got != "W5-104" {
+		t.Fatalf("unexpected canonical name: %s", got)
+	}
+	if got := NormalizeRoom("Zoom").CanonicalName(); got != "Zoom" {
+		t.Fatalf("unexpected canonical name for virtual room: %s", got)
+	}
+}
+
+func TestNewRoomScheduleDetectsDoubleBooking(t *testing.T) {
+	room := Room{ID: 1, Name: "W5-104", Building: "W5", Number: "104"}
+	bookings := []RoomBooking{
+		{LectureID: 3, Code: "CSC.T301", Title: "情報理論", Semester: SemesterFall, DayOfWeek: DayOfWeekMonday, Period: Period1},
+		{LectureID: 1, Code: "MTH.A201", Title: "線形代数", Semester: SemesterSpring, DayOfWeek: DayOfWeekTuesday, Period: Period3},
+		{LectureID: 2, Code: "MTH.A202", Title: "解析学", Semester: SemesterSpring, DayOfWeek: DayOfWeekTuesday, Period: Period3},
+		{LectureID: 4, Code: "LAH.S101", Title: "哲学", Semester: SemesterSpring, DayOfWeek: DayOfWeekMonday, Period: Period5},
+		{LectureID: 5, Code: "LAH.S102", Title: "哲学", Semester: SemesterSpring, DayOfWeek: DayOfWeekMonday, Period: Period5},
+		{LectureID: 4, Code: "LAH.S101", Title: "哲学", Semester: SemesterSpring, DayOfWeek: DayOfWeekMonday, Period: Period5},
+	}
+
+	schedule := NewRoomSchedule(room, 2025, bookings)
+
+	if len(schedule.Slots) != 3 {
+		t.Fatalf("expected 3 slots, got %d", len(schedule.Slots))
+	}
+	if schedule.DoubleBookings != 1 {
+		t.Fatalf("expected 1 double booking, got %d", schedule.DoubleBookings)
+	}
+
+	first := schedule.Slots[0]
+	if first.Semester != SemesterSpring || first.DayOfWeek != DayOfWeekMonday || first.Period != Period5 {
+		t.Fatalf("unexpected first slot: %+v", first)
+	}
+	if first.DoubleBooked {
+		t.Errorf("expected cross-listed lectures not to be a double booking")
+	}
+	if len(first.Bookings) != 2 {
+		t.Errorf("expected duplicate bookings to be collapsed, got %d", len(first.Bookings))
+	}
+
+	second := schedule.Slots[1]
+	if second.DayOfWeek != DayOfWeekTuesday || !second.DoubleBooked {
+		t.Errorf("expected tuesday slot to be double-booked: %+v", second)
+	}
+
+	if schedule.Slots[2].Semester != SemesterFall {
+		t.Errorf("expected fall slot last, got %s", schedule.Slots[2].Semester)
+	}
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SavedSearch is a named search query the user runs repeatedly.
+type SavedSearch struct {
+	ID        int
+	Name      string
+	Query     SearchQuery
+	CreatedAt time.Time
+	CheckedAt time.Time
+}
+
+// SearchResultEntry is one lecture of a saved search's result, reduced to what is diffed between runs.
+// Key is the course key, open term and year, so entries of re-scraped lectures compare equal while
+// sections sharing a code keep apart.
+type SearchResultEntry struct {
+	Key        string
+	LectureID  int
+	Code       string
+	Title      string
+	Year       int
+	Timetables string
+}
+
+// NewSearchResultEntry reduces a lecture summary to a result entry.
+func NewSearchResultEntry(summary LectureSummary) SearchResultEntry {
+	return SearchResultEntry{
+		Key:        strings.TrimSpace(summary.University) + "|" + CourseKey(summary.Code, summary.Title) + "|" + strings.Join(strings.Fields(summary.OpenTerm), " ") + "|" + strconv.Itoa(summary.Year),
+		LectureID:  summary.ID,
+		Code:       summary.Code,
+		Title:      summary.Title,
+		Year:       summary.Year,
+		Timetables: TimetableFingerprint(summary.Timetables),
+	}
+}
+
+// NewSearchResultEntries reduces a search result to entries with one entry per key. Re-scrapes store a
+// lecture again under a new id, so of the summaries sharing a key the newest lecture is kept.
+func NewSearchResultEntries(summaries []LectureSummary) []SearchResultEntry {
+	entries := make([]SearchResultEntry, 0, len(summaries))
+	for _, summary := range summaries {
+		entries = append(entries, NewSearchResultEntry(summary))
+	}
+	return uniqueSearchResults(entries)
+}
+
+// TimetableFingerprint describes timetables as a sorted, readable string such as "1Q Tue 3 W241; 1Q Tue 4 W241".
+func TimetableFingerprint(timetables []TimeTable) string {
+	slots := make([]string, 0, len(timetables))
+	seen := make(map[string]struct{})
+	for _, timetable := range timetables {
+		slot := strings.TrimSpace(fmt.Sprintf("%s %s %d %s", timetable.Semester, timetable.DayOfWeek, timetable.Period, strings.TrimSpace(timetable.Room.Name)))
+		if _, ok := seen[slot]; ok {
+			continue
+		}
+		seen[slot] = struct{}{}
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+	return strings.Join(slots, "; ")
+}
+
+// SearchChangeKind classifies a difference between two runs of a saved search.
+type SearchChangeKind string
+
+const (
+	SearchChangeAdded            SearchChangeKind = "added"
+	SearchChangeRemoved          SearchChangeKind = "removed"
+	SearchChangeTimetableChanged SearchChangeKind = "timetable_changed"
+)
+
+// SearchChange is one lecture that entered, left or changed its timetable in a saved search's result.
+// Before and After hold the timetables of changed lectures.
+type SearchChange struct {
+	Kind      SearchChangeKind
+	LectureID int
+	Code      string
+	Title     string
+	Year      int
+	Before    string
+	After     string
+}
+
+// DiffSearchResults compares the previous result of a saved search with the current one. Changes are
+// ordered added, removed, then changed, each by year and title.
+func DiffSearchResults(previous, current []SearchResultEntry) []SearchChange {
+	previous = uniqueSearchResults(previous)
+	current = uniqueSearchResults(current)
+	before := make(map[string]SearchResultEntry, len(previous))
+	for _, entry := range previous {
+		before[entry.Key] = entry
+	}
+	after := make(map[string]SearchResultEntry, len(current))
+	for _, entry := range current {
+		after[entry.Key] = entry
+	}
+
+	changes := make([]SearchChange, 0)
+	for _, entry := range current {
+		old, ok := before[entry.Key]
+		switch {
+		case !ok:
+			changes = append(changes, searchChangeOf(SearchChangeAdded, entry, "", entry.Timetables))
+		case old.Timetables != entry.Timetables:
+			changes = append(changes, searchChangeOf(SearchChangeTimetableChanged, entry, old.Timetables, entry.Timetables))
+		}
+	}
+	for _, entry := range previous {
+		if _, ok := after[entry.Key]; !ok {
+			changes = append(changes, searchChangeOf(SearchChangeRemoved, entry, entry.Timetables, ""))
+		}
+	}
+
+	order := map[SearchChangeKind]int{SearchChangeAdded: 0, SearchChangeRemoved: 1, SearchChangeTimetableChanged: 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if order[changes[i].Kind] != order[changes[j].Kind] {
+			return order[changes[i].Kind] < order[changes[j].Kind]
+		}
+		if changes[i].Year != changes[j].Year {
+			return changes[i].Year > changes[j].Year
+		}
+		return changes[i].Title < changes[j].Title
+	})
+	return changes
+}
+
+// uniqueSearchResults keeps the entry with the highest lecture id of each key, in the order keys first appear.
+func uniqueSearchResults(entries []SearchResultEntry) []SearchResultEntry {
+	positions := make(map[string]int, len(entries))
+	unique := make([]SearchResultEntry, 0, len(entries))
+	for _, entry := range entries {
+		position, ok := positions[entry.Key]
+		if !ok {
+			positions[entry.Key] = len(unique)
+			unique = append(unique, entry)
+			continue
+		}
+		if entry.LectureID > unique[position].LectureID {
+			unique[position] = entry
+		}
+	}
+	return unique
+}
+
+func searchChangeOf(kind SearchChangeKind, entry SearchResultEntry, before, after string) SearchChange {
+	return SearchChange{
+		Kind:      kind,
+		LectureID: entry.LectureID,
+		Code:      entry.Code,
+		Title:     entry.Title,
+		Year:      entry.Year,
+		Before:    before,
+		After:     after,
+	}
+}
+
+// SearchNotification is an inbox item listing the changes of one saved search found after a scrape.
+type SearchNotification struct {
+	ID         int
+	SearchID   int
+	SearchName string
+	Changes    []SearchChange
+	CreatedAt  time.Time
+	Read       bool
+}
+
+// SavedSearchRepository stores saved searches, the result each was last checked against, and the inbox.
+type SavedSearchRepository interface {
+	Save(search *SavedSearch) error
+	FindAll() ([]SavedSearch, error)
+	FindByID(id int) (*SavedSearch, error)
+	Delete(id int) error
+	FindResults(searchID int) ([]SearchResultEntry, error)
+	ReplaceResults(searchID int, entries []SearchResultEntry, checkedAt time.Time) error
+	AddNotification(notification *SearchNotification) error
+	FindNotifications(unreadOnly bool) ([]SearchNotification, error)
+	MarkNotificationRead(id int) error
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTimetableFingerprint(t *testing.T) {
+	timetables := []TimeTable{
+		{Semester: SemesterSpring, DayOfWeek: DayOfWeekTuesday, Period: 4, Room: Room{Name: "W241"}},
+		{Semester: SemesterSpring, DayOfWeek: DayOfWeekTuesday, Period: 3, Room: Room{Name: "W241"}},
+		{Semester: SemesterSpring, DayOfWeek: DayOfWeekTuesday, Period: 3, Room: Room{Name: "W241"}},
+	}
+	got := TimetableFingerprint(timetables)
+	want := TimetableFingerprint(timetables[:2])
+	if got != want || got == "" {
+		t.Errorf("expected duplicate slots to be ignored, got %q and %q", got, want)
+	}
+}
+
+func TestDiffSearchResults(t *testing.T) {
+	previous := []SearchResultEntry{
+		{Key: "a", LectureID: 1, Title: "線形代数第一", Year: 2025, Timetables: "1Q Tue 3"},
+		{Key: "b", LectureID: 2, Title: "哲学A", Year: 2025, Timetables: "1Q Wed 1"},
+		{Key: "c", LectureID: 3, Title: "確率と統計", Year: 2025, Timetables: "2Q Tue 5"},
+	}
+	current := []SearchResultEntry{
+		// re-scraped with a new id but the same timetable
+		{Key: "a", LectureID: 11, Title: "線形代数第一", Year: 2025, Timetables: "1Q Tue 3"},
+		{Key: "c", LectureID: 13, Title: "確率と統計", Year: 2025, Timetables: "2Q Tue 7"},
+		{Key: "d", LectureID: 14, Title: "情報理論", Year: 2025, Timetables: "3Q Tue 5"},
+	}
+
+	changes := DiffSearchResults(previous, current)
+	want := []SearchChange{
+		{Kind: SearchChangeAdded, LectureID: 14, Title: "情報理論", Year: 2025, After: "3Q Tue 5"},
+		{Kind: SearchChangeRemoved, LectureID: 2, Title: "哲学A", Year: 2025, Before: "1Q Wed 1"},
+		{Kind: SearchChangeTimetableChanged, LectureID: 13, Title: "確率と統計", Year: 2025, Before: "2Q Tue 5", After: "2Q Tue 7"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("unexpected changes:\n got %+v\nwant %+v", changes, want)
+	}
+
+	if changes := DiffSearchResults(current, current); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestNewSearchResultEntriesKeepsSections(t *testing.T) {
+	summaries := []LectureSummary{
+		{ID: 1, University: "Test University", Code: "LAS.M101", Title: "線形代数第一 (1)", Year: 2025, OpenTerm: "1Q", Timetables: []TimeTable{{Semester: SemesterSpring, DayOfWeek: DayOfWeekTuesday, Period: 3}}},
+		{ID: 2, University: "Test University", Code: "LAS.M101", Title: "線形代数第一 (2)", Year: 2025, OpenTerm: "1Q", Timetables: []TimeTable{{Semester: SemesterSpring, DayOfWeek: DayOfWeekThursday, Period: 3}}},
+		{ID: 3, University: "Test University", Code: "LAS.M101", Title: "線形代数第一 (1)", Year: 2025, OpenTerm: "3Q", Timetables: []TimeTable{{Semester: SemesterFall, DayOfWeek: DayOfWeekTuesday, Period: 3}}},
+	}
+	entries := NewSearchResultEntries(summaries)
+	if len(entries) != 3 {
+		t.Fatalf("expected every section to keep its own entry, got %+v", entries)
+	}
+	if changes := DiffSearchResults(entries, entries); len(changes) != 0 {
+		t.Errorf("expected no changes between identical sections, got %+v", changes)
+	}
+
+	// a re-scrape stores every section again under a new id while the old rows stay
+	rescraped := append(summaries, summaries...)
+	for i := len(summaries); i < len(rescraped); i++ {
+		rescraped[i].ID += 10
+	}
+	current := NewSearchResultEntries(rescraped)
+	if len(current) != 3 {
+		t.Fatalf("expected re-scraped duplicates to collapse, got %+v", current)
+	}
+	for _, entry := range current {
+		if entry.LectureID <= 10 {
+			t.Errorf("expected the newest lecture to be kept, got %+v", entry)
+		}
+	}
+	if changes := DiffSearchResults(entries, current); len(changes) != 0 {
+		t.Errorf("expected no changes after a re-scrape, got %+v", changes)
+	}
+}
@@ -8,6 +8,7 @@ type Teacher struct {
 
 type TeacherRepository interface {
 	FindByID(id int) (*Teacher, error)	
+	FindByName(name string) ([]Teacher, error)
 	Create(teacher *Teacher) error
 	Creates(teachers []Teacher) error
 	Update(teacher *Teacher) error
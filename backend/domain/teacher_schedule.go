@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TeacherLecture is a lecture as it appears in a teacher's timetable.
+type TeacherLecture struct {
+	LectureID int
+	Code      string
+	Title     string
+	Credit    int
+	Kind      TimeTableKind
+	Room      Room
+}
+
+// AmbiguousTeacherError reports a name shared by several teachers, such as "山田 太郎" and "山田太郎"
+// stored from different pages. Callers pick one of TeacherIDs and ask again by id.
+type AmbiguousTeacherError struct {
+	Name       string
+	TeacherIDs []int
+}
+
+func (e *AmbiguousTeacherError) Error() string {
+	ids := make([]string, len(e.TeacherIDs))
+	for i, id := range e.TeacherIDs {
+		ids[i] = fmt.Sprint(id)
+	}
+	return fmt.Sprintf("teacher name %s matches several teachers; use one of the ids %s", e.Name, strings.Join(ids, ", "))
+}
+
+// TeacherSlot is one weekly period of a quarter and the lectures taught in it.
+type TeacherSlot struct {
+	DayOfWeek DayOfWeek
+	Period    Period
+	Lectures  []TeacherLecture
+}
+
+// TeacherWorkload counts distinct courses, their credits and the weekly periods they occupy.
+type TeacherWorkload struct {
+	Courses  int
+	Credits  int
+	Sessions int
+}
+
+// TeacherQuarter is the weekly grid of a teacher within one quarter.
+// Lectures without a weekly slot, such as intensive or on-demand ones, are listed in Unscheduled.
+type TeacherQuarter struct {
+	Semester    Semester
+	Slots       []TeacherSlot
+	Unscheduled []TeacherLecture
+	Workload    TeacherWorkload
+}
+
+// TeacherSchedule is the timetable and workload of a teacher for one academic year.
+type TeacherSchedule struct {
+	Teacher  Teacher
+	Year     int
+	Quarters []TeacherQuarter
+	Workload TeacherWorkload
+}
+
+// NewTeacherSchedule arranges the lectures of a teacher into per-quarter weekly grids.
+// Quarter credits count every course held in the quarter, so a course spanning two
+// quarters contributes to both, while the yearly workload counts it once.
+func NewTeacherSchedule(teacher Teacher, year int, lectures []LectureSummary) TeacherSchedule {
+	order := []Semester{SemesterSpring, SemesterSummer, SemesterFall, SemesterWinter}
+	schedule := TeacherSchedule{Teacher: teacher, Year: year, Quarters: make([]TeacherQuarter, len(order))}
+	for i, semester := range order {
+		schedule.Quarters[i] = TeacherQuarter{Semester: semester, Slots: make([]TeacherSlot, 0), Unscheduled: make([]TeacherLecture, 0)}
+	}
+
+	type slotKey struct {
+		day    DayOfWeek
+		period Period
+	}
+	slotIndex := make([]map[slotKey]int, len(order))
+	quarterCourses := make([]map[int]struct{}, len(order))
+	for i := range order {
+		slotIndex[i] = make(map[slotKey]int)
+		quarterCourses[i] = make(map[int]struct{})
+	}
+	yearCourses := make(map[int]struct{})
+
+	for _, summary := range lectures {
+		if _, ok := yearCourses[summary.ID]; !ok {
+			yearCourses[summary.ID] = struct{}{}
+			schedule.Workload.Courses++
+			schedule.Workload.Credits += summary.Credit
+		}
+
+		for _, timetable := range summary.Timetables {
+			idx := timetable.Semester.Index()
+			if idx < 0 {
+				continue
+			}
+			quarter := &schedule.Quarters[idx]
+
+			if _, ok := quarterCourses[idx][summary.ID]; !ok {
+				quarterCourses[idx][summary.ID] = struct{}{}
+				quarter.Workload.Courses++
+				quarter.Workload.Credits += summary.Credit
+			}
+
+			kind := timetable.Kind
+			if kind == "" {
+				kind = TimeTableKindRegular
+			}
+			entry := TeacherLecture{
+				LectureID: summary.ID,
+				Code:      summary.Code,
+				Title:     strings.TrimSpace(summary.Title),
+				Credit:    summary.Credit,
+				Kind:      kind,
+				Room:      timetable.Room,
+			}
+
+			if kind != TimeTableKindRegular || timetable.DayOfWeek == "" || timetable.Period == 0 {
+				if !containsTeacherLecture(quarter.Unscheduled, summary.ID) {
+					quarter.Unscheduled = append(quarter.Unscheduled, entry)
+				}
+				continue
+			}
+
+			key := slotKey{day: timetable.DayOfWeek, period: timetable.Period}
+			slot, ok := slotIndex[idx][key]
+			if !ok {
+				slot = len(quarter.Slots)
+				slotIndex[idx][key] = slot
+				quarter.Slots = append(quarter.Slots, TeacherSlot{DayOfWeek: key.day, Period: key.period})
+			}
+			if !containsTeacherLecture(quarter.Slots[slot].Lectures, summary.ID) {
+				quarter.Slots[slot].Lectures = append(quarter.Slots[slot].Lectures, entry)
+			}
+		}
+	}
+
+	for i := range schedule.Quarters {
+		quarter := &schedule.Quarters[i]
+		sort.SliceStable(quarter.Slots, func(a, b int) bool {
+			if quarter.Slots[a].DayOfWeek.Index() != quarter.Slots[b].DayOfWeek.Index() {
+				return quarter.Slots[a].DayOfWeek.Index() < quarter.Slots[b].DayOfWeek.Index()
+			}
+			return quarter.Slots[a].Period < quarter.Slots[b].Period
+		})
+		quarter.Workload.Sessions = len(quarter.Slots)
+		schedule.Workload.Sessions += quarter.Workload.Sessions
+	}
+
+	return schedule
+}
+
+func containsTeacherLecture(lectures []TeacherLecture, lectureID int) bool {
+	for _, lecture := range lectures {
+		if lecture.LectureID == lectureID {
+			return true
+		}
+	}
+	return false
+}
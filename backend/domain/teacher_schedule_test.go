@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestNewTeacherScheduleBuildsQuarterGrids(t *testing.T) {
+	lectures := []LectureSummary{
+		{
+			ID:     1,
+			Code:   "MTH.A201",
+			Title:  "線形代数",
+			Credit: 2,
+			Timetables: []TimeTable{
+				{Kind: TimeTableKindRegular, Semester: SemesterSpring, DayOfWeek: DayOfWeekThursday, Period: Period3, Room: Room{Name: "W5-104"}},
+				{Kind: TimeTableKindRegular, Semester: SemesterSpring, DayOfWeek: DayOfWeekMonday, Period: Period3, Room: Room{Name: "W5-104"}},
+				{Kind: TimeTableKindRegular, Semester: SemesterSummer, DayOfWeek: DayOfWeekMonday, Period: Period3, Room: Room{Name: "W5-104"}},
+			},
+		},
+		{
+			ID:     2,
+			Code:   "MTH.A301",
+			Title:  "集中講義",
+			Credit: 1,
+			Timetables: []TimeTable{
+				{Kind: TimeTableKindIntensive, Semester: SemesterSpring},
+			},
+		},
+	}
+
+	schedule := NewTeacherSchedule(Teacher{ID: 7, Name: "山田 太郎"}, 2025, lectures)
+
+	if len(schedule.Quarters) != 4 {
+		t.Fatalf("expected 4 quarters, got %d", len(schedule.Quarters))
+	}
+
+	spring := schedule.Quarters[0]
+	if spring.Semester != SemesterSpring {
+		t.Fatalf("unexpected first quarter: %s", spring.Semester)
+	}
+	if len(spring.Slots) != 2 {
+		t.Fatalf("expected 2 spring slots, got %d", len(spring.Slots))
+	}
+	if spring.Slots[0].DayOfWeek != DayOfWeekMonday || spring.Slots[1].DayOfWeek != DayOfWeekThursday {
+		t.Errorf("expected slots ordered by day: %+v", spring.Slots)
+	}
+	if spring.Slots[0].Lectures[0].Room.Name != "W5-104" {
+		t.Errorf("expected room to be carried into the slot: %+v", spring.Slots[0].Lectures[0])
+	}
+	if len(spring.Unscheduled) != 1 || spring.Unscheduled[0].Kind != TimeTableKindIntensive {
+		t.Errorf("expected intensive lecture to be unscheduled: %+v", spring.Unscheduled)
+	}
+	if spring.Workload != (TeacherWorkload{Courses: 2, Credits: 3, Sessions: 2}) {
+		t.Errorf("unexpected spring workload: %+v", spring.Workload)
+	}
+
+	summer := schedule.Quarters[1]
+	if summer.Workload != (TeacherWorkload{Courses: 1, Credits: 2, Sessions: 1}) {
+		t.Errorf("unexpected summer workload: %+v", summer.Workload)
+	}
+
+	if schedule.Workload != (TeacherWorkload{Courses: 2, Credits: 3, Sessions: 3}) {
+		t.Errorf("unexpected yearly workload: %+v", schedule.Workload)
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"strings"
+	"time"
 )
 
 type Semester string
@@ -67,6 +68,22 @@ func FromQuarter(quarter string) []Semester {
 	return nil
 }
 
+// Index returns the position of the semester within the academic year, or -1 when it is unknown.
+func (s Semester) Index() int {
+	switch s {
+	case SemesterSpring:
+		return 0
+	case SemesterSummer:
+		return 1
+	case SemesterFall:
+		return 2
+	case SemesterWinter:
+		return 3
+	default:
+		return -1
+	}
+}
+
 func quarterIndex(token string) (int, bool) {
 	token = strings.TrimSpace(token)
 	switch token {
@@ -83,6 +100,14 @@ func quarterIndex(token string) (int, bool) {
 	}
 }
 
+// AcademicYear returns the academic year containing t; January to March belong to the previous year.
+func AcademicYear(t time.Time) int {
+	if t.Month() < time.April {
+		return t.Year() - 1
+	}
+	return t.Year()
+}
+
 type DayOfWeek string
 
 const (
@@ -95,6 +120,28 @@ const (
 	DayOfWeekSunday    DayOfWeek = "sunday"
 )
 
+// Index returns the position of the day within the week starting on Monday, or -1 when it is unknown.
+func (d DayOfWeek) Index() int {
+	switch d {
+	case DayOfWeekMonday:
+		return 0
+	case DayOfWeekTuesday:
+		return 1
+	case DayOfWeekWednesday:
+		return 2
+	case DayOfWeekThursday:
+		return 3
+	case DayOfWeekFriday:
+		return 4
+	case DayOfWeekSaturday:
+		return 5
+	case DayOfWeekSunday:
+		return 6
+	default:
+		return -1
+	}
+}
+
 type Period int
 
 const (
@@ -112,17 +159,25 @@ const (
 	Period12 Period = 12
 )
 
+// TimeTableKind distinguishes weekly slots from courses that do not occupy one.
+type TimeTableKind string
+
+const (
+	TimeTableKindRegular   TimeTableKind = "regular"
+	TimeTableKindIntensive TimeTableKind = "intensive"
+	TimeTableKindTBA       TimeTableKind = "tba"
+	TimeTableKindOndemand  TimeTableKind = "ondemand"
+)
+
 type TimeTable struct {
 	LectureID int
+	Kind      TimeTableKind
 	Semester  Semester
 	Room      Room
 	DayOfWeek DayOfWeek
 	Period    Period
-}
-
-type Room struct {
-	ID   int
-	Name string
+	StartDate time.Time
+	EndDate   time.Time
 }
 
 type TimeTableRepository interface {
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReportEntry is a lecture as it appears in a cell of a printed timetable.
+type ReportEntry struct {
+	LectureID int
+	Code      string
+	Title     string
+	Room      string
+	Teachers  []string
+	Kind      TimeTableKind
+}
+
+// ReportRow is one period of a quarter grid; Cells holds the entries of each day of ReportQuarter.Days.
+type ReportRow struct {
+	Period Period
+	Cells  [][]ReportEntry
+}
+
+// ReportQuarter is the weekly grid of the selected lectures within one quarter.
+// Lectures without a weekly slot, such as intensive or on-demand ones, are listed in Unscheduled.
+type ReportQuarter struct {
+	Semester    Semester
+	Days        []DayOfWeek
+	Rows        []ReportRow
+	Unscheduled []ReportEntry
+}
+
+// TimetableReport is a printable timetable of a set of lectures, followed by their syllabi.
+type TimetableReport struct {
+	Title    string
+	Quarters []ReportQuarter
+	Lectures []Lecture
+}
+
+// NewTimetableReport arranges lectures into per-quarter weekly grids. Quarters without lectures are
+// left out; each grid spans Monday to Friday, or to the latest day used, and the first period to the
+// latest one used. Lectures without a quarter appear in the appendix only.
+func NewTimetableReport(title string, lectures []Lecture) TimetableReport {
+	order := []Semester{SemesterSpring, SemesterSummer, SemesterFall, SemesterWinter}
+	type slotKey struct {
+		day    DayOfWeek
+		period Period
+	}
+	slots := make([]map[slotKey][]ReportEntry, len(order))
+	unscheduled := make([][]ReportEntry, len(order))
+	used := make([]bool, len(order))
+	lastDay := make([]int, len(order))
+	lastPeriod := make([]Period, len(order))
+	for i := range order {
+		slots[i] = make(map[slotKey][]ReportEntry)
+		lastDay[i] = DayOfWeekFriday.Index()
+	}
+
+	for _, lecture := range lectures {
+		teachers := make([]string, 0, len(lecture.Teachers))
+		for _, teacher := range lecture.Teachers {
+			if name := strings.TrimSpace(teacher.Name); name != "" {
+				teachers = append(teachers, name)
+			}
+		}
+
+		for _, timetable := range lecture.Timetables {
+			idx := timetable.Semester.Index()
+			if idx < 0 {
+				continue
+			}
+			used[idx] = true
+
+			kind := timetable.Kind
+			if kind == "" {
+				kind = TimeTableKindRegular
+			}
+			entry := ReportEntry{
+				LectureID: lecture.ID,
+				Code:      lecture.Code,
+				Title:     strings.TrimSpace(lecture.Title),
+				Room:      timetable.Room.Name,
+				Teachers:  teachers,
+				Kind:      kind,
+			}
+
+			if kind != TimeTableKindRegular || timetable.DayOfWeek.Index() < 0 || timetable.Period == 0 {
+				if !containsReportEntry(unscheduled[idx], lecture.ID) {
+					unscheduled[idx] = append(unscheduled[idx], entry)
+				}
+				continue
+			}
+
+			key := slotKey{day: timetable.DayOfWeek, period: timetable.Period}
+			if containsReportEntry(slots[idx][key], lecture.ID) {
+				continue
+			}
+			slots[idx][key] = append(slots[idx][key], entry)
+			if day := timetable.DayOfWeek.Index(); day > lastDay[idx] {
+				lastDay[idx] = day
+			}
+			if timetable.Period > lastPeriod[idx] {
+				lastPeriod[idx] = timetable.Period
+			}
+		}
+	}
+
+	week := []DayOfWeek{DayOfWeekMonday, DayOfWeekTuesday, DayOfWeekWednesday, DayOfWeekThursday, DayOfWeekFriday, DayOfWeekSaturday, DayOfWeekSunday}
+	report := TimetableReport{Title: title, Quarters: make([]ReportQuarter, 0), Lectures: lectures}
+	for i, semester := range order {
+		if !used[i] {
+			continue
+		}
+		quarter := ReportQuarter{
+			Semester:    semester,
+			Days:        week[:lastDay[i]+1],
+			Rows:        make([]ReportRow, 0, int(lastPeriod[i])),
+			Unscheduled: unscheduled[i],
+		}
+		for period := Period1; period <= lastPeriod[i]; period++ {
+			row := ReportRow{Period: period, Cells: make([][]ReportEntry, len(quarter.Days))}
+			for d, day := range quarter.Days {
+				row.Cells[d] = slots[i][slotKey{day: day, period: period}]
+			}
+			quarter.Rows = append(quarter.Rows, row)
+		}
+		if quarter.Unscheduled == nil {
+			quarter.Unscheduled = make([]ReportEntry, 0)
+		}
+		sort.SliceStable(quarter.Unscheduled, func(a, b int) bool { return quarter.Unscheduled[a].Code < quarter.Unscheduled[b].Code })
+		report.Quarters = append(report.Quarters, quarter)
+	}
+	return report
+}
+
+func containsReportEntry(entries []ReportEntry, lectureID int) bool {
+	for _, entry := range entries {
+		if entry.LectureID == lectureID {
+			return true
+		}
+	}
+	return false
+}
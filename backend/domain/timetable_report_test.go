@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+func TestNewTimetableReport(t *testing.T) {
+	lectures := []Lecture{
+		{
+			ID:       1,
+			Code:     "MTH.A201",
+			Title:    "線形代数",
+			Teachers: []Teacher{{Name: "山田 太郎"}},
+			Timetables: []TimeTable{
+				{Semester: SemesterSpring, DayOfWeek: DayOfWeekMonday, Period: Period1, Room: Room{Name: "W5-104"}},
+				{Semester: SemesterSpring, DayOfWeek: DayOfWeekMonday, Period: Period2, Room: Room{Name: "W5-104"}},
+				{Semester: SemesterSummer, DayOfWeek: DayOfWeekSaturday, Period: Period3},
+			},
+		},
+		{
+			ID:         2,
+			Code:       "MTH.A202",
+			Title:      "解析学",
+			Timetables: []TimeTable{{Semester: SemesterSpring, Kind: TimeTableKindIntensive}},
+		},
+		{ID: 3, Code: "MTH.A203", Title: "時期未定"},
+	}
+
+	report := NewTimetableReport("時間割", lectures)
+	if len(report.Quarters) != 2 || report.Quarters[0].Semester != SemesterSpring || report.Quarters[1].Semester != SemesterSummer {
+		t.Fatalf("expected spring and summer quarters, got %+v", report.Quarters)
+	}
+	if len(report.Lectures) != 3 {
+		t.Fatalf("appendix should keep every lecture, got %d", len(report.Lectures))
+	}
+
+	spring := report.Quarters[0]
+	if len(spring.Days) != 5 || len(spring.Rows) != 2 {
+		t.Fatalf("spring grid should span Monday to Friday and two periods, got %d days and %d rows", len(spring.Days), len(spring.Rows))
+	}
+	cell := spring.Rows[1].Cells[0]
+	if len(cell) != 1 || cell[0].Room != "W5-104" || cell[0].Teachers[0] != "山田 太郎" {
+		t.Fatalf("unexpected Monday second period cell: %+v", cell)
+	}
+	if len(spring.Rows[0].Cells[1]) != 0 {
+		t.Fatalf("Tuesday should be empty")
+	}
+	if len(spring.Unscheduled) != 1 || spring.Unscheduled[0].LectureID != 2 {
+		t.Fatalf("intensive lecture should be unscheduled, got %+v", spring.Unscheduled)
+	}
+
+	summer := report.Quarters[1]
+	if len(summer.Days) != 6 || len(summer.Rows) != 3 || len(summer.Rows[2].Cells[5]) != 1 {
+		t.Fatalf("summer grid should extend to Saturday third period, got %+v", summer)
+	}
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// TranscriptEntry is a course the student has taken. LectureID is the stored lecture it was matched
+// to, or zero when none matched.
+type TranscriptEntry struct {
+	ID        int
+	Code      string
+	Title     string
+	Credit    int
+	Grade     string
+	Year      int
+	Passed    bool
+	LectureID int
+}
+
+// TranscriptRepository stores transcript entries. Save replaces entries with the same code, title and year.
+type TranscriptRepository interface {
+	Save(entries []TranscriptEntry) (int, error)
+	FindAll() ([]TranscriptEntry, error)
+	Delete(id int) error
+}
+
+var passingGrades = map[string]bool{
+	"A+": true, "A": true, "B": true, "C": true, "P": true, "S": true, "PASS": true,
+	"秀": true, "優": true, "良": true, "可": true, "合": true, "合格": true, "認定": true, "修了": true,
+}
+
+// GradePassed reports whether a grade earns the credits. Scores pass from 60 and the usual letter and
+// Japanese passing grades pass; anything else, including an empty grade of a course in progress, does not.
+func GradePassed(grade string) bool {
+	grade = strings.ToUpper(strings.TrimSpace(contactWidthReplacer.Replace(grade)))
+	if grade == "" {
+		return false
+	}
+	if score, err := strconv.ParseFloat(grade, 64); err == nil {
+		return score >= 60
+	}
+	return passingGrades[grade]
+}
+
+// transcriptColumns maps each entry field to the headers used for it by CSV files and grade exports.
+var transcriptColumns = map[string][]string{
+	"code":   {"科目コード", "科目番号", "授業科目番号", "code", "course code", "course number"},
+	"title":  {"科目名", "授業科目名", "title", "course title", "course name"},
+	"credit": {"単位数", "単位", "credit", "credits"},
+	"grade":  {"評価", "成績", "評語", "点数", "grade", "score"},
+	"year":   {"年度", "修得年度", "履修年度", "year", "academic year"},
+}
+
+// ErrTranscriptEncoding is returned for files that are neither UTF-8 nor Shift_JIS.
+var ErrTranscriptEncoding = errors.New("transcript is neither UTF-8 nor Shift_JIS encoded; save it as UTF-8 and import again")
+
+// ParseTranscript reads a transcript from a CSV file or a tab-separated grade export. Files that are not
+// UTF-8 are decoded as Shift_JIS, the encoding Excel and the grade system export on Japanese Windows.
+// Rows before the header, such as the student information at the top of a grade export, are skipped.
+// The header must name a code or title column; the other columns are optional.
+func ParseTranscript(r io.Reader) ([]TranscriptEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read transcript: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		if data, err = decodeShiftJIS(data); err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = transcriptDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse transcript: %w", err)
+	}
+
+	columns := map[string]int(nil)
+	entries := make([]TranscriptEntry, 0)
+	for _, record := range records {
+		if columns == nil {
+			columns = transcriptHeader(record)
+			continue
+		}
+
+		entry := TranscriptEntry{
+			Code:  strings.ToUpper(transcriptField(record, columns, "code")),
+			Title: transcriptField(record, columns, "title"),
+			Grade: transcriptField(record, columns, "grade"),
+		}
+		if entry.Code == "" && entry.Title == "" {
+			continue
+		}
+		entry.Credit = parseFirstNumber(transcriptField(record, columns, "credit"))
+		entry.Year = parseFirstNumber(transcriptField(record, columns, "year"))
+		entry.Passed = GradePassed(entry.Grade)
+		entries = append(entries, entry)
+	}
+	if columns == nil {
+		return nil, errors.New("transcript has no header with a course code or title column")
+	}
+	return entries, nil
+}
+
+// decodeShiftJIS converts Shift_JIS to UTF-8. The decoder replaces undefined bytes instead of failing,
+// so a replacement character in the result marks a file in neither encoding.
+func decodeShiftJIS(data []byte) ([]byte, error) {
+	decoded, _, err := transform.Bytes(japanese.ShiftJIS.NewDecoder(), data)
+	if err != nil || bytes.ContainsRune(decoded, utf8.RuneError) {
+		return nil, ErrTranscriptEncoding
+	}
+	return decoded, nil
+}
+
+// transcriptDelimiter picks tabs when the first line containing a tab has more tabs than commas.
+func transcriptDelimiter(data []byte) rune {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		tabs, commas := strings.Count(line, "\t"), strings.Count(line, ",")
+		if tabs > 0 || commas > 0 {
+			if tabs > commas {
+				return '\t'
+			}
+			return ','
+		}
+	}
+	return ','
+}
+
+// transcriptHeader returns the column of each field when record is a header row, or nil otherwise.
+func transcriptHeader(record []string) map[string]int {
+	columns := make(map[string]int)
+	for index, cell := range record {
+		cell = strings.ToLower(strings.TrimSpace(contactWidthReplacer.Replace(cell)))
+		for field, aliases := range transcriptColumns {
+			if _, ok := columns[field]; ok {
+				continue
+			}
+			for _, alias := range aliases {
+				if cell == alias {
+					columns[field] = index
+					break
+				}
+			}
+		}
+	}
+	_, hasCode := columns["code"]
+	_, hasTitle := columns["title"]
+	if !hasCode && !hasTitle {
+		return nil
+	}
+	return columns
+}
+
+func transcriptField(record []string, columns map[string]int, field string) string {
+	index, ok := columns[field]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(contactWidthReplacer.Replace(record[index]))
+}
+
+func parseFirstNumber(raw string) int {
+	digits := strings.Builder{}
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		} else if digits.Len() > 0 {
+			break
+		}
+	}
+	value, _ := strconv.Atoi(digits.String())
+	return value
+}
+
+// TranscriptSummary totals the credits of a transcript. Courses without a grade are in progress
+// and count toward neither earned nor attempted credits.
+type TranscriptSummary struct {
+	EarnedCredits     int
+	AttemptedCredits  int
+	PassedCourses     int
+	FailedCourses     int
+	InProgressCourses int
+	CreditsByYear     map[int]int
+}
+
+// SummarizeTranscript totals earned and attempted credits; CreditsByYear counts earned credits.
+func SummarizeTranscript(entries []TranscriptEntry) TranscriptSummary {
+	summary := TranscriptSummary{CreditsByYear: make(map[int]int)}
+	for _, entry := range entries {
+		switch {
+		case entry.Passed:
+			summary.AttemptedCredits += entry.Credit
+			summary.EarnedCredits += entry.Credit
+			summary.PassedCourses++
+			summary.CreditsByYear[entry.Year] += entry.Credit
+		case strings.TrimSpace(entry.Grade) == "":
+			summary.InProgressCourses++
+		default:
+			summary.AttemptedCredits += entry.Credit
+			summary.FailedCourses++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestGradePassed(t *testing.T) {
+	cases := map[string]bool{
+		"85": true, "60": true, "59": false, "８０": true,
+		"A": true, "a+": true, "秀": true, "合格": true, "可": true,
+		"F": false, "不可": false, "不合格": false, "": false, "保留": false,
+	}
+	for grade, want := range cases {
+		if got := GradePassed(grade); got != want {
+			t.Errorf("%q: expected %v, got %v", grade, want, got)
+		}
+	}
+}
+
+func TestParseTranscriptCSV(t *testing.T) {
+	raw := "\ufeff科目コード,科目名,単位数,評価,年度\n" +
+		"LAS.M101,線形代数第一,2,85,2024\n" +
+		"las.m102,\"微分積分第一\",2単位,F,2024年度\n" +
+		",,,,\n" +
+		"LAH.S101,哲学A,2,,2025\n"
+
+	entries, err := ParseTranscript(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseTranscript returned error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+	want := TranscriptEntry{Code: "LAS.M102", Title: "微分積分第一", Credit: 2, Grade: "F", Year: 2024}
+	if entries[1] != want {
+		t.Errorf("expected %+v, got %+v", want, entries[1])
+	}
+	if !entries[0].Passed || entries[2].Passed {
+		t.Errorf("unexpected pass flags: %+v", entries)
+	}
+
+	summary := SummarizeTranscript(entries)
+	if summary.EarnedCredits != 2 || summary.AttemptedCredits != 4 || summary.PassedCourses != 1 ||
+		summary.FailedCourses != 1 || summary.InProgressCourses != 1 || summary.CreditsByYear[2024] != 2 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestParseTranscriptGradeExport(t *testing.T) {
+	raw := "学籍番号\t24B00000\n" +
+		"氏名\t東工 太郎\n" +
+		"\n" +
+		"履修年度\t授業科目番号\t授業科目名\t単位\t評語\n" +
+		"2024\tMTH.A201\t代数学\t２\t秀\n"
+
+	entries, err := ParseTranscript(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseTranscript returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %+v", entries)
+	}
+	if entries[0].Code != "MTH.A201" || entries[0].Credit != 2 || entries[0].Year != 2024 || !entries[0].Passed {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+}
+
+func TestParseTranscriptShiftJIS(t *testing.T) {
+	// a grade export saved by Excel on Japanese Windows
+	raw := "科目コード,科目名,単位数,成績,年度\r\n" +
+		"LAS.M101,線形代数第一,2,秀,2024\r\n" +
+		"LAH.S101,哲学Ａ,１,不可,2024\r\n"
+	fixture, err := japanese.ShiftJIS.NewEncoder().String(raw)
+	if err != nil {
+		t.Fatalf("encode fixture: %v", err)
+	}
+
+	entries, err := ParseTranscript(strings.NewReader(fixture))
+	if err != nil {
+		t.Fatalf("ParseTranscript returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	if entries[0].Title != "線形代数第一" || entries[0].Grade != "秀" || !entries[0].Passed {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+	if entries[1].Title != "哲学Ａ" || entries[1].Credit != 1 || entries[1].Passed {
+		t.Errorf("unexpected entry: %+v", entries[1])
+	}
+}
+
+func TestParseTranscriptErrors(t *testing.T) {
+	// 0xFD to 0xFF are undefined in both UTF-8 and Shift_JIS
+	if _, err := ParseTranscript(strings.NewReader("\xfd\xfe\xff,code\n")); !errors.Is(err, ErrTranscriptEncoding) {
+		t.Errorf("expected encoding error, got %v", err)
+	}
+	if _, err := ParseTranscript(strings.NewReader("a,b\n1,2\n")); err == nil {
+		t.Errorf("expected missing header error")
+	}
+}
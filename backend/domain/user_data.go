@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CourseIdentity identifies a lecture across re-scrapes, which replace lecture rows and their ids.
+// The title is part of the identity so that sections sharing a code keep their own data; it is stored
+// without whitespace so that a title corrected only in spacing keeps the user's data attached.
+type CourseIdentity struct {
+	University string
+	Code       string
+	Title      string
+	Year       int
+}
+
+// IdentityOf returns the identity of a lecture.
+func IdentityOf(university, code, title string, year int) CourseIdentity {
+	return CourseIdentity{
+		University: strings.TrimSpace(university),
+		Code:       strings.ToUpper(strings.TrimSpace(code)),
+		Title:      CourseIdentityTitle(title),
+		Year:       year,
+	}
+}
+
+// CourseIdentityTitle is the title as it appears in a course identity: with every Unicode space removed,
+// so titles that differ only in spacing or in full-width and no-break spaces name the same course.
+func CourseIdentityTitle(title string) string {
+	return strings.Join(strings.Fields(title), "")
+}
+
+// CourseNote is the user's private note on a course.
+type CourseNote struct {
+	Course    CourseIdentity
+	Body      string
+	UpdatedAt time.Time
+}
+
+// UserDataRepository stores the user's bookmarks, tags and notes. Tags are stored trimmed and
+// lower-cased; saving an empty note deletes it.
+type UserDataRepository interface {
+	SetBookmark(course CourseIdentity, bookmarked bool) error
+	IsBookmarked(course CourseIdentity) (bool, error)
+	AddTag(course CourseIdentity, tag string) error
+	RemoveTag(course CourseIdentity, tag string) error
+	FindTags(course CourseIdentity) ([]string, error)
+	ListTags() ([]string, error)
+	SaveNote(course CourseIdentity, body string) error
+	FindNote(course CourseIdentity) (*CourseNote, error)
+}
+
+// NormalizeTag trims and lower-cases a tag so that "Math" and "math " are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// Key returns the identity as a single comparable string.
+func (c CourseIdentity) Key() string {
+	return c.University + "|" + c.Code + "|" + c.Title + "|" + strconv.Itoa(c.Year)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "testing"
+
+func TestIdentityOf(t *testing.T) {
+	withCode := IdentityOf(" Test University ", " las.m101 ", "線形代数 第一", 2025)
+	if withCode != (CourseIdentity{University: "Test University", Code: "LAS.M101", Title: "線形代数第一", Year: 2025}) {
+		t.Errorf("expected the title to be kept without whitespace, got %+v", withCode)
+	}
+	if section := IdentityOf("Test University", "LAS.M101", "線形代数第一 (演習)", 2025); section == withCode {
+		t.Errorf("expected sections sharing a code to have their own identity, got %+v", section)
+	}
+
+	withoutCode := IdentityOf("Test University", "", " 特別講義 ", 2025)
+	if withoutCode != (CourseIdentity{University: "Test University", Title: "特別講義", Year: 2025}) {
+		t.Errorf("expected the title to identify a lecture without code, got %+v", withoutCode)
+	}
+
+	if tag := NormalizeTag("  Math "); tag != "math" {
+		t.Errorf("expected normalized tag, got %q", tag)
+	}
+}
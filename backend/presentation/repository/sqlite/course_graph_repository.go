@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// CourseGraphRepository builds course graphs from stored lectures and their relations.
+type CourseGraphRepository struct {
+	db *sql.DB
+}
+
+// NewCourseGraphRepository creates a course graph repository for the provided database handle.
+func NewCourseGraphRepository(db *sql.DB) (*CourseGraphRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &CourseGraphRepository{db: db}, nil
+}
+
+// FindCourseGraph loads the lectures of a year, or of every year when year is zero, with the relations
+// of the given kinds between them. related_courses entries are read as related relations.
+func (r *CourseGraphRepository) FindCourseGraph(year int, kinds []domain.CourseRelationKind) (*domain.CourseGraph, error) {
+	nodes := make([]domain.CourseNode, 0)
+	rows, err := r.db.Query(`SELECT id, IFNULL(code, ''), title, IFNULL(level, 0), IFNULL(year, 0)
+		FROM lectures
+		WHERE ? = 0 OR year = ?
+		ORDER BY id`, year, year)
+	if err != nil {
+		return nil, fmt.Errorf("select course graph lectures: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var node domain.CourseNode
+		var level int
+		if err := rows.Scan(&node.LectureID, &node.Code, &node.Title, &level, &node.Year); err != nil {
+			return nil, fmt.Errorf("scan course graph lecture: %w", err)
+		}
+		node.Level = domain.FromLevel(level)
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate course graph lectures: %w", err)
+	}
+
+	query := `SELECT lecture_id, related_lecture_id, kind FROM course_relations
+		UNION
+		SELECT lecture_id, related_lecture_id, ? FROM related_courses`
+	args := []any{string(domain.CourseRelationRelated)}
+	if len(kinds) > 0 {
+		query = `SELECT lecture_id, related_lecture_id, kind FROM (` + query + `) WHERE kind IN (` + placeholders(len(kinds)) + `)`
+		for _, kind := range kinds {
+			args = append(args, strings.TrimSpace(string(kind)))
+		}
+	}
+	query += ` ORDER BY 1, 2, 3`
+
+	edgeRows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select course relations: %w", err)
+	}
+	defer edgeRows.Close()
+
+	edges := make([]domain.CourseEdge, 0)
+	for edgeRows.Next() {
+		var relation domain.CourseRelation
+		var kind string
+		if err := edgeRows.Scan(&relation.LectureID, &relation.RelatedLectureID, &kind); err != nil {
+			return nil, fmt.Errorf("scan course relation: %w", err)
+		}
+		relation.Kind = domain.CourseRelationKind(kind)
+		edges = append(edges, domain.EdgeFromRelation(relation))
+	}
+	if err := edgeRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate course relations: %w", err)
+	}
+
+	return domain.NewCourseGraph(nodes, edges), nil
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestCourseGraphRepositoryFindCourseGraph(t *testing.T) {
+	lectureRepo, db := newTestRepository(t)
+
+	lectures := []domain.Lecture{
+		{University: "Test University", Title: "線形代数第一", Code: "LAS.M101", Level: domain.LevelBachelor1, Year: 2025},
+		{University: "Test University", Title: "確率と統計", Code: "MTH.B201", Level: domain.LevelBachelor2, Year: 2025, RelatedCourseCodes: []string{"MTH.A201"}},
+		{
+			University: "Test University", Title: "代数学", Code: "MTH.A201", Level: domain.LevelBachelor2, Year: 2025,
+			CourseReferences: []domain.CourseReference{{Kind: domain.CourseRelationPrerequisite, Code: "LAS.M101"}},
+		},
+		{University: "Test University", Title: "代数学", Code: "MTH.A201", Year: 2024},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	if _, err := lectureRepo.MigrateRelatedCourses(context.Background()); err != nil {
+		t.Fatalf("MigrateRelatedCourses returned error: %v", err)
+	}
+	if _, err := lectureRepo.MigrateCourseReferences(context.Background()); err != nil {
+		t.Fatalf("MigrateCourseReferences returned error: %v", err)
+	}
+
+	repo, err := NewCourseGraphRepository(db)
+	if err != nil {
+		t.Fatalf("NewCourseGraphRepository returned error: %v", err)
+	}
+
+	graph, err := repo.FindCourseGraph(2025, nil)
+	if err != nil {
+		t.Fatalf("FindCourseGraph returned error: %v", err)
+	}
+	if len(graph.Nodes) != 3 {
+		t.Fatalf("expected the 2025 lectures only, got %+v", graph.Nodes)
+	}
+	if node, _ := graph.Node(1); node.Level != domain.LevelBachelor1 || node.Code != "LAS.M101" {
+		t.Errorf("unexpected node: %+v", node)
+	}
+	want := []domain.CourseEdge{
+		{From: 3, To: 2, Kind: domain.CourseRelationRelated},
+		{From: 1, To: 3, Kind: domain.CourseRelationPrerequisite},
+	}
+	if !reflect.DeepEqual(graph.Edges, want) {
+		t.Fatalf("unexpected edges: %+v", graph.Edges)
+	}
+
+	prerequisites, err := repo.FindCourseGraph(0, []domain.CourseRelationKind{domain.CourseRelationPrerequisite})
+	if err != nil {
+		t.Fatalf("FindCourseGraph returned error: %v", err)
+	}
+	if len(prerequisites.Nodes) != 4 || len(prerequisites.Edges) != 1 || prerequisites.Edges[0] != want[1] {
+		t.Errorf("unexpected prerequisite graph: %+v", prerequisites.Edges)
+	}
+}
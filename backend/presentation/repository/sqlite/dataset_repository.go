@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// DatasetRepository answers the questions dataset export and import ask about the whole database.
+type DatasetRepository struct {
+	db *sql.DB
+}
+
+// NewDatasetRepository creates a dataset repository for the provided database handle.
+func NewDatasetRepository(db *sql.DB) (*DatasetRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &DatasetRepository{db: db}, nil
+}
+
+// FindDatasetKeys maps the dataset key of every stored lecture to its update stamp. A lecture stored again
+// by a re-scrape or an import keeps its key, so the row with the highest id wins.
+func (r *DatasetRepository) FindDatasetKeys() (map[string]domain.LectureUpdateStamp, error) {
+	rows, err := r.db.Query(`SELECT id, IFNULL(code, ''), title, IFNULL(open_term, ''), IFNULL(year, 0), updated_at FROM lectures ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("select dataset keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make(map[string]domain.LectureUpdateStamp)
+	for rows.Next() {
+		var stamp domain.LectureUpdateStamp
+		var updatedAt sql.NullString
+		if err := rows.Scan(&stamp.LectureID, &stamp.Code, &stamp.Title, &stamp.OpenTerm, &stamp.Year, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan dataset key: %w", err)
+		}
+		if updatedAt.Valid {
+			if parsed, err := time.ParseInLocation(lectureDateLayout, updatedAt.String, time.UTC); err == nil {
+				stamp.UpdatedAt = parsed
+			}
+		}
+		keys[domain.DatasetLectureKey(stamp.Code, stamp.Title, stamp.OpenTerm, stamp.Year)] = stamp
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dataset keys: %w", err)
+	}
+	return keys, nil
+}
+
+// FindDatasetSources returns the sites that were scraped and when the last scrape finished, falling back to
+// the newest lecture update when no scrape was recorded.
+func (r *DatasetRepository) FindDatasetSources() ([]string, time.Time, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT site_id FROM scrape_runs ORDER BY site_id`)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("select scraped sites: %w", err)
+	}
+	defer rows.Close()
+
+	sites := make([]string, 0)
+	for rows.Next() {
+		var site string
+		if err := rows.Scan(&site); err != nil {
+			return nil, time.Time{}, fmt.Errorf("scan scraped site: %w", err)
+		}
+		sites = append(sites, site)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, time.Time{}, fmt.Errorf("iterate scraped sites: %w", err)
+	}
+
+	var finishedAt, updatedAt sql.NullString
+	if err := r.db.QueryRow(`SELECT (SELECT MAX(finished_at) FROM scrape_runs), (SELECT MAX(updated_at) FROM lectures)`).Scan(&finishedAt, &updatedAt); err != nil {
+		return nil, time.Time{}, fmt.Errorf("select scrape date: %w", err)
+	}
+	if finishedAt.Valid {
+		if scrapedAt, err := time.Parse(scrapeRunTimeLayout, finishedAt.String); err == nil {
+			return sites, scrapedAt, nil
+		}
+	}
+	if updatedAt.Valid {
+		if scrapedAt, err := time.Parse(lectureDateLayout, updatedAt.String); err == nil {
+			return sites, scrapedAt, nil
+		}
+	}
+	return sites, time.Time{}, nil
+}
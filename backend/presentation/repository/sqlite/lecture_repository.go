@@ -45,7 +45,7 @@ func (r *LectureRepository) FindByID(id int) (*domain.Lecture, error) {
 		return nil, fmt.Errorf("invalid lecture id: %d", id)
 	}
 
-	const query = `SELECT id, university, title, english_title, department, lecture_type, code, level, credit, year, open_term, language, url, abstract, goal, experience, flow, out_of_class_work, textbook, reference_book, assessment, prerequisite, contact, office_hours, note, updated_at FROM lectures WHERE id = ?`
+	const query = `SELECT id, university, title, english_title, department, lecture_type, code, level, credit, year, open_term, language, url, abstract, goal, experience, flow, out_of_class_work, textbook, reference_book, assessment, prerequisite, contact, office_hours, note, updated_at, english_department, english_abstract, english_goal, english_flow, english_assessment FROM lectures WHERE id = ?`
 
 	row := r.db.QueryRow(query, id)
 
@@ -61,6 +61,9 @@ func (r *LectureRepository) FindByID(id int) (*domain.Lecture, error) {
 		contact, officeHours, note      sql.NullString
 		levelValue, creditValue, year   sql.NullInt64
 		updatedAtValue                  sql.NullString
+		englishDepartment               sql.NullString
+		englishAbstract, englishGoal    sql.NullString
+		englishFlow, englishAssessment  sql.NullString
 	)
 
 	err := row.Scan(
@@ -90,6 +93,11 @@ func (r *LectureRepository) FindByID(id int) (*domain.Lecture, error) {
 		&officeHours,
 		&note,
 		&updatedAtValue,
+		&englishDepartment,
+		&englishAbstract,
+		&englishGoal,
+		&englishFlow,
+		&englishAssessment,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -166,6 +174,11 @@ func (r *LectureRepository) FindByID(id int) (*domain.Lecture, error) {
 			lecture.UpdatedAt = parsed
 		}
 	}
+	lecture.EnglishDepartment = englishDepartment.String
+	lecture.EnglishAbstract = englishAbstract.String
+	lecture.EnglishGoal = englishGoal.String
+	lecture.EnglishFlow = englishFlow.String
+	lecture.EnglishAssessment = englishAssessment.String
 
 	timetables, err := r.fetchTimetablesMap([]int{lecture.ID})
 	if err != nil {
@@ -206,9 +219,84 @@ func (r *LectureRepository) FindByID(id int) (*domain.Lecture, error) {
 	}
 	lecture.RelatedCourseCodes = codes
 
+	englishPlans, err := r.fetchEnglishLecturePlans(lecture.ID)
+	if err != nil {
+		return nil, err
+	}
+	lecture.EnglishLecturePlans = englishPlans
+
+	englishKeywords, err := r.fetchEnglishKeywords(lecture.ID)
+	if err != nil {
+		return nil, err
+	}
+	lecture.EnglishKeywords = englishKeywords
+
+	contacts, err := r.fetchContacts(lecture.ID)
+	if err != nil {
+		return nil, err
+	}
+	lecture.Contacts = contacts
+
+	slots, err := r.fetchOfficeHours(lecture.ID)
+	if err != nil {
+		return nil, err
+	}
+	lecture.OfficeHourSlots = slots
+
+	references, err := r.fetchCourseReferences(lecture.ID)
+	if err != nil {
+		return nil, err
+	}
+	lecture.CourseReferences = references
+
+	relations, err := r.fetchCourseRelations(lecture.ID)
+	if err != nil {
+		return nil, err
+	}
+	lecture.CourseRelations = relations
+
 	return &lecture, nil
 }
 
+// FindAllByCode retrieves every lecture aggregate with the given course code, oldest year first.
+func (r *LectureRepository) FindAllByCode(code string) ([]domain.Lecture, error) {
+	code = normalizeCourseCode(code)
+	if code == "" {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(`SELECT id FROM lectures WHERE UPPER(TRIM(code)) = ? ORDER BY IFNULL(year, 0), id`, code)
+	if err != nil {
+		return nil, fmt.Errorf("select lectures by code: %w", err)
+	}
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan lecture id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("iterate lectures by code: %w", err)
+	}
+	rows.Close()
+
+	lectures := make([]domain.Lecture, 0, len(ids))
+	for _, id := range ids {
+		lecture, err := r.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if lecture != nil {
+			lectures = append(lectures, *lecture)
+		}
+	}
+	return lectures, nil
+}
+
 // FindByCode retrieves a lecture aggregate by its code, title, and open term combination.
 func (r *LectureRepository) FindByCode(code, title, openTerm string) (*domain.Lecture, error) {
 	code = strings.TrimSpace(code)
@@ -286,10 +374,39 @@ func (r *LectureRepository) FindByCode(code, title, openTerm string) (*domain.Le
 	return r.FindByID(lectureID)
 }
 
+// FindUpdateStamps returns the code, title, open term, year and update date of every lecture in id order,
+// for deciding which course list rows are stale without loading each lecture.
+func (r *LectureRepository) FindUpdateStamps() ([]domain.LectureUpdateStamp, error) {
+	rows, err := r.db.Query(`SELECT id, IFNULL(code, ''), title, IFNULL(open_term, ''), IFNULL(year, 0), updated_at FROM lectures ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("select lecture update stamps: %w", err)
+	}
+	defer rows.Close()
+
+	stamps := make([]domain.LectureUpdateStamp, 0)
+	for rows.Next() {
+		var stamp domain.LectureUpdateStamp
+		var updatedAt sql.NullString
+		if err := rows.Scan(&stamp.LectureID, &stamp.Code, &stamp.Title, &stamp.OpenTerm, &stamp.Year, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan lecture update stamp: %w", err)
+		}
+		if updatedAt.Valid {
+			if parsed, err := time.ParseInLocation(lectureDateLayout, updatedAt.String, time.UTC); err == nil {
+				stamp.UpdatedAt = parsed
+			}
+		}
+		stamps = append(stamps, stamp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lecture update stamps: %w", err)
+	}
+	return stamps, nil
+}
+
 // Search retrieves lecture summaries filtered by the provided query fields.
 func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSummary, error) {
 	selectBuilder := strings.Builder{}
-	selectBuilder.WriteString("SELECT DISTINCT l.id, l.university, l.title, l.department, l.code, l.level, l.credit, l.year FROM lectures l")
+	selectBuilder.WriteString("SELECT DISTINCT l.id, l.university, l.title, IFNULL(l.department, ''), IFNULL(l.code, ''), l.level, l.credit, l.year, IFNULL(l.open_term, ''), EXISTS (SELECT 1 FROM user_bookmarks ub WHERE " + userDataMatch("ub") + ") FROM lectures l")
 
 	var joins []string
 	var conditions []string
@@ -302,15 +419,17 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 	}
 
 	if len(query.Keywords) > 0 {
-		joins = append(joins, "JOIN lecture_keywords lk ON lk.lecture_id = l.id")
 		keywordPlaceholders := placeholders(len(query.Keywords))
-		conditions = append(conditions, "lk.keyword IN ("+keywordPlaceholders+")")
-		for _, keyword := range query.Keywords {
-			args = append(args, keyword)
+		conditions = append(conditions, "(EXISTS (SELECT 1 FROM lecture_keywords lk WHERE lk.lecture_id = l.id AND lk.keyword IN ("+keywordPlaceholders+"))"+
+			" OR EXISTS (SELECT 1 FROM english_keywords ek WHERE ek.lecture_id = l.id AND ek.keyword IN ("+keywordPlaceholders+")))")
+		for range 2 {
+			for _, keyword := range query.Keywords {
+				args = append(args, keyword)
+			}
 		}
 	}
 
-	timetableJoinRequired := len(query.TimeTables) > 0 || query.Room != "" || len(query.Semester) > 0
+	timetableJoinRequired := len(query.TimeTables) > 0 || query.Room != "" || len(query.Semester) > 0 || len(query.TimeTableKinds) > 0
 	if timetableJoinRequired {
 		joins = append(joins, "JOIN timetables tt ON tt.lecture_id = l.id")
 	}
@@ -337,10 +456,52 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 		}
 	}
 
+	if len(query.TimeTableKinds) > 0 {
+		conditions = append(conditions, "IFNULL(tt.kind, ?) IN ("+placeholders(len(query.TimeTableKinds))+")")
+		args = append(args, string(domain.TimeTableKindRegular))
+		for _, kind := range query.TimeTableKinds {
+			args = append(args, string(kind))
+		}
+	}
+
+	if len(query.ExcludeKinds) > 0 {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM timetables xt WHERE xt.lecture_id = l.id AND IFNULL(xt.kind, ?) IN ("+placeholders(len(query.ExcludeKinds))+"))")
+		args = append(args, string(domain.TimeTableKindRegular))
+		for _, kind := range query.ExcludeKinds {
+			args = append(args, string(kind))
+		}
+	}
+
 	if query.Room != "" {
 		joins = append(joins, "JOIN rooms r ON r.id = tt.room_id")
-		conditions = append(conditions, "r.name LIKE ?")
-		args = append(args, "%"+query.Room+"%")
+		like := "%" + query.Room + "%"
+		room := domain.NormalizeRoom(query.Room)
+		switch {
+		case room.Virtual:
+			conditions = append(conditions, "(r.name LIKE ? OR r.virtual = 1)")
+			args = append(args, like)
+		case room.Building != "" && room.Number != "":
+			conditions = append(conditions, "(r.name LIKE ? OR (r.building = ? AND r.number = ?))")
+			args = append(args, like, room.Building, room.Number)
+		case room.Building != "":
+			conditions = append(conditions, "(r.name LIKE ? OR r.building = ?)")
+			args = append(args, like, room.Building)
+		default:
+			conditions = append(conditions, "r.name LIKE ?")
+			args = append(args, like)
+		}
+	}
+
+	if query.PlanTopic != "" {
+		conditions = append(conditions, "(EXISTS (SELECT 1 FROM lecture_plans lp WHERE lp.lecture_id = l.id AND (IFNULL(lp.plan, '') LIKE ? OR IFNULL(lp.goal, '') LIKE ? OR IFNULL(lp.assignment, '') LIKE ?))"+
+			" OR EXISTS (SELECT 1 FROM english_lecture_plans ep WHERE ep.lecture_id = l.id AND (IFNULL(ep.plan, '') LIKE ? OR IFNULL(ep.goal, '') LIKE ? OR IFNULL(ep.assignment, '') LIKE ?)))")
+		like := "%" + query.PlanTopic + "%"
+		args = append(args, like, like, like, like, like, like)
+	}
+
+	if query.University != "" {
+		conditions = append(conditions, "l.university LIKE ?")
+		args = append(args, "%"+query.University+"%")
 	}
 
 	if query.Title != "" {
@@ -350,9 +511,12 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 	}
 
 	if len(query.Departments) > 0 {
-		conditions = append(conditions, "l.department IN ("+placeholders(len(query.Departments))+")")
-		for _, department := range query.Departments {
-			args = append(args, department)
+		departmentPlaceholders := placeholders(len(query.Departments))
+		conditions = append(conditions, "(l.department IN ("+departmentPlaceholders+") OR l.english_department IN ("+departmentPlaceholders+"))")
+		for range 2 {
+			for _, department := range query.Departments {
+				args = append(args, department)
+			}
 		}
 	}
 
@@ -368,6 +532,32 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 		}
 	}
 
+	if query.FilterNotResearch {
+		condition, ruleArgs, err := r.researchFilterCondition()
+		if err != nil {
+			return nil, err
+		}
+		if condition != "" {
+			conditions = append(conditions, condition)
+			args = append(args, ruleArgs...)
+		}
+	}
+
+	if query.ExcludePassed {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM transcript_entries te WHERE te.passed = 1 AND (te.lecture_id = l.id OR (te.code <> '' AND te.code = UPPER(TRIM(IFNULL(l.code, ''))))))")
+	}
+
+	if query.BookmarkedOnly {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM user_bookmarks bb WHERE "+userDataMatch("bb")+")")
+	}
+
+	if tags := normalizeTags(query.Tags); len(tags) > 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM user_tags ut WHERE "+userDataMatch("ut")+" AND ut.tag IN ("+placeholders(len(tags))+"))")
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+	}
+
 	if len(joins) > 0 {
 		selectBuilder.WriteString(" ")
 		selectBuilder.WriteString(strings.Join(joins, " "))
@@ -392,7 +582,7 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 	for rows.Next() {
 		var summary domain.LectureSummary
 		var levelValue, creditValue, yearValue sql.NullInt64
-		if err := rows.Scan(&summary.ID, &summary.University, &summary.Title, &summary.Department, &summary.Code, &levelValue, &creditValue, &yearValue); err != nil {
+		if err := rows.Scan(&summary.ID, &summary.University, &summary.Title, &summary.Department, &summary.Code, &levelValue, &creditValue, &yearValue, &summary.OpenTerm, &summary.Bookmarked); err != nil {
 			return nil, fmt.Errorf("scan lecture summary: %w", err)
 		}
 		if levelValue.Valid {
@@ -414,9 +604,6 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 	}
 
 	if len(ids) == 0 {
-		if query.FilterNotResearch {
-			return filterNotResearch(&summaries), nil
-		}
 		return summaries, nil
 	}
 
@@ -439,10 +626,6 @@ func (r *LectureRepository) Search(query domain.SearchQuery) ([]domain.LectureSu
 		}
 	}
 
-	if query.FilterNotResearch {
-		return filterNotResearch(&summaries), nil
-	}
-
 	return summaries, nil
 }
 
@@ -772,6 +955,268 @@ func (r *LectureRepository) MigrateRelatedCourses(ctx context.Context) (int, err
 	return inserted, nil
 }
 
+// MigrateCourseReferences resolves the courses named in prerequisite texts into typed lecture links.
+// Lectures stored before their references were extracted have them extracted from the stored prerequisite
+// text once. Only references that have not been resolved yet are looked at: they are matched by course
+// code first and otherwise by a fuzzy title match among the lectures of the referring lecture's year.
+func (r *LectureRepository) MigrateCourseReferences(ctx context.Context) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin migrate course references transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = r.backfillCourseReferencesTx(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	type pendingReference struct {
+		lectureID int
+		year      int
+		reference domain.CourseReference
+	}
+	pending := make([]pendingReference, 0)
+	titleYears := make(map[int]struct{})
+	rows, err := tx.QueryContext(ctx, `SELECT cr.lecture_id, COALESCE(l.year, 0), cr.kind, cr.code, cr.title
+		FROM course_references cr
+		INNER JOIN lectures l ON l.id = cr.lecture_id
+		WHERE cr.resolved = 0`)
+	if err != nil {
+		return 0, fmt.Errorf("select course references: %w", err)
+	}
+	for rows.Next() {
+		var item pendingReference
+		var kind string
+		if err = rows.Scan(&item.lectureID, &item.year, &kind, &item.reference.Code, &item.reference.Title); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan course reference: %w", err)
+		}
+		item.reference.Kind = domain.CourseRelationKind(kind)
+		pending = append(pending, item)
+		if strings.TrimSpace(item.reference.Title) != "" {
+			titleYears[item.year] = struct{}{}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("iterate course references: %w", err)
+	}
+	rows.Close()
+
+	if len(pending) == 0 {
+		if err = tx.Commit(); err != nil {
+			return 0, fmt.Errorf("commit migrate course references transaction: %w", err)
+		}
+		return 0, nil
+	}
+
+	type lectureCandidate struct {
+		id   int
+		year int
+	}
+	byCode := make(map[string][]lectureCandidate)
+	byYear := make(map[int]*domain.CourseTitleIndex)
+	rows, err = tx.QueryContext(ctx, `SELECT id, IFNULL(code, ''), title, IFNULL(english_title, ''), COALESCE(year, 0) FROM lectures ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("select lectures for course references: %w", err)
+	}
+	for rows.Next() {
+		var (
+			id                        int
+			code, title, englishTitle string
+			year                      int
+		)
+		if err = rows.Scan(&id, &code, &title, &englishTitle, &year); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan lecture for course references: %w", err)
+		}
+		if normalized := normalizeCourseCode(code); normalized != "" {
+			byCode[normalized] = append(byCode[normalized], lectureCandidate{id: id, year: year})
+		}
+		// only the years some title reference points into are worth indexing
+		if _, ok := titleYears[year]; !ok {
+			continue
+		}
+		if byYear[year] == nil {
+			byYear[year] = domain.NewCourseTitleIndex(nil)
+		}
+		byYear[year].Add(domain.CourseTitleCandidate{LectureID: id, Titles: []string{title, englishTitle}})
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("iterate lectures for course references: %w", err)
+	}
+	rows.Close()
+
+	resolve := func(item pendingReference) (int, bool) {
+		if candidates := byCode[normalizeCourseCode(item.reference.Code)]; len(candidates) > 0 {
+			for _, candidate := range candidates {
+				if candidate.year == item.year {
+					return candidate.id, true
+				}
+			}
+			return candidates[len(candidates)-1].id, true
+		}
+		if strings.TrimSpace(item.reference.Title) == "" {
+			return 0, false
+		}
+		return byYear[item.year].Match(item.reference.Title)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO course_relations (lecture_id, related_lecture_id, kind) VALUES (?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare insert course relation: %w", err)
+	}
+	defer stmt.Close()
+	resolvedStmt, err := tx.PrepareContext(ctx, `UPDATE course_references SET resolved = 1 WHERE lecture_id = ? AND kind = ? AND code = ? AND title = ?`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare mark course reference resolved: %w", err)
+	}
+	defer resolvedStmt.Close()
+
+	inserted := 0
+	for _, item := range pending {
+		targetID, ok := resolve(item)
+		if !ok {
+			continue
+		}
+		// a reference stays resolved even if it named its own lecture, so later runs do not retry it
+		if _, execErr := resolvedStmt.ExecContext(ctx, item.lectureID, string(item.reference.Kind), item.reference.Code, item.reference.Title); execErr != nil {
+			err = fmt.Errorf("mark course reference resolved: %w", execErr)
+			return 0, err
+		}
+		if targetID == item.lectureID {
+			continue
+		}
+		result, execErr := stmt.ExecContext(ctx, item.lectureID, targetID, string(item.reference.Kind))
+		if execErr != nil {
+			err = fmt.Errorf("insert course relation: %w", execErr)
+			return 0, err
+		}
+		affected, affErr := result.RowsAffected()
+		if affErr != nil {
+			err = fmt.Errorf("rows affected on insert course relation: %w", affErr)
+			return 0, err
+		}
+		inserted += int(affected)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit migrate course references transaction: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// FindByTeacher retrieves summaries of the lectures a teacher gives in the given year, including their timetables and rooms.
+func (r *LectureRepository) FindByTeacher(teacherID int, year int) ([]domain.LectureSummary, error) {
+	rows, err := r.db.Query(`SELECT l.id, l.university, l.title, IFNULL(l.department, ''), IFNULL(l.code, ''), l.level, l.credit, l.year, IFNULL(l.open_term, '')
+FROM lectures l
+JOIN lecture_teachers lt ON lt.lecture_id = l.id
+WHERE lt.teacher_id = ? AND l.year = ?
+ORDER BY l.code, l.id`, teacherID, year)
+	if err != nil {
+		return nil, fmt.Errorf("select teacher lectures: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make([]domain.LectureSummary, 0)
+	ids := make([]int, 0)
+	for rows.Next() {
+		var summary domain.LectureSummary
+		var levelValue, creditValue, yearValue sql.NullInt64
+		if err := rows.Scan(&summary.ID, &summary.University, &summary.Title, &summary.Department, &summary.Code, &levelValue, &creditValue, &yearValue, &summary.OpenTerm); err != nil {
+			return nil, fmt.Errorf("scan teacher lecture: %w", err)
+		}
+		if levelValue.Valid {
+			summary.Level = domain.Level(levelValue.Int64)
+		}
+		if creditValue.Valid {
+			summary.Credit = int(creditValue.Int64)
+		}
+		if yearValue.Valid {
+			summary.Year = int(yearValue.Int64)
+		}
+
+		summaries = append(summaries, summary)
+		ids = append(ids, summary.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teacher lectures: %w", err)
+	}
+
+	timetables, err := r.fetchTimetablesMap(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	teachers, err := r.fetchTeachersMap(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range summaries {
+		summaries[index].Timetables = timetables[summaries[index].ID]
+		summaries[index].Teachers = teachers[summaries[index].ID]
+	}
+
+	return summaries, nil
+}
+
+// FindSessions retrieves lecture plan entries of the given lectures, optionally restricted to the given kinds.
+func (r *LectureRepository) FindSessions(lectureIDs []int, kinds []domain.LecturePlanKind) ([]domain.LectureSession, error) {
+	if len(lectureIDs) == 0 {
+		return []domain.LectureSession{}, nil
+	}
+
+	args := make([]any, 0, len(lectureIDs)+len(kinds))
+	for _, id := range lectureIDs {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`SELECT l.id, IFNULL(l.code, ''), l.title, IFNULL(l.year, 0), lp.count, lp.plan, lp.assignment, lp.goal, lp.date, lp.kind, lp.exam FROM lecture_plans lp JOIN lectures l ON l.id = lp.lecture_id WHERE lp.lecture_id IN (%s)`, placeholders(len(lectureIDs)))
+	if len(kinds) > 0 {
+		query += fmt.Sprintf(" AND IFNULL(lp.kind, ?) IN (%s)", placeholders(len(kinds)))
+		args = append(args, string(domain.LecturePlanKindLecture))
+		for _, kind := range kinds {
+			args = append(args, string(kind))
+		}
+	}
+	query += " ORDER BY lp.date IS NULL, lp.date, l.id, lp.count"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select lecture sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]domain.LectureSession, 0)
+	for rows.Next() {
+		var session domain.LectureSession
+		plan, err := scanLecturePlan(rows, &session.LectureID, &session.Code, &session.Title, &session.Year)
+		if err != nil {
+			return nil, err
+		}
+		session.Plan = plan
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lecture sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
 // ExpandTimetableRanges inserts missing timetable periods for entries that were previously stored as only the start and end of a range.
 // Update updates an existing lecture aggregate.
 func (r *LectureRepository) Update(lecture *domain.Lecture) error {
@@ -784,12 +1229,27 @@ func (r *LectureRepository) Delete(id int) error {
 }
 
 func (r *LectureRepository) initSchema() error {
+	rulesExist, err := tableExists(r.db, "research_filter_rules")
+	if err != nil {
+		return fmt.Errorf("init schema: %w", err)
+	}
+
 	for _, statement := range schemaStatements() {
 		if _, err := r.db.Exec(statement); err != nil {
 			return fmt.Errorf("init schema: %w", err)
 		}
 	}
 
+	if err := ensureAddedColumns(r.db); err != nil {
+		return fmt.Errorf("init schema: %w", err)
+	}
+
+	if !rulesExist {
+		if err := seedResearchFilterRules(r.db); err != nil {
+			return fmt.Errorf("init schema: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -800,7 +1260,7 @@ func (r *LectureRepository) fetchTimetablesMap(lectureIDs []int) (map[int][]doma
 	}
 
 	ph := placeholders(len(lectureIDs))
-	query := fmt.Sprintf(`SELECT tt.lecture_id, tt.semester, tt.room_id, r.name, tt.day_of_week, tt.period FROM timetables tt LEFT JOIN rooms r ON r.id = tt.room_id WHERE tt.lecture_id IN (%s) ORDER BY tt.lecture_id, tt.semester, tt.day_of_week, tt.period`, ph)
+	query := fmt.Sprintf(`SELECT tt.lecture_id, tt.semester, tt.room_id, r.name, r.building, r.campus, r.number, r.virtual, tt.day_of_week, tt.period, tt.kind, tt.start_date, tt.end_date FROM timetables tt LEFT JOIN rooms r ON r.id = tt.room_id WHERE tt.lecture_id IN (%s) ORDER BY tt.lecture_id, tt.semester, tt.day_of_week, tt.period`, ph)
 	args := make([]any, len(lectureIDs))
 	for index, id := range lectureIDs {
 		args[index] = id
@@ -813,36 +1273,11 @@ func (r *LectureRepository) fetchTimetablesMap(lectureIDs []int) (map[int][]doma
 	defer rows.Close()
 
 	for rows.Next() {
-		var (
-			lectureID           int
-			semester, dayOfWeek sql.NullString
-			roomID              sql.NullInt64
-			roomName            sql.NullString
-			period              sql.NullInt64
-		)
-
-		if err := rows.Scan(&lectureID, &semester, &roomID, &roomName, &dayOfWeek, &period); err != nil {
-			return nil, fmt.Errorf("scan timetable: %w", err)
-		}
-
-		timetable := domain.TimeTable{LectureID: lectureID}
-		if semester.Valid {
-			timetable.Semester = domain.Semester(semester.String)
-		}
-		if dayOfWeek.Valid {
-			timetable.DayOfWeek = domain.DayOfWeek(dayOfWeek.String)
-		}
-		if period.Valid {
-			timetable.Period = domain.Period(period.Int64)
-		}
-		if roomID.Valid {
-			timetable.Room.ID = int(roomID.Int64)
-		}
-		if roomName.Valid {
-			timetable.Room.Name = roomName.String
+		timetable, err := scanTimetable(rows)
+		if err != nil {
+			return nil, err
 		}
-
-		result[lectureID] = append(result[lectureID], timetable)
+		result[timetable.LectureID] = append(result[timetable.LectureID], timetable)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -899,46 +1334,184 @@ func (r *LectureRepository) fetchTeachersMap(lectureIDs []int) (map[int][]domain
 }
 
 func (r *LectureRepository) fetchLecturePlans(lectureID int) ([]domain.LecturePlan, error) {
-	rows, err := r.db.Query(`SELECT count, plan, assignment FROM lecture_plans WHERE lecture_id = ? ORDER BY count`, lectureID)
+	return r.fetchPlansFrom("lecture_plans", lectureID)
+}
+
+func (r *LectureRepository) fetchEnglishLecturePlans(lectureID int) ([]domain.LecturePlan, error) {
+	return r.fetchPlansFrom("english_lecture_plans", lectureID)
+}
+
+func (r *LectureRepository) fetchPlansFrom(table string, lectureID int) ([]domain.LecturePlan, error) {
+	rows, err := r.db.Query(`SELECT count, plan, assignment, goal, date, kind, exam FROM `+table+` WHERE lecture_id = ? ORDER BY count`, lectureID)
 	if err != nil {
-		return nil, fmt.Errorf("select lecture plans: %w", err)
+		return nil, fmt.Errorf("select %s: %w", strings.ReplaceAll(table, "_", " "), err)
 	}
 	defer rows.Close()
 
 	plans := make([]domain.LecturePlan, 0)
 	for rows.Next() {
-		var (
-			count      sql.NullInt64
-			plan       sql.NullString
-			assignment sql.NullString
-		)
-		if err := rows.Scan(&count, &plan, &assignment); err != nil {
-			return nil, fmt.Errorf("scan lecture plan: %w", err)
+		lp, err := scanLecturePlan(rows)
+		if err != nil {
+			return nil, err
 		}
+		plans = append(plans, lp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate %s: %w", strings.ReplaceAll(table, "_", " "), err)
+	}
+
+	return plans, nil
+}
 
-		lp := domain.LecturePlan{}
-		if count.Valid {
-			lp.Count = int(count.Int64)
+// scanLecturePlan reads the count, plan, assignment, goal, date, kind and exam columns of a lecture plan row.
+func scanLecturePlan(rows *sql.Rows, prefix ...any) (domain.LecturePlan, error) {
+	var (
+		count                  sql.NullInt64
+		plan, assignment, goal sql.NullString
+		date, kind, exam       sql.NullString
+	)
+	dest := append(prefix, &count, &plan, &assignment, &goal, &date, &kind, &exam)
+	if err := rows.Scan(dest...); err != nil {
+		return domain.LecturePlan{}, fmt.Errorf("scan lecture plan: %w", err)
+	}
+
+	lp := domain.LecturePlan{}
+	if count.Valid {
+		lp.Count = int(count.Int64)
+	}
+	if plan.Valid {
+		lp.Plan = plan.String
+	}
+	if assignment.Valid {
+		lp.Assignment = assignment.String
+	}
+	if goal.Valid {
+		lp.Goal = goal.String
+	}
+	if date.Valid {
+		if parsed, err := time.ParseInLocation(lectureDateLayout, date.String, time.UTC); err == nil {
+			lp.Date = parsed
 		}
-		if plan.Valid {
-			lp.Plan = plan.String
+	}
+	if kind.Valid {
+		lp.Kind = domain.LecturePlanKind(kind.String)
+	}
+	if exam.Valid {
+		lp.Exam = domain.ExamType(exam.String)
+	}
+
+	return lp, nil
+}
+
+func (r *LectureRepository) fetchKeywords(lectureID int) ([]string, error) {
+	return r.fetchKeywordsFrom("lecture_keywords", lectureID)
+}
+
+func (r *LectureRepository) fetchEnglishKeywords(lectureID int) ([]string, error) {
+	return r.fetchKeywordsFrom("english_keywords", lectureID)
+}
+
+func (r *LectureRepository) fetchContacts(lectureID int) ([]domain.ContactEntry, error) {
+	rows, err := r.db.Query(`SELECT kind, value, teacher_name FROM lecture_contacts WHERE lecture_id = ? ORDER BY rowid`, lectureID)
+	if err != nil {
+		return nil, fmt.Errorf("select contacts: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := make([]domain.ContactEntry, 0)
+	for rows.Next() {
+		var kind, value string
+		var teacherName sql.NullString
+		if err := rows.Scan(&kind, &value, &teacherName); err != nil {
+			return nil, fmt.Errorf("scan contact: %w", err)
 		}
-		if assignment.Valid {
-			lp.Assignment = assignment.String
+		contacts = append(contacts, domain.ContactEntry{Kind: domain.ContactKind(kind), Value: value, TeacherName: teacherName.String})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
+func (r *LectureRepository) fetchOfficeHours(lectureID int) ([]domain.OfficeHour, error) {
+	rows, err := r.db.Query(`SELECT day_of_week, start_time, end_time, note FROM lecture_office_hours WHERE lecture_id = ? ORDER BY rowid`, lectureID)
+	if err != nil {
+		return nil, fmt.Errorf("select office hours: %w", err)
+	}
+	defer rows.Close()
+
+	hours := make([]domain.OfficeHour, 0)
+	for rows.Next() {
+		var day, start, end string
+		var note sql.NullString
+		if err := rows.Scan(&day, &start, &end, &note); err != nil {
+			return nil, fmt.Errorf("scan office hour: %w", err)
 		}
+		hours = append(hours, domain.OfficeHour{DayOfWeek: domain.DayOfWeek(day), Start: start, End: end, Note: note.String})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate office hours: %w", err)
+	}
 
-		plans = append(plans, lp)
+	return hours, nil
+}
+
+func (r *LectureRepository) fetchCourseReferences(lectureID int) ([]domain.CourseReference, error) {
+	rows, err := r.db.Query(`SELECT kind, code, title FROM course_references WHERE lecture_id = ? ORDER BY rowid`, lectureID)
+	if err != nil {
+		return nil, fmt.Errorf("select course references: %w", err)
 	}
+	defer rows.Close()
 
+	references := make([]domain.CourseReference, 0)
+	for rows.Next() {
+		var reference domain.CourseReference
+		var kind string
+		if err := rows.Scan(&kind, &reference.Code, &reference.Title); err != nil {
+			return nil, fmt.Errorf("scan course reference: %w", err)
+		}
+		reference.Kind = domain.CourseRelationKind(kind)
+		references = append(references, reference)
+	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate lecture plans: %w", err)
+		return nil, fmt.Errorf("iterate course references: %w", err)
 	}
 
-	return plans, nil
+	return references, nil
 }
 
-func (r *LectureRepository) fetchKeywords(lectureID int) ([]string, error) {
-	rows, err := r.db.Query(`SELECT keyword FROM lecture_keywords WHERE lecture_id = ? ORDER BY keyword`, lectureID)
+// fetchCourseRelations returns the typed links of a lecture; entries of related_courses are reported as related.
+func (r *LectureRepository) fetchCourseRelations(lectureID int) ([]domain.CourseRelation, error) {
+	rows, err := r.db.Query(`SELECT related_lecture_id, kind FROM course_relations WHERE lecture_id = ?
+		UNION
+		SELECT related_lecture_id, ? FROM related_courses WHERE lecture_id = ?
+		ORDER BY 2, 1`, lectureID, string(domain.CourseRelationRelated), lectureID)
+	if err != nil {
+		return nil, fmt.Errorf("select course relations: %w", err)
+	}
+	defer rows.Close()
+
+	relations := make([]domain.CourseRelation, 0)
+	for rows.Next() {
+		relation := domain.CourseRelation{LectureID: lectureID}
+		var kind string
+		if err := rows.Scan(&relation.RelatedLectureID, &kind); err != nil {
+			return nil, fmt.Errorf("scan course relation: %w", err)
+		}
+		relation.Kind = domain.CourseRelationKind(kind)
+		relations = append(relations, relation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate course relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+func (r *LectureRepository) fetchKeywordsFrom(table string, lectureID int) ([]string, error) {
+	rows, err := r.db.Query(`SELECT keyword FROM `+table+` WHERE lecture_id = ? ORDER BY keyword`, lectureID)
 	if err != nil {
 		return nil, fmt.Errorf("select keywords: %w", err)
 	}
@@ -1021,7 +1594,7 @@ func (r *LectureRepository) insertLectureTx(tx *sql.Tx, lecture *domain.Lecture)
 		return 0, errors.New("lecture title is required")
 	}
 
-	const insertLecture = `INSERT INTO lectures (university, title, english_title, department, lecture_type, code, level, credit, year, open_term, language, url, abstract, goal, experience, flow, out_of_class_work, textbook, reference_book, assessment, prerequisite, contact, office_hours, note, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	const insertLecture = `INSERT INTO lectures (university, title, english_title, department, lecture_type, code, level, credit, year, open_term, language, url, abstract, goal, experience, flow, out_of_class_work, textbook, reference_book, assessment, prerequisite, contact, office_hours, note, updated_at, english_department, english_abstract, english_goal, english_flow, english_assessment) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	result, err := tx.Exec(insertLecture,
 		strings.TrimSpace(lecture.University),
@@ -1049,6 +1622,11 @@ func (r *LectureRepository) insertLectureTx(tx *sql.Tx, lecture *domain.Lecture)
 		nullString(lecture.OfficeHours),
 		nullString(lecture.Note),
 		nullDate(lecture.UpdatedAt),
+		nullString(lecture.EnglishDepartment),
+		nullString(lecture.EnglishAbstract),
+		nullString(lecture.EnglishGoal),
+		nullString(lecture.EnglishFlow),
+		nullString(lecture.EnglishAssessment),
 	)
 	if err != nil {
 		return 0, fmt.Errorf("insert lecture: %w", err)
@@ -1072,9 +1650,24 @@ func (r *LectureRepository) insertLectureTx(tx *sql.Tx, lecture *domain.Lecture)
 	if err := r.insertKeywordsTx(tx, lectureID, lecture.Keywords); err != nil {
 		return 0, err
 	}
+	if err := r.insertPlansIntoTx(tx, "english_lecture_plans", lectureID, lecture.EnglishLecturePlans); err != nil {
+		return 0, err
+	}
+	if err := r.insertKeywordsIntoTx(tx, "english_keywords", lectureID, lecture.EnglishKeywords); err != nil {
+		return 0, err
+	}
+	if err := r.insertContactsTx(tx, lectureID, lecture.Contacts); err != nil {
+		return 0, err
+	}
+	if err := r.insertOfficeHoursTx(tx, lectureID, lecture.OfficeHourSlots); err != nil {
+		return 0, err
+	}
 	if err := r.insertRelatedCourseCodesTx(tx, lectureID, lecture.RelatedCourseCodes); err != nil {
 		return 0, err
 	}
+	if err := r.insertCourseReferencesTx(tx, lectureID, lecture.CourseReferences); err != nil {
+		return 0, err
+	}
 
 	return lectureID, nil
 }
@@ -1106,7 +1699,7 @@ func (r *LectureRepository) insertTeachersTx(tx *sql.Tx, lectureID int, lecture
 			}
 			seenNames[key] = struct{}{}
 
-			id, err := r.ensureTeacherTx(tx, name, teacher.Url)
+			id, err := ensureTeacherTx(tx, name, teacher.Url)
 			if err != nil {
 				return err
 			}
@@ -1130,7 +1723,7 @@ func (r *LectureRepository) insertTimetablesTx(tx *sql.Tx, lectureID int, lectur
 	for idx, timetable := range lecture.Timetables {
 		roomID := 0
 		if name := strings.TrimSpace(timetable.Room.Name); name != "" {
-			id, err := r.ensureRoomTx(tx, name)
+			id, err := ensureRoomTx(tx, name)
 			if err != nil {
 				return err
 			}
@@ -1138,12 +1731,15 @@ func (r *LectureRepository) insertTimetablesTx(tx *sql.Tx, lectureID int, lectur
 			lecture.Timetables[idx].Room.ID = id
 		}
 
-		if _, err := tx.Exec(`INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period) VALUES (?, ?, ?, ?, ?)`,
+		if _, err := tx.Exec(`INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period, kind, start_date, end_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 			lectureID,
 			nullString(string(timetable.Semester)),
 			nullInt(roomID),
 			nullString(string(timetable.DayOfWeek)),
 			nullInt(int(timetable.Period)),
+			nullString(string(timetable.Kind)),
+			nullDate(timetable.StartDate),
+			nullDate(timetable.EndDate),
 		); err != nil {
 			return fmt.Errorf("insert timetable: %w", err)
 		}
@@ -1153,16 +1749,24 @@ func (r *LectureRepository) insertTimetablesTx(tx *sql.Tx, lectureID int, lectur
 }
 
 func (r *LectureRepository) insertLecturePlansTx(tx *sql.Tx, lectureID int, plans []domain.LecturePlan) error {
+	return r.insertPlansIntoTx(tx, "lecture_plans", lectureID, plans)
+}
+
+func (r *LectureRepository) insertPlansIntoTx(tx *sql.Tx, table string, lectureID int, plans []domain.LecturePlan) error {
 	if len(plans) == 0 {
 		return nil
 	}
 
 	for _, plan := range plans {
-		if _, err := tx.Exec(`INSERT INTO lecture_plans (lecture_id, count, plan, assignment) VALUES (?, ?, ?, ?)`,
+		if _, err := tx.Exec(`INSERT INTO `+table+` (lecture_id, count, plan, assignment, goal, date, kind, exam) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 			lectureID,
 			nullInt(plan.Count),
 			nullString(plan.Plan),
 			nullString(plan.Assignment),
+			nullString(plan.Goal),
+			nullDate(plan.Date),
+			nullString(string(plan.Kind)),
+			nullString(string(plan.Exam)),
 		); err != nil {
 			return fmt.Errorf("insert lecture plan: %w", err)
 		}
@@ -1196,6 +1800,10 @@ func (r *LectureRepository) insertRelatedCourseCodesTx(tx *sql.Tx, lectureID int
 }
 
 func (r *LectureRepository) insertKeywordsTx(tx *sql.Tx, lectureID int, keywords []string) error {
+	return r.insertKeywordsIntoTx(tx, "lecture_keywords", lectureID, keywords)
+}
+
+func (r *LectureRepository) insertKeywordsIntoTx(tx *sql.Tx, table string, lectureID int, keywords []string) error {
 	if len(keywords) == 0 {
 		return nil
 	}
@@ -1212,7 +1820,7 @@ func (r *LectureRepository) insertKeywordsTx(tx *sql.Tx, lectureID int, keywords
 		}
 		seen[key] = struct{}{}
 
-		if _, err := tx.Exec(`INSERT OR IGNORE INTO lecture_keywords (lecture_id, keyword) VALUES (?, ?)`, lectureID, clean); err != nil {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO `+table+` (lecture_id, keyword) VALUES (?, ?)`, lectureID, clean); err != nil {
 			return fmt.Errorf("insert keyword: %w", err)
 		}
 	}
@@ -1220,6 +1828,85 @@ func (r *LectureRepository) insertKeywordsTx(tx *sql.Tx, lectureID int, keywords
 	return nil
 }
 
+func (r *LectureRepository) insertContactsTx(tx *sql.Tx, lectureID int, contacts []domain.ContactEntry) error {
+	for _, contact := range contacts {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO lecture_contacts (lecture_id, kind, value, teacher_name) VALUES (?, ?, ?, ?)`,
+			lectureID, string(contact.Kind), contact.Value, nullString(contact.TeacherName)); err != nil {
+			return fmt.Errorf("insert contact: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *LectureRepository) insertOfficeHoursTx(tx *sql.Tx, lectureID int, hours []domain.OfficeHour) error {
+	for _, hour := range hours {
+		if _, err := tx.Exec(`INSERT INTO lecture_office_hours (lecture_id, day_of_week, start_time, end_time, note) VALUES (?, ?, ?, ?, ?)`,
+			lectureID, string(hour.DayOfWeek), hour.Start, hour.End, nullString(hour.Note)); err != nil {
+			return fmt.Errorf("insert office hour: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backfillCourseReferencesTx extracts the references of lectures with a prerequisite text but no stored
+// references, then marks every lecture as extracted so texts naming no course are not parsed again.
+func (r *LectureRepository) backfillCourseReferencesTx(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT l.id, l.prerequisite FROM lectures l
+		WHERE l.references_extracted = 0
+		AND TRIM(IFNULL(l.prerequisite, '')) <> ''
+		AND NOT EXISTS (SELECT 1 FROM course_references cr WHERE cr.lecture_id = l.id)`)
+	if err != nil {
+		return fmt.Errorf("select lectures without course references: %w", err)
+	}
+	references := make(map[int][]domain.CourseReference)
+	ids := make([]int, 0)
+	for rows.Next() {
+		var (
+			id           int
+			prerequisite string
+		)
+		if err := rows.Scan(&id, &prerequisite); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan lecture without course references: %w", err)
+		}
+		if extracted := domain.ExtractCourseReferences(prerequisite); len(extracted) > 0 {
+			references[id] = extracted
+			ids = append(ids, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("iterate lectures without course references: %w", err)
+	}
+	rows.Close()
+
+	for _, id := range ids {
+		if err := r.insertCourseReferencesTx(tx, id, references[id]); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE lectures SET references_extracted = 1 WHERE references_extracted = 0`); err != nil {
+		return fmt.Errorf("mark course references extracted: %w", err)
+	}
+	return nil
+}
+
+func (r *LectureRepository) insertCourseReferencesTx(tx *sql.Tx, lectureID int, references []domain.CourseReference) error {
+	for _, reference := range references {
+		if reference.Code == "" && reference.Title == "" {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO course_references (lecture_id, kind, code, title) VALUES (?, ?, ?, ?)`,
+			lectureID, string(reference.Kind), reference.Code, reference.Title); err != nil {
+			return fmt.Errorf("insert course reference: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *LectureRepository) insertRelatedCoursesTx(tx *sql.Tx, lectureID int, related []int) error {
 	if len(related) == 0 {
 		return nil
@@ -1355,67 +2042,6 @@ func normalizeComparable(value string) string {
 	return collapsed
 }
 
-func (r *LectureRepository) ensureTeacherTx(tx *sql.Tx, name, url string) (int, error) {
-	var id int
-	err := tx.QueryRow(`SELECT id FROM teachers WHERE name = ?`, name).Scan(&id)
-	if err == nil {
-		if strings.TrimSpace(url) != "" {
-			if _, err := tx.Exec(`UPDATE teachers SET url = ? WHERE id = ?`, url, id); err != nil {
-				return 0, fmt.Errorf("update teacher url: %w", err)
-			}
-		}
-		return id, nil
-	}
-	if !errors.Is(err, sql.ErrNoRows) {
-		return 0, fmt.Errorf("select teacher: %w", err)
-	}
-
-	result, err := tx.Exec(`INSERT INTO teachers (name, url) VALUES (?, ?)`, name, nullString(url))
-	if err != nil {
-		return 0, fmt.Errorf("insert teacher: %w", err)
-	}
-
-	teacherID, err := result.LastInsertId()
-	if err != nil {
-		return 0, fmt.Errorf("last insert teacher id: %w", err)
-	}
-
-	return int(teacherID), nil
-}
-
-func (r *LectureRepository) ensureRoomTx(tx *sql.Tx, name string) (int, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return 0, nil
-	}
-
-	var id int
-	err := tx.QueryRow(`SELECT id FROM rooms WHERE name = ?`, name).Scan(&id)
-	if err == nil {
-		return id, nil
-	}
-	if !errors.Is(err, sql.ErrNoRows) {
-		return 0, fmt.Errorf("select room: %w", err)
-	}
-
-	result, err := tx.Exec(`INSERT OR IGNORE INTO rooms (name) VALUES (?)`, name)
-	if err != nil {
-		return 0, fmt.Errorf("insert room: %w", err)
-	}
-
-	id64, err := result.LastInsertId()
-	if err == nil && id64 != 0 {
-		return int(id64), nil
-	}
-
-	err = tx.QueryRow(`SELECT id FROM rooms WHERE name = ?`, name).Scan(&id)
-	if err != nil {
-		return 0, fmt.Errorf("select ensured room: %w", err)
-	}
-
-	return id, nil
-}
-
 func nullString(value string) sql.NullString {
 	if strings.TrimSpace(value) == "" {
 		return sql.NullString{Valid: false}
@@ -1453,60 +2079,3 @@ func placeholders(count int) string {
 
 	return builder.String()
 }
-
-var researchWords = []string{
-	"特定課題プロジェクト",
-	"特定課題研究",
-	"講究",
-	"B2D",
-	"リカレント研修",
-	"オフキャンパスプロジェクト",
-	"国際派遣プロジェクト",
-	"インターンシップ",
-	"学外研修",
-	"論文研究計画論",
-	"物理学プレゼンテーション実践",
-	"数理・計算科学プレゼンテーション実践",
-	"国際プレゼンテーション",
-	"エンジニアリングデザインプレゼンテーション",
-	"チュートリアル",
-	"留学",
-	"国際研究",
-	"キャリアディベロップメント",
-	"キャリア開発",
-	"キャリア特別",
-	"派遣プロジェクト",
-	"企画実践",
-	"先端研究",
-}
-
-var researchTeacherWords = []string{
-	"教員",
-}
-
-func filterNotResearch(lectures *[]domain.LectureSummary) []domain.LectureSummary {
-	result := make([]domain.LectureSummary, 0, len(*lectures))
-	for _, lecture := range *lectures {
-		if checkIsResearchLecture(lecture.Title, lecture.Teachers) {
-			continue
-		}
-		result = append(result, lecture)
-	}
-	return result
-}
-
-func checkIsResearchLecture(title string, teachers []domain.Teacher) bool {
-	for _, word := range researchWords {
-		if strings.Contains(title, word) {
-			return true
-		}
-	}
-	for _, teacher := range teachers {
-		for _, word := range researchTeacherWords {
-			if strings.Contains(teacher.Name, word) {
-				return true
-			}
-		}
-	}
-	return false
-}
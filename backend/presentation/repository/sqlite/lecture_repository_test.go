@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -907,3 +908,446 @@ func parseDetailFixture(t *testing.T, name string) *domain.Lecture {
 
 	return lecture
 }
+
+func TestLectureRepositoryFindSessionsFiltersByKind(t *testing.T) {
+	repo, db := newTestRepository(t)
+
+	err := repo.Creates([]domain.Lecture{
+		{
+			University: "Test University",
+			Title:      "Linear Algebra",
+			Code:       "MTH.A101",
+			Year:       2025,
+			LecturePlans: []domain.LecturePlan{
+				{Count: 1, Plan: "Vectors", Kind: domain.LecturePlanKindLecture},
+				{Count: 8, Plan: "Final exam", Kind: domain.LecturePlanKindExam, Exam: domain.ExamTypeFinal, Date: time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			University: "Test University",
+			Title:      "Mechanics",
+			Code:       "PHY.M101",
+			Year:       2025,
+			LecturePlans: []domain.LecturePlan{
+				{Count: 4, Plan: "Midterm exam", Goal: "Newton's laws", Kind: domain.LecturePlanKindExam, Exam: domain.ExamTypeMidterm, Date: time.Date(2025, time.November, 5, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	mustExec(t, db, `INSERT INTO lecture_plans (lecture_id, count, plan) VALUES (?, ?, ?)`, 1, 2, "Legacy row without kind")
+
+	sessions, err := repo.FindSessions([]int{1, 2}, []domain.LecturePlanKind{domain.LecturePlanKindExam})
+	if err != nil {
+		t.Fatalf("FindSessions returned error: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 exam sessions, got %d", len(sessions))
+	}
+	if sessions[0].Code != "PHY.M101" || sessions[0].Plan.Exam != domain.ExamTypeMidterm {
+		t.Fatalf("expected midterm first, got %+v", sessions[0])
+	}
+	if sessions[0].Plan.Goal != "Newton's laws" {
+		t.Fatalf("unexpected goal: %s", sessions[0].Plan.Goal)
+	}
+	if !sessions[1].Plan.Date.Equal(time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected final exam date: %v", sessions[1].Plan.Date)
+	}
+
+	lectures, err := repo.FindSessions([]int{1}, []domain.LecturePlanKind{domain.LecturePlanKindLecture})
+	if err != nil {
+		t.Fatalf("FindSessions returned error: %v", err)
+	}
+	if len(lectures) != 2 {
+		t.Fatalf("expected legacy rows to count as lectures, got %d sessions", len(lectures))
+	}
+}
+
+func TestLectureRepositorySearchFiltersByPlanTopic(t *testing.T) {
+	repo, db := newTestRepository(t)
+	seedSearchData(t, db)
+
+	mustExec(t, db, `INSERT INTO lecture_plans (lecture_id, count, plan, goal) VALUES (?, ?, ?, ?)`, 1, 1, "回帰分析", "")
+	mustExec(t, db, `INSERT INTO lecture_plans (lecture_id, count, plan, goal) VALUES (?, ?, ?, ?)`, 2, 1, "量子化", "シュレディンガー方程式を解く")
+
+	results, err := repo.Search(domain.SearchQuery{PlanTopic: "シュレディンガー"})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 2 {
+		t.Fatalf("unexpected plan topic results: %#v", results)
+	}
+}
+
+func TestNewLectureRepositoryAddsMissingColumns(t *testing.T) {
+	db, err := sql.Open(testDriverName, testDataSourceName)
+	if err != nil {
+		t.Fatalf("open in-memory sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	mustExec(t, db, `CREATE TABLE lecture_plans (id INTEGER PRIMARY KEY AUTOINCREMENT, lecture_id INTEGER NOT NULL, count INTEGER, plan TEXT, assignment TEXT)`)
+
+	if _, err := NewLectureRepository(db); err != nil {
+		t.Fatalf("NewLectureRepository: %v", err)
+	}
+
+	columns, err := tableColumns(db, "lecture_plans")
+	if err != nil {
+		t.Fatalf("tableColumns: %v", err)
+	}
+	for _, name := range []string{"goal", "date", "kind", "exam"} {
+		if _, ok := columns[name]; !ok {
+			t.Fatalf("expected column %s to be added", name)
+		}
+	}
+}
+
+func TestLectureRepositorySearchFiltersByTimetableKind(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	err := repo.Creates([]domain.Lecture{
+		{
+			University: "Test University",
+			Title:      "Weekly Course",
+			Department: "Testing",
+			Code:       "TST.A101",
+			Timetables: []domain.TimeTable{{Kind: domain.TimeTableKindRegular, Semester: domain.SemesterFall, DayOfWeek: domain.DayOfWeekMonday, Period: domain.Period1}},
+		},
+		{
+			University: "Test University",
+			Title:      "Summer Intensive",
+			Department: "Testing",
+			Code:       "TST.A102",
+			Timetables: []domain.TimeTable{{
+				Kind:      domain.TimeTableKindIntensive,
+				Semester:  domain.SemesterSummer,
+				StartDate: time.Date(2025, time.August, 5, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, time.August, 9, 0, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			University: "Test University",
+			Title:      "Recorded Course",
+			Department: "Testing",
+			Code:       "TST.A103",
+			Timetables: []domain.TimeTable{{Kind: domain.TimeTableKindOndemand, Semester: domain.SemesterSpring}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	intensive, err := repo.Search(domain.SearchQuery{TimeTableKinds: []domain.TimeTableKind{domain.TimeTableKindIntensive}})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(intensive) != 1 || intensive[0].Title != "Summer Intensive" {
+		t.Fatalf("unexpected intensive results: %#v", intensive)
+	}
+	if len(intensive[0].Timetables) != 1 {
+		t.Fatalf("unexpected intensive timetables: %#v", intensive[0].Timetables)
+	}
+	timetable := intensive[0].Timetables[0]
+	if timetable.Kind != domain.TimeTableKindIntensive {
+		t.Fatalf("unexpected kind: %s", timetable.Kind)
+	}
+	if !timetable.StartDate.Equal(time.Date(2025, time.August, 5, 0, 0, 0, 0, time.UTC)) || !timetable.EndDate.Equal(time.Date(2025, time.August, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date range: %v - %v", timetable.StartDate, timetable.EndDate)
+	}
+
+	weekly, err := repo.Search(domain.SearchQuery{ExcludeKinds: []domain.TimeTableKind{domain.TimeTableKindIntensive, domain.TimeTableKindOndemand}})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(weekly) != 1 || weekly[0].Title != "Weekly Course" {
+		t.Fatalf("unexpected weekly results: %#v", weekly)
+	}
+	if weekly[0].Timetables[0].Kind != domain.TimeTableKindRegular {
+		t.Fatalf("unexpected weekly kind: %s", weekly[0].Timetables[0].Kind)
+	}
+}
+
+func TestLectureRepositoryStoresAndSearchesEnglishFields(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	lecture := &domain.Lecture{
+		University:        "Test University",
+		Title:             "代数学",
+		EnglishTitle:      "Algebra",
+		Department:        "数学系",
+		Code:              "MTH.A201",
+		Year:              2025,
+		Abstract:          "群論の基礎",
+		Keywords:          []string{"群"},
+		LecturePlans:      []domain.LecturePlan{{Count: 1, Plan: "群の定義"}},
+		EnglishDepartment: "Department of Mathematics",
+		EnglishAbstract:   "Basics of group theory",
+		EnglishGoal:       "Understand groups",
+		EnglishFlow:       "Lectures",
+		EnglishAssessment: "Final exam",
+		EnglishKeywords:   []string{"group", "ring"},
+		EnglishLecturePlans: []domain.LecturePlan{
+			{Count: 1, Plan: "Definition of groups"},
+		},
+	}
+	if err := repo.Create(lecture); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	stored, err := repo.FindByID(lecture.ID)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if stored.EnglishDepartment != "Department of Mathematics" || stored.EnglishAbstract != "Basics of group theory" ||
+		stored.EnglishGoal != "Understand groups" || stored.EnglishFlow != "Lectures" || stored.EnglishAssessment != "Final exam" {
+		t.Errorf("unexpected english fields: %+v", stored)
+	}
+	if len(stored.EnglishKeywords) != 2 || stored.EnglishKeywords[0] != "group" {
+		t.Errorf("unexpected english keywords: %v", stored.EnglishKeywords)
+	}
+	if len(stored.EnglishLecturePlans) != 1 || stored.EnglishLecturePlans[0].Plan != "Definition of groups" {
+		t.Errorf("unexpected english plans: %+v", stored.EnglishLecturePlans)
+	}
+	if len(stored.LecturePlans) != 1 || stored.LecturePlans[0].Plan != "群の定義" {
+		t.Errorf("japanese plans must be kept apart: %+v", stored.LecturePlans)
+	}
+
+	queries := map[string]domain.SearchQuery{
+		"english keyword":    {Keywords: []string{"ring"}},
+		"japanese keyword":   {Keywords: []string{"群"}},
+		"english department": {Departments: []string{"Department of Mathematics"}},
+		"english plan topic": {PlanTopic: "Definition"},
+		"english title":      {Title: "Algebra"},
+	}
+	for name, query := range queries {
+		results, err := repo.Search(query)
+		if err != nil {
+			t.Fatalf("%s: Search returned error: %v", name, err)
+		}
+		if len(results) != 1 || results[0].ID != lecture.ID {
+			t.Errorf("%s: unexpected results: %+v", name, results)
+		}
+	}
+}
+
+func TestLectureRepositoryStoresContactsAndOfficeHours(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	lecture := &domain.Lecture{
+		University: "Test University",
+		Title:      "代数学",
+		Code:       "MTH.A201",
+		Year:       2025,
+		Contacts: []domain.ContactEntry{
+			{Kind: domain.ContactKindEmail, Value: "yamada@example.ac.jp", TeacherName: "山田 太郎"},
+			{Kind: domain.ContactKindURL, Value: "https://example.ac.jp/lab"},
+		},
+		OfficeHourSlots: []domain.OfficeHour{
+			{DayOfWeek: domain.DayOfWeekMonday, Start: "12:00", End: "13:00", Note: "月 12:00-13:00"},
+		},
+	}
+	if err := repo.Create(lecture); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	stored, err := repo.FindByID(lecture.ID)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if len(stored.Contacts) != 2 || stored.Contacts[0] != lecture.Contacts[0] || stored.Contacts[1] != lecture.Contacts[1] {
+		t.Errorf("unexpected contacts: %+v", stored.Contacts)
+	}
+	if len(stored.OfficeHourSlots) != 1 || stored.OfficeHourSlots[0] != lecture.OfficeHourSlots[0] {
+		t.Errorf("unexpected office hours: %+v", stored.OfficeHourSlots)
+	}
+}
+
+func TestLectureRepositoryMigrateCourseReferences(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	linear := &domain.Lecture{University: "Test University", Title: "線形代数第一", Code: "LAS.M101", Year: 2025}
+	probability := &domain.Lecture{University: "Test University", Title: "確率と統計 ", Code: "MTH.B201", Year: 2025}
+	if err := repo.Creates([]domain.Lecture{*linear, *probability}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	linearID, probabilityID := 1, 2
+
+	algebra := &domain.Lecture{
+		University:         "Test University",
+		Title:              "代数学",
+		Code:               "MTH.A201",
+		Year:               2025,
+		RelatedCourseCodes: []string{"MTH.B201"},
+		CourseReferences: []domain.CourseReference{
+			{Kind: domain.CourseRelationPrerequisite, Code: "las.m101", Title: "線形代数"},
+			{Kind: domain.CourseRelationRecommended, Title: "確率と統計"},
+			{Kind: domain.CourseRelationRecommended, Title: "存在しない科目"},
+		},
+	}
+	if err := repo.Create(algebra); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	inserted, err := repo.MigrateCourseReferences(context.Background())
+	if err != nil {
+		t.Fatalf("MigrateCourseReferences returned error: %v", err)
+	}
+	if inserted != 2 {
+		t.Fatalf("expected 2 relations, got %d", inserted)
+	}
+	if again, err := repo.MigrateCourseReferences(context.Background()); err != nil || again != 0 {
+		t.Fatalf("expected rerun to insert nothing, got %d, %v", again, err)
+	}
+
+	stored, err := repo.FindByID(algebra.ID)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if len(stored.CourseReferences) != 3 {
+		t.Errorf("unexpected course references: %+v", stored.CourseReferences)
+	}
+	want := []domain.CourseRelation{
+		{LectureID: algebra.ID, RelatedLectureID: linearID, Kind: domain.CourseRelationPrerequisite},
+		{LectureID: algebra.ID, RelatedLectureID: probabilityID, Kind: domain.CourseRelationRecommended},
+		{LectureID: algebra.ID, RelatedLectureID: probabilityID, Kind: domain.CourseRelationRelated},
+	}
+	if len(stored.CourseRelations) != len(want) {
+		t.Fatalf("unexpected course relations: %+v", stored.CourseRelations)
+	}
+	for i := range want {
+		if stored.CourseRelations[i] != want[i] {
+			t.Errorf("relation %d: expected %+v, got %+v", i, want[i], stored.CourseRelations[i])
+		}
+	}
+}
+
+func TestLectureRepositoryMigrateCourseReferencesBackfillsStoredLectures(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	linear := domain.Lecture{University: "Test University", Title: "線形代数第一", Code: "LAS.M101", Year: 2025}
+	// stored before references were extracted: the prerequisite text is there but no references
+	algebra := domain.Lecture{
+		University:   "Test University",
+		Title:        "代数学",
+		Code:         "MTH.A201",
+		Year:         2025,
+		Prerequisite: "「線形代数第一」を履修していること、ただしMTH.B301と同時に履修することはできない。",
+	}
+	if err := repo.Creates([]domain.Lecture{linear, algebra}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	inserted, err := repo.MigrateCourseReferences(context.Background())
+	if err != nil {
+		t.Fatalf("MigrateCourseReferences returned error: %v", err)
+	}
+	if inserted != 1 {
+		t.Fatalf("expected 1 relation, got %d", inserted)
+	}
+
+	stored, err := repo.FindByID(2)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	wantReferences := []domain.CourseReference{{Kind: domain.CourseRelationPrerequisite, Title: "線形代数第一"}}
+	if !reflect.DeepEqual(stored.CourseReferences, wantReferences) {
+		t.Errorf("unexpected course references: %+v", stored.CourseReferences)
+	}
+	wantRelations := []domain.CourseRelation{{LectureID: 2, RelatedLectureID: 1, Kind: domain.CourseRelationPrerequisite}}
+	if !reflect.DeepEqual(stored.CourseRelations, wantRelations) {
+		t.Errorf("unexpected course relations: %+v", stored.CourseRelations)
+	}
+}
+
+func TestLectureRepositoryMigrateCourseReferencesIsIncremental(t *testing.T) {
+	repo, db := newTestRepository(t)
+
+	algebra := domain.Lecture{
+		University: "Test University",
+		Title:      "代数学",
+		Code:       "MTH.A201",
+		Year:       2025,
+		CourseReferences: []domain.CourseReference{
+			{Kind: domain.CourseRelationRecommended, Title: "確率と統計"},
+		},
+	}
+	// the only lecture with the title is from another year
+	older := domain.Lecture{University: "Test University", Title: "確率と統計", Code: "MTH.B201", Year: 2024}
+	none := domain.Lecture{University: "Test University", Title: "解析学", Code: "MTH.A202", Year: 2025, Prerequisite: "特になし"}
+	if err := repo.Creates([]domain.Lecture{algebra, older, none}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	inserted, err := repo.MigrateCourseReferences(context.Background())
+	if err != nil || inserted != 0 {
+		t.Fatalf("expected no relation to another year's lecture, got %d, %v", inserted, err)
+	}
+	var pending, extracted int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM course_references WHERE resolved = 0`).Scan(&pending); err != nil {
+		t.Fatalf("count pending references: %v", err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM lectures WHERE references_extracted = 1`).Scan(&extracted); err != nil {
+		t.Fatalf("count extracted lectures: %v", err)
+	}
+	if pending != 1 || extracted != 3 {
+		t.Fatalf("expected 1 pending reference and 3 extracted lectures, got %d and %d", pending, extracted)
+	}
+
+	// the course is offered this year too, so the pending reference resolves on the next run
+	if err := repo.Creates([]domain.Lecture{{University: "Test University", Title: "確率と統計", Code: "MTH.B201", Year: 2025}}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	inserted, err = repo.MigrateCourseReferences(context.Background())
+	if err != nil || inserted != 1 {
+		t.Fatalf("expected 1 relation, got %d, %v", inserted, err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM course_references WHERE resolved = 0`).Scan(&pending); err != nil {
+		t.Fatalf("count pending references: %v", err)
+	}
+	if pending != 0 {
+		t.Errorf("expected the reference to be marked resolved, got %d pending", pending)
+	}
+
+	stored, err := repo.FindByID(1)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	wantRelations := []domain.CourseRelation{{LectureID: 1, RelatedLectureID: 4, Kind: domain.CourseRelationRecommended}}
+	if !reflect.DeepEqual(stored.CourseRelations, wantRelations) {
+		t.Errorf("unexpected course relations: %+v", stored.CourseRelations)
+	}
+}
+
+func TestLectureRepositoryFindAllByCode(t *testing.T) {
+	repo, _ := newTestRepository(t)
+
+	lectures := []domain.Lecture{
+		{University: "Test University", Title: "哲学A", Code: "LAH.S101", Year: 2025, Teachers: []domain.Teacher{{Name: "山田 太郎"}}},
+		{University: "Test University", Title: "哲学A", Code: "lah.s101", Year: 2023},
+		{University: "Test University", Title: "倫理学A", Code: "LAH.S102", Year: 2025},
+	}
+	if err := repo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	found, err := repo.FindAllByCode(" LAH.S101")
+	if err != nil {
+		t.Fatalf("FindAllByCode returned error: %v", err)
+	}
+	if len(found) != 2 || found[0].Year != 2023 || found[1].Year != 2025 {
+		t.Fatalf("unexpected lectures: %+v", found)
+	}
+	if len(found[1].Teachers) != 1 {
+		t.Errorf("expected the full aggregate, got teachers %+v", found[1].Teachers)
+	}
+
+	if none, err := repo.FindAllByCode(""); err != nil || none != nil {
+		t.Errorf("expected nil for an empty code, got %+v, %v", none, err)
+	}
+}
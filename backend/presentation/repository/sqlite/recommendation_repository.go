@@ -0,0 +1,247 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// RecommendationRepository stores the recommendation index next to the lectures it is built from.
+type RecommendationRepository struct {
+	db *sql.DB
+}
+
+// NewRecommendationRepository creates a recommendation repository for the provided database handle.
+func NewRecommendationRepository(db *sql.DB) (*RecommendationRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &RecommendationRepository{db: db}, nil
+}
+
+type staleRecommendationDocument struct {
+	lectureID int
+	title     string
+	updatedAt string
+	texts     []string
+}
+
+// RefreshRecommendationIndex re-tokenizes lectures that have no index entry or whose title or updated_at
+// differs from the one they were indexed with, and removes entries of deleted lectures.
+func (r *RecommendationRepository) RefreshRecommendationIndex(ctx context.Context) (changed int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM recommendation_terms WHERE lecture_id NOT IN (SELECT id FROM lectures)`); err != nil {
+		return 0, fmt.Errorf("delete orphaned recommendation terms: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM recommendation_documents WHERE lecture_id NOT IN (SELECT id FROM lectures)`)
+	if err != nil {
+		return 0, fmt.Errorf("delete orphaned recommendation documents: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("count orphaned recommendation documents: %w", err)
+	}
+
+	stale, err := r.findStaleDocumentsTx(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleteTerms, err := tx.PrepareContext(ctx, `DELETE FROM recommendation_terms WHERE lecture_id = ?`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare recommendation terms delete: %w", err)
+	}
+	defer deleteTerms.Close()
+	insertTerm, err := tx.PrepareContext(ctx, `INSERT INTO recommendation_terms (lecture_id, term, count) VALUES (?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare recommendation term insert: %w", err)
+	}
+	defer insertTerm.Close()
+	upsertDocument, err := tx.PrepareContext(ctx, `INSERT INTO recommendation_documents (lecture_id, source_title, source_updated_at, indexed_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(lecture_id) DO UPDATE SET
+			source_title = excluded.source_title, source_updated_at = excluded.source_updated_at, indexed_at = excluded.indexed_at`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare recommendation document upsert: %w", err)
+	}
+	defer upsertDocument.Close()
+
+	indexedAt := time.Now().UTC().Format(time.RFC3339)
+	for _, document := range stale {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+		if _, err = upsertDocument.ExecContext(ctx, document.lectureID, document.title, document.updatedAt, indexedAt); err != nil {
+			return 0, fmt.Errorf("upsert recommendation document: %w", err)
+		}
+		if _, err = deleteTerms.ExecContext(ctx, document.lectureID); err != nil {
+			return 0, fmt.Errorf("delete recommendation terms: %w", err)
+		}
+		for term, count := range domain.RecommendationTerms(append([]string{document.title}, document.texts...)...) {
+			if _, err = insertTerm.ExecContext(ctx, document.lectureID, term, count); err != nil {
+				return 0, fmt.Errorf("insert recommendation term: %w", err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit recommendation index: %w", err)
+	}
+	return len(stale) + int(removed), nil
+}
+
+func (r *RecommendationRepository) findStaleDocumentsTx(ctx context.Context, tx *sql.Tx) ([]staleRecommendationDocument, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT l.id, IFNULL(l.updated_at, ''), l.title, IFNULL(l.english_title, ''),
+			IFNULL(l.abstract, ''), IFNULL(l.goal, ''), IFNULL(l.english_abstract, ''), IFNULL(l.english_goal, ''),
+			IFNULL((SELECT GROUP_CONCAT(keyword, ' ') FROM lecture_keywords lk WHERE lk.lecture_id = l.id), '')
+		FROM lectures l
+		LEFT JOIN recommendation_documents d ON d.lecture_id = l.id
+		WHERE d.lecture_id IS NULL OR d.source_updated_at <> IFNULL(l.updated_at, '') OR d.source_title <> l.title
+		ORDER BY l.id`)
+	if err != nil {
+		return nil, fmt.Errorf("select stale recommendation documents: %w", err)
+	}
+	defer rows.Close()
+
+	stale := make([]staleRecommendationDocument, 0)
+	for rows.Next() {
+		document := staleRecommendationDocument{texts: make([]string, 6)}
+		dest := []any{&document.lectureID, &document.updatedAt, &document.title}
+		for i := range document.texts {
+			dest = append(dest, &document.texts[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan stale recommendation document: %w", err)
+		}
+		stale = append(stale, document)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stale recommendation documents: %w", err)
+	}
+	return stale, nil
+}
+
+// FindRecommendationDocuments loads the indexed lectures with their terms, keywords, teachers and course relations.
+func (r *RecommendationRepository) FindRecommendationDocuments() ([]domain.RecommendationDocument, error) {
+	documents := make(map[int]*domain.RecommendationDocument)
+	order := make([]int, 0)
+	err := r.eachRow("recommendation documents", `SELECT l.id, IFNULL(l.code, ''), l.title, IFNULL(l.year, 0)
+		FROM recommendation_documents d
+		JOIN lectures l ON l.id = d.lecture_id
+		ORDER BY l.id`, func(rows *sql.Rows) error {
+		document := &domain.RecommendationDocument{Terms: make(map[string]int)}
+		if err := rows.Scan(&document.LectureID, &document.Code, &document.Title, &document.Year); err != nil {
+			return err
+		}
+		documents[document.LectureID] = document
+		order = append(order, document.LectureID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow("recommendation terms", `SELECT lecture_id, term, count FROM recommendation_terms`, func(rows *sql.Rows) error {
+		var id, count int
+		var term string
+		if err := rows.Scan(&id, &term, &count); err != nil {
+			return err
+		}
+		if document, ok := documents[id]; ok {
+			document.Terms[term] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow("recommendation keywords", `SELECT lecture_id, keyword FROM lecture_keywords ORDER BY lecture_id, keyword`, func(rows *sql.Rows) error {
+		var id int
+		var keyword string
+		if err := rows.Scan(&id, &keyword); err != nil {
+			return err
+		}
+		if document, ok := documents[id]; ok {
+			document.Keywords = append(document.Keywords, keyword)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow("recommendation teachers", `SELECT lt.lecture_id, t.name
+		FROM lecture_teachers lt
+		JOIN teachers t ON t.id = lt.teacher_id
+		ORDER BY lt.lecture_id, t.name`, func(rows *sql.Rows) error {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		if document, ok := documents[id]; ok {
+			document.Teachers = append(document.Teachers, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow("recommendation relations", `SELECT lecture_id, related_lecture_id FROM course_relations
+		UNION
+		SELECT lecture_id, related_lecture_id FROM related_courses
+		ORDER BY 1, 2`, func(rows *sql.Rows) error {
+		var from, to int
+		if err := rows.Scan(&from, &to); err != nil {
+			return err
+		}
+		if document, ok := documents[from]; ok {
+			document.Related = append(document.Related, to)
+		}
+		if document, ok := documents[to]; ok {
+			document.Related = append(document.Related, from)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.RecommendationDocument, 0, len(order))
+	for _, id := range order {
+		result = append(result, *documents[id])
+	}
+	return result, nil
+}
+
+// eachRow runs query and calls scan for every row; what names the rows in errors.
+func (r *RecommendationRepository) eachRow(what, query string, scan func(rows *sql.Rows) error) error {
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return fmt.Errorf("select %s: %w", what, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("scan %s: %w", what, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestRecommendationRepositoryRefreshesIncrementally(t *testing.T) {
+	lectureRepo, db := newTestRepository(t)
+	updatedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	lectures := []domain.Lecture{
+		{University: "Test University", Title: "機械学習", Code: "CSC.T371", Year: 2025, Abstract: "ニューラルネットワーク", Keywords: []string{"機械学習"},
+			Teachers: []domain.Teacher{{Name: "山田 太郎"}}, UpdatedAt: updatedAt},
+		{University: "Test University", Title: "確率と統計", Code: "MTH.B201", Year: 2025, Goal: "推定と検定",
+			Teachers: []domain.Teacher{{Name: "山田 太郎"}}, RelatedCourseCodes: []string{"CSC.T371"}, UpdatedAt: updatedAt},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	repo, err := NewRecommendationRepository(db)
+	if err != nil {
+		t.Fatalf("NewRecommendationRepository returned error: %v", err)
+	}
+	ctx := context.Background()
+	if changed, err := repo.RefreshRecommendationIndex(ctx); err != nil || changed != 2 {
+		t.Fatalf("expected both lectures to be indexed, got %d, %v", changed, err)
+	}
+	if changed, err := repo.RefreshRecommendationIndex(ctx); err != nil || changed != 0 {
+		t.Fatalf("expected nothing to refresh, got %d, %v", changed, err)
+	}
+
+	if _, err := db.Exec(`UPDATE lectures SET updated_at = '2025-04-01', goal = '最尤推定' WHERE id = 2`); err != nil {
+		t.Fatalf("update lecture: %v", err)
+	}
+	if changed, err := repo.RefreshRecommendationIndex(ctx); err != nil || changed != 1 {
+		t.Fatalf("expected the updated lecture to be re-indexed, got %d, %v", changed, err)
+	}
+
+	documents, err := repo.FindRecommendationDocuments()
+	if err != nil {
+		t.Fatalf("FindRecommendationDocuments returned error: %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("expected 2 documents, got %+v", documents)
+	}
+	first, second := documents[0], documents[1]
+	if first.Terms["機械"] == 0 || first.Terms["ネッ"] == 0 || len(first.Keywords) != 1 || first.Teachers[0] != "山田 太郎" {
+		t.Errorf("unexpected first document: %+v", first)
+	}
+	if second.Terms["最尤"] == 0 || second.Terms["検定"] != 0 {
+		t.Errorf("expected the terms of the updated goal, got %v", second.Terms)
+	}
+	if len(first.Related) != 1 || first.Related[0] != 2 || len(second.Related) != 1 || second.Related[0] != 1 {
+		t.Errorf("expected relations in both directions, got %v and %v", first.Related, second.Related)
+	}
+
+	if _, err := db.Exec(`DELETE FROM lectures WHERE id = 1`); err != nil {
+		t.Fatalf("delete lecture: %v", err)
+	}
+	if changed, err := repo.RefreshRecommendationIndex(ctx); err != nil || changed != 1 {
+		t.Fatalf("expected the deleted lecture to be removed, got %d, %v", changed, err)
+	}
+	if documents, err := repo.FindRecommendationDocuments(); err != nil || len(documents) != 1 {
+		t.Errorf("expected 1 document, got %+v, %v", documents, err)
+	}
+}
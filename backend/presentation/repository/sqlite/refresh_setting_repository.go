@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// RefreshSettingRepository provides SQLite backed storage of the background refresh schedule.
+type RefreshSettingRepository struct {
+	db *sql.DB
+}
+
+// NewRefreshSettingRepository creates a refresh setting repository for the provided database handle.
+func NewRefreshSettingRepository(db *sql.DB) (*RefreshSettingRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &RefreshSettingRepository{db: db}, nil
+}
+
+// Find returns the stored schedule, or nil when the background refresh is disabled.
+func (r *RefreshSettingRepository) Find() (*domain.RefreshSetting, error) {
+	var setting domain.RefreshSetting
+	var updatedAt string
+	err := r.db.QueryRow(`SELECT site_id, spec, updated_at FROM refresh_settings WHERE id = 1`).Scan(&setting.SiteID, &setting.Spec, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select refresh setting: %w", err)
+	}
+	setting.UpdatedAt = parseStoredTime(updatedAt)
+	return &setting, nil
+}
+
+// Save replaces the stored schedule.
+func (r *RefreshSettingRepository) Save(setting domain.RefreshSetting) error {
+	updatedAt := setting.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+	if _, err := r.db.Exec(`INSERT INTO refresh_settings (id, site_id, spec, updated_at) VALUES (1, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET site_id = excluded.site_id, spec = excluded.spec, updated_at = excluded.updated_at`,
+		strings.TrimSpace(setting.SiteID), strings.TrimSpace(setting.Spec), updatedAt.UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("save refresh setting: %w", err)
+	}
+	return nil
+}
+
+// Delete disables the background refresh.
+func (r *RefreshSettingRepository) Delete() error {
+	if _, err := r.db.Exec(`DELETE FROM refresh_settings`); err != nil {
+		return fmt.Errorf("delete refresh setting: %w", err)
+	}
+	return nil
+}
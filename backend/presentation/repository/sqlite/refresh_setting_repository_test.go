@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestRefreshSettingRepositoryRoundTrip(t *testing.T) {
+	_, db := newTestRepository(t)
+	repo, err := NewRefreshSettingRepository(db)
+	if err != nil {
+		t.Fatalf("NewRefreshSettingRepository: %v", err)
+	}
+
+	setting, err := repo.Find()
+	if err != nil || setting != nil {
+		t.Fatalf("expected no setting, got %+v, %v", setting, err)
+	}
+
+	updatedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	if err := repo.Save(domain.RefreshSetting{SiteID: "isct", Spec: "0 3 * * *", UpdatedAt: updatedAt}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := repo.Save(domain.RefreshSetting{SiteID: "isct", Spec: " @daily ", UpdatedAt: updatedAt}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	setting, err = repo.Find()
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if setting == nil || setting.SiteID != "isct" || setting.Spec != "@daily" || !setting.UpdatedAt.Equal(updatedAt) {
+		t.Fatalf("unexpected setting: %+v", setting)
+	}
+
+	if err := repo.Delete(); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if setting, err := repo.Find(); err != nil || setting != nil {
+		t.Fatalf("expected the setting to be deleted, got %+v, %v", setting, err)
+	}
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// RequirementSetRepository stores graduation requirement sets as their DSL source.
+type RequirementSetRepository struct {
+	db *sql.DB
+}
+
+// NewRequirementSetRepository creates a requirement set repository for the provided database handle.
+func NewRequirementSetRepository(db *sql.DB) (*RequirementSetRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &RequirementSetRepository{db: db}, nil
+}
+
+// Save creates or replaces the set with the same name.
+func (r *RequirementSetRepository) Save(set *domain.RequirementSet) error {
+	if set == nil {
+		return errors.New("nil requirement set")
+	}
+	name := strings.TrimSpace(set.Name)
+	if name == "" {
+		return errors.New("requirement set name is empty")
+	}
+
+	if _, err := r.db.Exec(`INSERT INTO requirement_sets (name, source, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET source = excluded.source, updated_at = excluded.updated_at`,
+		name, set.Source, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("save requirement set: %w", err)
+	}
+	return nil
+}
+
+// FindByName returns the parsed set, or nil when it does not exist.
+func (r *RequirementSetRepository) FindByName(name string) (*domain.RequirementSet, error) {
+	var source string
+	err := r.db.QueryRow(`SELECT source FROM requirement_sets WHERE name = ?`, strings.TrimSpace(name)).Scan(&source)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select requirement set: %w", err)
+	}
+
+	set, err := domain.ParseRequirementSet(name, source)
+	if err != nil {
+		return nil, fmt.Errorf("parse requirement set %q: %w", name, err)
+	}
+	return set, nil
+}
+
+// FindAll returns every stored set ordered by name.
+func (r *RequirementSetRepository) FindAll() ([]domain.RequirementSet, error) {
+	rows, err := r.db.Query(`SELECT name, source FROM requirement_sets ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("select requirement sets: %w", err)
+	}
+	defer rows.Close()
+
+	sets := make([]domain.RequirementSet, 0)
+	for rows.Next() {
+		var name, source string
+		if err := rows.Scan(&name, &source); err != nil {
+			return nil, fmt.Errorf("scan requirement set: %w", err)
+		}
+		set, err := domain.ParseRequirementSet(name, source)
+		if err != nil {
+			return nil, fmt.Errorf("parse requirement set %q: %w", name, err)
+		}
+		sets = append(sets, *set)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate requirement sets: %w", err)
+	}
+	return sets, nil
+}
+
+// Delete removes the set with the given name.
+func (r *RequirementSetRepository) Delete(name string) error {
+	if _, err := r.db.Exec(`DELETE FROM requirement_sets WHERE name = ?`, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("delete requirement set: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestRequirementSetRepositorySaveFindDelete(t *testing.T) {
+	_, db := newTestRepository(t)
+	repo, err := NewRequirementSetRepository(db)
+	if err != nil {
+		t.Fatalf("NewRequirementSetRepository returned error: %v", err)
+	}
+
+	set, err := domain.ParseRequirementSet("数学系", `requirement "総単位" credits >= 124`)
+	if err != nil {
+		t.Fatalf("ParseRequirementSet returned error: %v", err)
+	}
+	if err := repo.Save(set); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	updated, err := domain.ParseRequirementSet("数学系", "requirement \"総単位\" credits >= 124\nrequirement \"必修\" mandatory \"MTH.A201\"")
+	if err != nil {
+		t.Fatalf("ParseRequirementSet returned error: %v", err)
+	}
+	if err := repo.Save(updated); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	found, err := repo.FindByName("数学系")
+	if err != nil {
+		t.Fatalf("FindByName returned error: %v", err)
+	}
+	if found == nil || len(found.Requirements) != 2 || found.Requirements[1].Kind != domain.RequirementKindMandatory {
+		t.Fatalf("expected the saved set to be replaced, got %+v", found)
+	}
+
+	all, err := repo.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll returned error: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("expected one set, got %+v", all)
+	}
+
+	if err := repo.Delete("数学系"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if missing, err := repo.FindByName("数学系"); err != nil || missing != nil {
+		t.Errorf("expected the set to be deleted, got %+v, %v", missing, err)
+	}
+}
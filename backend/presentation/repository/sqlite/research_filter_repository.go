@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/kavos113/desy/backend/domain"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// SQLite parses "X REGEXP Y" but leaves the regexp(Y, X) function to the application.
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, sqliteRegexp)
+}
+
+var compiledRegexps sync.Map
+
+func sqliteRegexp(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	pattern, ok := args[0].(string)
+	if !ok {
+		return nil, errors.New("regexp: pattern must be text")
+	}
+	var value string
+	switch v := args[1].(type) {
+	case nil:
+		return int64(0), nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		value = fmt.Sprint(v)
+	}
+
+	compiled, ok := compiledRegexps.Load(pattern)
+	if !ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("regexp: %w", err)
+		}
+		compiled, _ = compiledRegexps.LoadOrStore(pattern, re)
+	}
+
+	if compiled.(*regexp.Regexp).MatchString(value) {
+		return int64(1), nil
+	}
+	return int64(0), nil
+}
+
+// ResearchFilterRepository provides SQLite backed access to the rules behind FilterNotResearch.
+type ResearchFilterRepository struct {
+	db *sql.DB
+}
+
+// NewResearchFilterRepository creates a research filter repository for the provided database handle.
+func NewResearchFilterRepository(db *sql.DB) (*ResearchFilterRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &ResearchFilterRepository{db: db}, nil
+}
+
+// FindAll retrieves every rule, including disabled ones, in creation order.
+func (r *ResearchFilterRepository) FindAll() ([]domain.ResearchFilterRule, error) {
+	return findResearchFilterRules(r.db, false)
+}
+
+// Create stores a new rule and assigns its identifier.
+func (r *ResearchFilterRepository) Create(rule *domain.ResearchFilterRule) error {
+	if rule == nil {
+		return errors.New("nil research filter rule")
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(`INSERT INTO research_filter_rules (field, match_type, pattern, enabled) VALUES (?, ?, ?, ?)`,
+		string(rule.Field), string(rule.Match), rule.Pattern, boolToInt(rule.Enabled))
+	if err != nil {
+		return fmt.Errorf("insert research filter rule: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("last insert research filter rule id: %w", err)
+	}
+	rule.ID = int(id)
+
+	return nil
+}
+
+// Update overwrites an existing rule.
+func (r *ResearchFilterRepository) Update(rule *domain.ResearchFilterRule) error {
+	if rule == nil {
+		return errors.New("nil research filter rule")
+	}
+	if rule.ID <= 0 {
+		return fmt.Errorf("invalid research filter rule id: %d", rule.ID)
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(`UPDATE research_filter_rules SET field = ?, match_type = ?, pattern = ?, enabled = ? WHERE id = ?`,
+		string(rule.Field), string(rule.Match), rule.Pattern, boolToInt(rule.Enabled), rule.ID)
+	if err != nil {
+		return fmt.Errorf("update research filter rule: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("research filter rule not found: %d", rule.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a rule.
+func (r *ResearchFilterRepository) Delete(id int) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid research filter rule id: %d", id)
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM research_filter_rules WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete research filter rule: %w", err)
+	}
+
+	return nil
+}
+
+func findResearchFilterRules(db *sql.DB, enabledOnly bool) ([]domain.ResearchFilterRule, error) {
+	query := `SELECT id, field, match_type, pattern, enabled FROM research_filter_rules`
+	if enabledOnly {
+		query += ` WHERE enabled = 1`
+	}
+	query += ` ORDER BY id`
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("select research filter rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]domain.ResearchFilterRule, 0)
+	for rows.Next() {
+		var (
+			rule         domain.ResearchFilterRule
+			field, match string
+			enabled      int
+		)
+		if err := rows.Scan(&rule.ID, &field, &match, &rule.Pattern, &enabled); err != nil {
+			return nil, fmt.Errorf("scan research filter rule: %w", err)
+		}
+		rule.Field = domain.ResearchFilterField(field)
+		rule.Match = domain.ResearchFilterMatch(match)
+		rule.Enabled = enabled != 0
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate research filter rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func seedResearchFilterRules(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin seed research filter rules: %w", err)
+	}
+
+	for _, rule := range domain.DefaultResearchFilterRules() {
+		if _, err := tx.Exec(`INSERT INTO research_filter_rules (field, match_type, pattern, enabled) VALUES (?, ?, ?, ?)`,
+			string(rule.Field), string(rule.Match), rule.Pattern, boolToInt(rule.Enabled)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("seed research filter rule: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit seed research filter rules: %w", err)
+	}
+
+	return nil
+}
+
+// researchFilterCondition compiles the enabled rules into a condition that excludes matching lectures.
+func (r *LectureRepository) researchFilterCondition() (string, []any, error) {
+	rules, err := findResearchFilterRules(r.db, true)
+	if err != nil {
+		return "", nil, err
+	}
+
+	matches := make([]string, 0, len(rules))
+	args := make([]any, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Validate() != nil {
+			continue
+		}
+		condition, arg := researchRuleCondition(rule)
+		matches = append(matches, condition)
+		args = append(args, arg)
+	}
+
+	if len(matches) == 0 {
+		return "", nil, nil
+	}
+
+	return "NOT (" + strings.Join(matches, " OR ") + ")", args, nil
+}
+
+func researchRuleCondition(rule domain.ResearchFilterRule) (string, any) {
+	column := "l.title"
+	pattern := rule.Pattern
+	switch rule.Field {
+	case domain.ResearchFilterFieldDepartment:
+		column = "IFNULL(l.department, '')"
+	case domain.ResearchFilterFieldCode:
+		column = "UPPER(IFNULL(l.code, ''))"
+		if rule.Match != domain.ResearchFilterMatchRegex {
+			pattern = strings.ToUpper(pattern)
+		}
+	case domain.ResearchFilterFieldTeacher:
+		column = "rt.name"
+	}
+
+	var condition string
+	switch rule.Match {
+	case domain.ResearchFilterMatchPrefix:
+		condition = "INSTR(" + column + ", ?) = 1"
+	case domain.ResearchFilterMatchRegex:
+		condition = column + " REGEXP ?"
+	default:
+		condition = "INSTR(" + column + ", ?) > 0"
+	}
+
+	if rule.Field == domain.ResearchFilterFieldTeacher {
+		condition = "EXISTS (SELECT 1 FROM lecture_teachers rlt JOIN teachers rt ON rt.id = rlt.teacher_id WHERE rlt.lecture_id = l.id AND " + condition + ")"
+	}
+
+	return condition, pattern
+}
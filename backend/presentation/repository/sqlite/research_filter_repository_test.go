@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestNewLectureRepositorySeedsResearchFilterRules(t *testing.T) {
+	_, db := newTestRepository(t)
+	ruleRepo, err := NewResearchFilterRepository(db)
+	if err != nil {
+		t.Fatalf("NewResearchFilterRepository returned error: %v", err)
+	}
+
+	rules, err := ruleRepo.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll returned error: %v", err)
+	}
+	if len(rules) != len(domain.DefaultResearchFilterRules()) {
+		t.Fatalf("expected default rules to be seeded, got %d", len(rules))
+	}
+
+	for _, rule := range rules {
+		if err := ruleRepo.Delete(rule.ID); err != nil {
+			t.Fatalf("Delete returned error: %v", err)
+		}
+	}
+	if _, err := NewLectureRepository(db); err != nil {
+		t.Fatalf("NewLectureRepository returned error: %v", err)
+	}
+
+	rules, err = ruleRepo.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll returned error: %v", err)
+	}
+	if len(rules) != 0 {
+		t.Fatalf("expected deleted rules not to be reseeded, got %d", len(rules))
+	}
+}
+
+func TestLectureRepositorySearchAppliesResearchFilterRules(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ruleRepo, err := NewResearchFilterRepository(db)
+	if err != nil {
+		t.Fatalf("NewResearchFilterRepository returned error: %v", err)
+	}
+
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 1, "テスト大学", "線形代数", "理学院", "MTH.A201", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 2, "テスト大学", "数学輪講第一", "理学院", "MTH.Z491", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 3, "テスト大学", "教職実践演習", "教職課程", "LAT.A401", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 4, "テスト大学", "修士論文研究", "理学院", "MTH.Z591", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 5, "テスト大学", "解析学", "理学院", "MTH.A202", 2025)
+
+	mustExec(t, db, `INSERT INTO teachers (id, name) VALUES (?, ?)`, 1, "指導教員")
+	mustExec(t, db, `INSERT INTO lecture_teachers (lecture_id, teacher_id) VALUES (?, ?)`, 5, 1)
+
+	rules := []domain.ResearchFilterRule{
+		{Field: domain.ResearchFilterFieldTitle, Match: domain.ResearchFilterMatchRegex, Pattern: "輪講第[一二三]$", Enabled: true},
+		{Field: domain.ResearchFilterFieldDepartment, Match: domain.ResearchFilterMatchContains, Pattern: "教職", Enabled: true},
+		{Field: domain.ResearchFilterFieldCode, Match: domain.ResearchFilterMatchPrefix, Pattern: "mth.z5", Enabled: true},
+		{Field: domain.ResearchFilterFieldTitle, Match: domain.ResearchFilterMatchContains, Pattern: "線形", Enabled: false},
+	}
+	for i := range rules {
+		if err := ruleRepo.Create(&rules[i]); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+	}
+
+	results, err := repo.Search(domain.SearchQuery{FilterNotResearch: true})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 1 {
+		t.Fatalf("expected only 線形代数 to remain, got %+v", results)
+	}
+
+	rules[3].Enabled = true
+	if err := ruleRepo.Update(&rules[3]); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	results, err = repo.Search(domain.SearchQuery{FilterNotResearch: true})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected enabled rule to hide remaining lecture, got %+v", results)
+	}
+
+	if err := ruleRepo.Create(&domain.ResearchFilterRule{Field: domain.ResearchFilterFieldTitle, Match: domain.ResearchFilterMatchRegex, Pattern: "(", Enabled: true}); err == nil {
+		t.Fatal("expected invalid regex to be rejected")
+	}
+}
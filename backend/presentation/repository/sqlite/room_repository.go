@@ -0,0 +1,338 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// RoomRepository provides SQLite backed access to the room directory.
+type RoomRepository struct {
+	db *sql.DB
+}
+
+// NewRoomRepository creates a room repository for the provided database handle.
+func NewRoomRepository(db *sql.DB) (*RoomRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &RoomRepository{db: db}, nil
+}
+
+// FindAll retrieves every known room ordered by campus, building and number.
+func (r *RoomRepository) FindAll() ([]domain.Room, error) {
+	rows, err := r.db.Query(`SELECT id, name, building, campus, number, virtual FROM rooms ORDER BY IFNULL(campus, ''), IFNULL(building, ''), IFNULL(number, ''), name`)
+	if err != nil {
+		return nil, fmt.Errorf("select rooms: %w", err)
+	}
+	defer rows.Close()
+
+	rooms := make([]domain.Room, 0)
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rooms: %w", err)
+	}
+
+	return rooms, nil
+}
+
+// FindByID retrieves a room by identifier.
+func (r *RoomRepository) FindByID(id int) (*domain.Room, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid room id: %d", id)
+	}
+
+	rows, err := r.db.Query(`SELECT id, name, building, campus, number, virtual FROM rooms WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("select room: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("iterate room: %w", err)
+		}
+		return nil, nil
+	}
+
+	room, err := scanRoom(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// FindFree retrieves the physical rooms that no regular timetable of the given year occupies in the slot.
+func (r *RoomRepository) FindFree(year int, semester domain.Semester, day domain.DayOfWeek, period domain.Period, building string) ([]domain.Room, error) {
+	query := `SELECT r.id, r.name, r.building, r.campus, r.number, r.virtual FROM rooms r
+WHERE IFNULL(r.virtual, 0) = 0
+AND NOT EXISTS (
+	SELECT 1 FROM timetables tt
+	JOIN lectures l ON l.id = tt.lecture_id
+	WHERE tt.room_id = r.id AND l.year = ? AND tt.semester = ? AND tt.day_of_week = ? AND tt.period = ? AND IFNULL(tt.kind, ?) = ?
+)`
+	args := []any{year, string(semester), string(day), int(period), string(domain.TimeTableKindRegular), string(domain.TimeTableKindRegular)}
+
+	if building != "" {
+		query += " AND r.building = ?"
+		args = append(args, building)
+	}
+	query += " ORDER BY IFNULL(r.campus, ''), IFNULL(r.building, ''), IFNULL(r.number, ''), r.name"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select free rooms: %w", err)
+	}
+	defer rows.Close()
+
+	rooms := make([]domain.Room, 0)
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate free rooms: %w", err)
+	}
+
+	return rooms, nil
+}
+
+// FindBookedYears returns the years, newest first, whose regular timetables hold a physical room.
+func (r *RoomRepository) FindBookedYears() ([]int, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT l.year FROM timetables tt
+JOIN lectures l ON l.id = tt.lecture_id
+JOIN rooms r ON r.id = tt.room_id
+WHERE IFNULL(r.virtual, 0) = 0 AND IFNULL(tt.kind, ?) = ? AND l.year IS NOT NULL
+ORDER BY l.year DESC`, string(domain.TimeTableKindRegular), string(domain.TimeTableKindRegular))
+	if err != nil {
+		return nil, fmt.Errorf("select booked years: %w", err)
+	}
+	defer rows.Close()
+
+	years := make([]int, 0)
+	for rows.Next() {
+		var year int
+		if err := rows.Scan(&year); err != nil {
+			return nil, fmt.Errorf("scan booked year: %w", err)
+		}
+		years = append(years, year)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate booked years: %w", err)
+	}
+	return years, nil
+}
+
+// FindBookings retrieves the regular timetable entries held in a room during the given year.
+func (r *RoomRepository) FindBookings(roomID int, year int) ([]domain.RoomBooking, error) {
+	rows, err := r.db.Query(`SELECT l.id, IFNULL(l.code, ''), l.title, l.year, IFNULL(tt.semester, ''), IFNULL(tt.day_of_week, ''), IFNULL(tt.period, 0)
+FROM timetables tt
+JOIN lectures l ON l.id = tt.lecture_id
+WHERE tt.room_id = ? AND l.year = ? AND IFNULL(tt.kind, ?) = ?
+ORDER BY tt.semester, tt.day_of_week, tt.period, l.code, l.id`,
+		roomID, year, string(domain.TimeTableKindRegular), string(domain.TimeTableKindRegular))
+	if err != nil {
+		return nil, fmt.Errorf("select room bookings: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := make([]domain.RoomBooking, 0)
+	for rows.Next() {
+		var (
+			booking  domain.RoomBooking
+			semester string
+			day      string
+			period   int
+		)
+		if err := rows.Scan(&booking.LectureID, &booking.Code, &booking.Title, &booking.Year, &semester, &day, &period); err != nil {
+			return nil, fmt.Errorf("scan room booking: %w", err)
+		}
+		booking.Semester = domain.Semester(semester)
+		booking.DayOfWeek = domain.DayOfWeek(day)
+		booking.Period = domain.Period(period)
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate room bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// NormalizeRooms fills the building, campus and number columns of every room
+// and merges rooms whose names normalize to the same building and number.
+func (r *RoomRepository) NormalizeRooms(ctx context.Context) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin normalize rooms transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, name, building, campus, number, virtual FROM rooms ORDER BY id`)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("select rooms for normalization: %w", err)
+	}
+
+	stored := make([]domain.Room, 0)
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, err
+		}
+		stored = append(stored, room)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		tx.Rollback()
+		return 0, fmt.Errorf("iterate rooms for normalization: %w", err)
+	}
+	rows.Close()
+
+	changed := 0
+	canonical := make(map[string]int)
+
+	for _, room := range stored {
+		normalized := domain.NormalizeRoom(room.Name)
+
+		if key := roomKey(normalized); key != "" {
+			if keepID, ok := canonical[key]; ok {
+				if _, err := tx.ExecContext(ctx, `UPDATE timetables SET room_id = ? WHERE room_id = ?`, keepID, room.ID); err != nil {
+					tx.Rollback()
+					return 0, fmt.Errorf("merge room timetables: %w", err)
+				}
+				if _, err := tx.ExecContext(ctx, `DELETE FROM rooms WHERE id = ?`, room.ID); err != nil {
+					tx.Rollback()
+					return 0, fmt.Errorf("delete merged room: %w", err)
+				}
+				changed++
+				continue
+			}
+			canonical[key] = room.ID
+		}
+
+		if room.Building == normalized.Building && room.Campus == normalized.Campus && room.Number == normalized.Number && room.Virtual == normalized.Virtual {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE rooms SET building = ?, campus = ?, number = ?, virtual = ? WHERE id = ?`,
+			nullString(normalized.Building),
+			nullString(string(normalized.Campus)),
+			nullString(normalized.Number),
+			boolToInt(normalized.Virtual),
+			room.ID,
+		); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("update normalized room: %w", err)
+		}
+		changed++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit normalize rooms transaction: %w", err)
+	}
+
+	return changed, nil
+}
+
+// ensureRoomTx returns the room for the given name, reusing a room that normalizes to the same building and number.
+func ensureRoomTx(tx *sql.Tx, name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, nil
+	}
+
+	var id int
+	err := tx.QueryRow(`SELECT id FROM rooms WHERE name = ?`, name).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("select room: %w", err)
+	}
+
+	room := domain.NormalizeRoom(name)
+	if roomKey(room) != "" {
+		err := tx.QueryRow(`SELECT id FROM rooms WHERE building = ? AND number = ? ORDER BY id LIMIT 1`, room.Building, room.Number).Scan(&id)
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("select room by building: %w", err)
+		}
+	}
+
+	result, err := tx.Exec(`INSERT OR IGNORE INTO rooms (name, building, campus, number, virtual) VALUES (?, ?, ?, ?, ?)`,
+		name,
+		nullString(room.Building),
+		nullString(string(room.Campus)),
+		nullString(room.Number),
+		boolToInt(room.Virtual),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("insert room: %w", err)
+	}
+
+	id64, err := result.LastInsertId()
+	if err == nil && id64 != 0 {
+		return int(id64), nil
+	}
+
+	err = tx.QueryRow(`SELECT id FROM rooms WHERE name = ?`, name).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("select ensured room: %w", err)
+	}
+
+	return id, nil
+}
+
+// roomKey identifies a physical room by its building and number; it is empty for rooms that could not be normalized.
+func roomKey(room domain.Room) string {
+	if room.Virtual || room.Building == "" || room.Number == "" {
+		return ""
+	}
+	return room.Building + "-" + room.Number
+}
+
+func scanRoom(rows *sql.Rows) (domain.Room, error) {
+	var (
+		room                     domain.Room
+		building, campus, number sql.NullString
+		virtual                  sql.NullInt64
+	)
+	if err := rows.Scan(&room.ID, &room.Name, &building, &campus, &number, &virtual); err != nil {
+		return domain.Room{}, fmt.Errorf("scan room: %w", err)
+	}
+	room.Building = building.String
+	room.Campus = domain.Campus(campus.String)
+	room.Number = number.String
+	room.Virtual = virtual.Valid && virtual.Int64 != 0
+	return room, nil
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,231 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestRoomRepositoryNormalizeRoomsMergesVariants(t *testing.T) {
+	_, db := newTestRepository(t)
+
+	roomRepo, err := NewRoomRepository(db)
+	if err != nil {
+		t.Fatalf("NewRoomRepository returned error: %v", err)
+	}
+
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 1, "テスト大学", "線形代数", "理学院", "MTH.A201", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 2, "テスト大学", "解析学", "理学院", "MTH.A202", 2025)
+
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 10, "W5-104")
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 11, "西5号館104")
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 12, "Zoom")
+
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, room_id, day_of_week, period) VALUES (?, ?, ?, ?)`, 1, 10, string(domain.DayOfWeekMonday), int(domain.Period1))
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, room_id, day_of_week, period) VALUES (?, ?, ?, ?)`, 2, 11, string(domain.DayOfWeekTuesday), int(domain.Period2))
+
+	if _, err := roomRepo.NormalizeRooms(context.Background()); err != nil {
+		t.Fatalf("NormalizeRooms returned error: %v", err)
+	}
+
+	rooms, err := roomRepo.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll returned error: %v", err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("expected variants to be merged into 2 rooms, got %d", len(rooms))
+	}
+
+	room, err := roomRepo.FindByID(10)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if room == nil {
+		t.Fatal("expected canonical room to be kept")
+	}
+	if room.Building != "W5" || room.Number != "104" || room.Campus != domain.CampusOokayama {
+		t.Fatalf("unexpected normalized room: %+v", room)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM timetables WHERE room_id = 10`).Scan(&count); err != nil {
+		t.Fatalf("count timetables: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected timetables to point at the merged room, got %d", count)
+	}
+
+	virtual, err := roomRepo.FindByID(12)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if virtual == nil || !virtual.Virtual || virtual.Campus != domain.CampusOnline {
+		t.Fatalf("expected virtual room, got %+v", virtual)
+	}
+
+	changed, err := roomRepo.NormalizeRooms(context.Background())
+	if err != nil {
+		t.Fatalf("NormalizeRooms returned error: %v", err)
+	}
+	if changed != 0 {
+		t.Fatalf("expected second normalization to be a no-op, got %d changes", changed)
+	}
+}
+
+func TestLectureRepositoryCreateReusesNormalizedRoom(t *testing.T) {
+	repo, db := newTestRepository(t)
+
+	for _, seed := range []struct{ code, room string }{
+		{code: "MTH.A201", room: "W5-104"},
+		{code: "MTH.A202", room: "西5号館104"},
+	} {
+		lecture := &domain.Lecture{
+			University: "テスト大学",
+			Title:      "講義",
+			Department: "理学院",
+			Code:       seed.code,
+			Year:       2025,
+			Timetables: []domain.TimeTable{{
+				DayOfWeek: domain.DayOfWeekMonday,
+				Period:    domain.Period1,
+				Room:      domain.Room{Name: seed.room},
+			}},
+		}
+		if err := repo.Create(lecture); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM rooms`).Scan(&count); err != nil {
+		t.Fatalf("count rooms: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected room variants to share one row, got %d", count)
+	}
+}
+
+func TestLectureRepositorySearchFiltersByRoomBuilding(t *testing.T) {
+	repo, db := newTestRepository(t)
+	roomRepo, err := NewRoomRepository(db)
+	if err != nil {
+		t.Fatalf("NewRoomRepository returned error: %v", err)
+	}
+
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 1, "テスト大学", "線形代数", "理学院", "MTH.A201", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 2, "テスト大学", "解析学", "理学院", "MTH.A202", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 3, "テスト大学", "情報理論", "情報理工学院", "CSC.T301", 2025)
+
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 10, "W5-104")
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 11, "西5号館201")
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 12, "J2-204")
+
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, room_id, day_of_week, period) VALUES (?, ?, ?, ?)`, 1, 10, string(domain.DayOfWeekMonday), int(domain.Period1))
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, room_id, day_of_week, period) VALUES (?, ?, ?, ?)`, 2, 11, string(domain.DayOfWeekTuesday), int(domain.Period2))
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, room_id, day_of_week, period) VALUES (?, ?, ?, ?)`, 3, 12, string(domain.DayOfWeekFriday), int(domain.Period3))
+
+	if _, err := roomRepo.NormalizeRooms(context.Background()); err != nil {
+		t.Fatalf("NormalizeRooms returned error: %v", err)
+	}
+
+	tests := []struct {
+		room  string
+		codes []string
+	}{
+		{room: "W5", codes: []string{"MTH.A201", "MTH.A202"}},
+		{room: "西5号館", codes: []string{"MTH.A201", "MTH.A202"}},
+		{room: "西5号館104", codes: []string{"MTH.A201"}},
+		{room: "J2", codes: []string{"CSC.T301"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.room, func(t *testing.T) {
+			results, err := repo.Search(domain.SearchQuery{Room: tt.room})
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			if len(results) != len(tt.codes) {
+				t.Fatalf("expected %d results, got %d", len(tt.codes), len(results))
+			}
+			found := make(map[string]bool)
+			for _, result := range results {
+				found[result.Code] = true
+			}
+			for _, code := range tt.codes {
+				if !found[code] {
+					t.Errorf("expected %s to match room %s", code, tt.room)
+				}
+			}
+		})
+	}
+}
+
+func TestRoomRepositoryFindFreeAndBookings(t *testing.T) {
+	_, db := newTestRepository(t)
+	roomRepo, err := NewRoomRepository(db)
+	if err != nil {
+		t.Fatalf("NewRoomRepository returned error: %v", err)
+	}
+
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 1, "テスト大学", "線形代数", "理学院", "MTH.A201", 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 2, "テスト大学", "解析学", "理学院", "MTH.A202", 2024)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, year) VALUES (?, ?, ?, ?, ?, ?)`, 3, "テスト大学", "集中講義", "理学院", "MTH.A203", 2025)
+
+	mustExec(t, db, `INSERT INTO rooms (id, name, building, number) VALUES (?, ?, ?, ?)`, 10, "W5-104", "W5", "104")
+	mustExec(t, db, `INSERT INTO rooms (id, name, building, number) VALUES (?, ?, ?, ?)`, 11, "W5-105", "W5", "105")
+	mustExec(t, db, `INSERT INTO rooms (id, name, building, number) VALUES (?, ?, ?, ?)`, 12, "J2-204", "J2", "204")
+	mustExec(t, db, `INSERT INTO rooms (id, name, virtual) VALUES (?, ?, ?)`, 13, "Zoom", 1)
+
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period) VALUES (?, ?, ?, ?, ?)`, 1, string(domain.SemesterSpring), 10, string(domain.DayOfWeekMonday), int(domain.Period1))
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period) VALUES (?, ?, ?, ?, ?)`, 2, string(domain.SemesterSpring), 11, string(domain.DayOfWeekMonday), int(domain.Period1))
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, kind, semester, room_id, day_of_week, period) VALUES (?, ?, ?, ?, ?, ?)`, 3, string(domain.TimeTableKindIntensive), string(domain.SemesterSpring), 12, string(domain.DayOfWeekMonday), int(domain.Period1))
+
+	free, err := roomRepo.FindFree(2025, domain.SemesterSpring, domain.DayOfWeekMonday, domain.Period1, "")
+	if err != nil {
+		t.Fatalf("FindFree returned error: %v", err)
+	}
+	if len(free) != 2 {
+		t.Fatalf("expected 2 free rooms, got %d: %+v", len(free), free)
+	}
+	for _, room := range free {
+		if room.ID == 10 || room.Virtual {
+			t.Errorf("unexpected free room: %+v", room)
+		}
+	}
+
+	inBuilding, err := roomRepo.FindFree(2025, domain.SemesterSpring, domain.DayOfWeekMonday, domain.Period1, "W5")
+	if err != nil {
+		t.Fatalf("FindFree returned error: %v", err)
+	}
+	if len(inBuilding) != 1 || inBuilding[0].ID != 11 {
+		t.Fatalf("expected only W5-105 to be free in W5, got %+v", inBuilding)
+	}
+
+	bookings, err := roomRepo.FindBookings(10, 2025)
+	if err != nil {
+		t.Fatalf("FindBookings returned error: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Fatalf("expected 1 booking, got %d", len(bookings))
+	}
+	if bookings[0].Code != "MTH.A201" || bookings[0].Semester != domain.SemesterSpring || bookings[0].Period != domain.Period1 {
+		t.Fatalf("unexpected booking: %+v", bookings[0])
+	}
+
+	years, err := roomRepo.FindBookedYears()
+	if err != nil {
+		t.Fatalf("FindBookedYears returned error: %v", err)
+	}
+	if len(years) != 2 || years[0] != 2025 || years[1] != 2024 {
+		t.Fatalf("expected 2025 and 2024, got %v", years)
+	}
+
+	intensive, err := roomRepo.FindBookings(12, 2025)
+	if err != nil {
+		t.Fatalf("FindBookings returned error: %v", err)
+	}
+	if len(intensive) != 0 {
+		t.Fatalf("expected intensive timetables not to book the room, got %d", len(intensive))
+	}
+}
@@ -0,0 +1,255 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// SavedSearchRepository provides SQLite backed storage of saved searches, their last results and the
+// notification inbox. Queries and changes are stored as JSON.
+type SavedSearchRepository struct {
+	db *sql.DB
+}
+
+// NewSavedSearchRepository creates a saved search repository for the provided database handle.
+func NewSavedSearchRepository(db *sql.DB) (*SavedSearchRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &SavedSearchRepository{db: db}, nil
+}
+
+// Save stores a search. A search without an id replaces the query of the search with the same name;
+// one with an id is renamed and updated in place. The id and creation time are set on search.
+func (r *SavedSearchRepository) Save(search *domain.SavedSearch) error {
+	if search == nil {
+		return errors.New("nil saved search")
+	}
+	name := strings.TrimSpace(search.Name)
+	if name == "" {
+		return errors.New("saved search name is empty")
+	}
+	query, err := json.Marshal(search.Query)
+	if err != nil {
+		return fmt.Errorf("encode saved search query: %w", err)
+	}
+
+	if search.ID != 0 {
+		if _, err := r.db.Exec(`UPDATE saved_searches SET name = ?, query = ? WHERE id = ?`, name, string(query), search.ID); err != nil {
+			return fmt.Errorf("update saved search: %w", err)
+		}
+	} else if _, err := r.db.Exec(`INSERT INTO saved_searches (name, query, created_at) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET query = excluded.query`,
+		name, string(query), time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("insert saved search: %w", err)
+	}
+
+	var createdAt string
+	if err := r.db.QueryRow(`SELECT id, created_at FROM saved_searches WHERE name = ?`, name).Scan(&search.ID, &createdAt); err != nil {
+		return fmt.Errorf("select saved search id: %w", err)
+	}
+	search.Name = name
+	search.CreatedAt = parseStoredTime(createdAt)
+	return nil
+}
+
+// FindAll returns every saved search ordered by name.
+func (r *SavedSearchRepository) FindAll() ([]domain.SavedSearch, error) {
+	rows, err := r.db.Query(`SELECT id, name, query, created_at, IFNULL(checked_at, '') FROM saved_searches ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("select saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	searches := make([]domain.SavedSearch, 0)
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate saved searches: %w", err)
+	}
+	return searches, nil
+}
+
+// FindByID returns a saved search, or nil when it does not exist.
+func (r *SavedSearchRepository) FindByID(id int) (*domain.SavedSearch, error) {
+	row := r.db.QueryRow(`SELECT id, name, query, created_at, IFNULL(checked_at, '') FROM saved_searches WHERE id = ?`, id)
+	search, err := scanSavedSearch(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func scanSavedSearch(row interface{ Scan(dest ...any) error }) (domain.SavedSearch, error) {
+	var search domain.SavedSearch
+	var query, createdAt, checkedAt string
+	if err := row.Scan(&search.ID, &search.Name, &query, &createdAt, &checkedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return search, err
+		}
+		return search, fmt.Errorf("scan saved search: %w", err)
+	}
+	if err := json.Unmarshal([]byte(query), &search.Query); err != nil {
+		return search, fmt.Errorf("decode saved search query %q: %w", search.Name, err)
+	}
+	search.CreatedAt = parseStoredTime(createdAt)
+	search.CheckedAt = parseStoredTime(checkedAt)
+	return search, nil
+}
+
+// Delete removes a saved search together with its results and notifications.
+func (r *SavedSearchRepository) Delete(id int) error {
+	if _, err := r.db.Exec(`DELETE FROM saved_searches WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete saved search: %w", err)
+	}
+	return nil
+}
+
+// FindResults returns the result the search was last checked against.
+func (r *SavedSearchRepository) FindResults(searchID int) ([]domain.SearchResultEntry, error) {
+	rows, err := r.db.Query(`SELECT course_key, lecture_id, code, title, year, timetables
+		FROM saved_search_results
+		WHERE search_id = ?
+		ORDER BY year DESC, title`, searchID)
+	if err != nil {
+		return nil, fmt.Errorf("select saved search results: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.SearchResultEntry, 0)
+	for rows.Next() {
+		var entry domain.SearchResultEntry
+		if err := rows.Scan(&entry.Key, &entry.LectureID, &entry.Code, &entry.Title, &entry.Year, &entry.Timetables); err != nil {
+			return nil, fmt.Errorf("scan saved search result: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate saved search results: %w", err)
+	}
+	return entries, nil
+}
+
+// ReplaceResults stores the result the search was checked against at checkedAt.
+func (r *SavedSearchRepository) ReplaceResults(searchID int, entries []domain.SearchResultEntry, checkedAt time.Time) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(`DELETE FROM saved_search_results WHERE search_id = ?`, searchID); err != nil {
+		return fmt.Errorf("delete saved search results: %w", err)
+	}
+	stmt, err := tx.Prepare(`INSERT INTO saved_search_results (search_id, course_key, lecture_id, code, title, year, timetables)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(search_id, course_key) DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("prepare saved search result insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, entry := range entries {
+		if _, err = stmt.Exec(searchID, entry.Key, entry.LectureID, entry.Code, entry.Title, entry.Year, entry.Timetables); err != nil {
+			return fmt.Errorf("insert saved search result: %w", err)
+		}
+	}
+	if _, err = tx.Exec(`UPDATE saved_searches SET checked_at = ? WHERE id = ?`, checkedAt.UTC().Format(time.RFC3339), searchID); err != nil {
+		return fmt.Errorf("update saved search checked_at: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit saved search results: %w", err)
+	}
+	return nil
+}
+
+// AddNotification stores a notification in the inbox and sets its id.
+func (r *SavedSearchRepository) AddNotification(notification *domain.SearchNotification) error {
+	if notification == nil {
+		return errors.New("nil notification")
+	}
+	changes, err := json.Marshal(notification.Changes)
+	if err != nil {
+		return fmt.Errorf("encode notification changes: %w", err)
+	}
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.Exec(`INSERT INTO saved_search_notifications (search_id, changes, created_at, read) VALUES (?, ?, ?, ?)`,
+		notification.SearchID, string(changes), notification.CreatedAt.UTC().Format(time.RFC3339), notification.Read)
+	if err != nil {
+		return fmt.Errorf("insert notification: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("read notification id: %w", err)
+	}
+	notification.ID = int(id)
+	return nil
+}
+
+// FindNotifications returns the inbox, newest first.
+func (r *SavedSearchRepository) FindNotifications(unreadOnly bool) ([]domain.SearchNotification, error) {
+	rows, err := r.db.Query(`SELECT n.id, n.search_id, s.name, n.changes, n.created_at, n.read
+		FROM saved_search_notifications n
+		JOIN saved_searches s ON s.id = n.search_id
+		WHERE ? = 0 OR n.read = 0
+		ORDER BY n.created_at DESC, n.id DESC`, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("select notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]domain.SearchNotification, 0)
+	for rows.Next() {
+		var notification domain.SearchNotification
+		var changes, createdAt string
+		if err := rows.Scan(&notification.ID, &notification.SearchID, &notification.SearchName, &changes, &createdAt, &notification.Read); err != nil {
+			return nil, fmt.Errorf("scan notification: %w", err)
+		}
+		if err := json.Unmarshal([]byte(changes), &notification.Changes); err != nil {
+			return nil, fmt.Errorf("decode notification %d: %w", notification.ID, err)
+		}
+		notification.CreatedAt = parseStoredTime(createdAt)
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// MarkNotificationRead marks an inbox item as read.
+func (r *SavedSearchRepository) MarkNotificationRead(id int) error {
+	if _, err := r.db.Exec(`UPDATE saved_search_notifications SET read = 1 WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	return nil
+}
+
+func parseStoredTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
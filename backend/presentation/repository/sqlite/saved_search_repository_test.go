@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestSavedSearchRepositoryRoundTrip(t *testing.T) {
+	_, db := newTestRepository(t)
+	repo, err := NewSavedSearchRepository(db)
+	if err != nil {
+		t.Fatalf("NewSavedSearchRepository returned error: %v", err)
+	}
+
+	query := domain.SearchQuery{
+		Departments: []string{"情報工学系"},
+		Semester:    []domain.Semester{domain.SemesterFall},
+		TimeTables:  []domain.TimeTable{{DayOfWeek: domain.DayOfWeekTuesday, Period: 5}},
+	}
+	search := &domain.SavedSearch{Name: " 火曜午後 ", Query: query}
+	if err := repo.Save(search); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if search.ID == 0 || search.Name != "火曜午後" || search.CreatedAt.IsZero() {
+		t.Fatalf("expected id, trimmed name and creation time, got %+v", search)
+	}
+
+	query.Year = 2025
+	replaced := &domain.SavedSearch{Name: "火曜午後", Query: query}
+	if err := repo.Save(replaced); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if replaced.ID != search.ID {
+		t.Errorf("expected a save by name to replace the search, got ids %d and %d", search.ID, replaced.ID)
+	}
+
+	stored, err := repo.FindByID(search.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("FindByID returned %+v, %v", stored, err)
+	}
+	if stored.Query.Year != 2025 || stored.Query.Departments[0] != "情報工学系" || stored.Query.TimeTables[0].Period != 5 {
+		t.Errorf("unexpected stored query: %+v", stored.Query)
+	}
+
+	entries := []domain.SearchResultEntry{{Key: "k", LectureID: 1, Code: "CSC.T351", Title: "情報理論", Year: 2025, Timetables: "3Q Tue 5"}}
+	if err := repo.ReplaceResults(search.ID, entries, time.Now()); err != nil {
+		t.Fatalf("ReplaceResults returned error: %v", err)
+	}
+	results, err := repo.FindResults(search.ID)
+	if err != nil || len(results) != 1 || results[0] != entries[0] {
+		t.Errorf("FindResults returned %+v, %v", results, err)
+	}
+
+	notification := &domain.SearchNotification{SearchID: search.ID, Changes: []domain.SearchChange{{Kind: domain.SearchChangeAdded, LectureID: 1, Title: "情報理論"}}}
+	if err := repo.AddNotification(notification); err != nil {
+		t.Fatalf("AddNotification returned error: %v", err)
+	}
+	inbox, err := repo.FindNotifications(true)
+	if err != nil || len(inbox) != 1 || inbox[0].SearchName != "火曜午後" || inbox[0].Changes[0].Kind != domain.SearchChangeAdded {
+		t.Fatalf("FindNotifications returned %+v, %v", inbox, err)
+	}
+	if err := repo.MarkNotificationRead(notification.ID); err != nil {
+		t.Fatalf("MarkNotificationRead returned error: %v", err)
+	}
+	if unread, err := repo.FindNotifications(true); err != nil || len(unread) != 0 {
+		t.Errorf("expected no unread notifications, got %+v, %v", unread, err)
+	}
+
+	if err := repo.Delete(search.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if all, err := repo.FindNotifications(false); err != nil || len(all) != 0 {
+		t.Errorf("expected notifications to be deleted with the search, got %+v, %v", all, err)
+	}
+}
@@ -1,7 +1,9 @@
 package sqlite
 
 import (
+	"database/sql"
 	_ "embed"
+	"fmt"
 	"strings"
 )
 
@@ -20,3 +22,89 @@ func schemaStatements() []string {
 	}
 	return statements
 }
+
+// schemaColumn describes a column that was added after a table was first released.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so such columns are added explicitly.
+type schemaColumn struct {
+	table      string
+	name       string
+	definition string
+}
+
+var addedColumns = []schemaColumn{
+	{table: "lecture_plans", name: "goal", definition: "TEXT"},
+	{table: "lecture_plans", name: "date", definition: "TEXT"},
+	{table: "lecture_plans", name: "kind", definition: "TEXT"},
+	{table: "lecture_plans", name: "exam", definition: "TEXT"},
+	{table: "timetables", name: "kind", definition: "TEXT"},
+	{table: "timetables", name: "start_date", definition: "TEXT"},
+	{table: "timetables", name: "end_date", definition: "TEXT"},
+	{table: "rooms", name: "building", definition: "TEXT"},
+	{table: "rooms", name: "campus", definition: "TEXT"},
+	{table: "rooms", name: "number", definition: "TEXT"},
+	{table: "rooms", name: "virtual", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: "lectures", name: "english_department", definition: "TEXT"},
+	{table: "lectures", name: "english_abstract", definition: "TEXT"},
+	{table: "lectures", name: "english_goal", definition: "TEXT"},
+	{table: "lectures", name: "english_flow", definition: "TEXT"},
+	{table: "lectures", name: "english_assessment", definition: "TEXT"},
+	{table: "lectures", name: "references_extracted", definition: "INTEGER NOT NULL DEFAULT 0"},
+	{table: "course_references", name: "resolved", definition: "INTEGER NOT NULL DEFAULT 0"},
+}
+
+func ensureAddedColumns(db *sql.DB) error {
+	existing := make(map[string]map[string]struct{})
+	for _, column := range addedColumns {
+		columns, ok := existing[column.table]
+		if !ok {
+			loaded, err := tableColumns(db, column.table)
+			if err != nil {
+				return err
+			}
+			columns = loaded
+			existing[column.table] = columns
+		}
+		if _, ok := columns[column.name]; ok {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition)); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", column.table, column.name, err)
+		}
+		columns[column.name] = struct{}{}
+	}
+	return nil
+}
+
+func tableExists(db *sql.DB, table string) (bool, error) {
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&count); err != nil {
+		return false, fmt.Errorf("check table %s: %w", table, err)
+	}
+	return count > 0, nil
+}
+
+func tableColumns(db *sql.DB, table string) (map[string]struct{}, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, fmt.Errorf("select columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]struct{})
+	for rows.Next() {
+		var (
+			cid          int
+			name, kind   string
+			notNull, pk  int
+			defaultValue sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &kind, &notNull, &defaultValue, &pk); err != nil {
+			return nil, fmt.Errorf("scan column of %s: %w", table, err)
+		}
+		columns[name] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate columns of %s: %w", table, err)
+	}
+	return columns, nil
+}
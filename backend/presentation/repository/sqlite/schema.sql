@@ -24,7 +24,13 @@ CREATE TABLE IF NOT EXISTS lectures (
     contact TEXT,
     office_hours TEXT,
     note TEXT,
-    updated_at TEXT
+    updated_at TEXT,
+    english_department TEXT,
+    english_abstract TEXT,
+    english_goal TEXT,
+    english_flow TEXT,
+    english_assessment TEXT,
+    references_extracted INTEGER NOT NULL DEFAULT 0
 );
 
 CREATE TABLE IF NOT EXISTS teachers (
@@ -43,7 +49,11 @@ CREATE TABLE IF NOT EXISTS lecture_teachers (
 
 CREATE TABLE IF NOT EXISTS rooms (
     id INTEGER PRIMARY KEY,
-    name TEXT NOT NULL UNIQUE
+    name TEXT NOT NULL UNIQUE,
+    building TEXT,
+    campus TEXT,
+    number TEXT,
+    virtual INTEGER NOT NULL DEFAULT 0
 );
 
 CREATE TABLE IF NOT EXISTS timetables (
@@ -53,6 +63,9 @@ CREATE TABLE IF NOT EXISTS timetables (
     room_id INTEGER,
     day_of_week TEXT,
     period INTEGER,
+    kind TEXT,
+    start_date TEXT,
+    end_date TEXT,
     FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE,
     FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE SET NULL
 );
@@ -63,6 +76,10 @@ CREATE TABLE IF NOT EXISTS lecture_plans (
     count INTEGER,
     plan TEXT,
     assignment TEXT,
+    goal TEXT,
+    date TEXT,
+    kind TEXT,
+    exam TEXT,
     FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
 );
 
@@ -73,6 +90,43 @@ CREATE TABLE IF NOT EXISTS lecture_keywords (
     FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS english_lecture_plans (
+    lecture_id INTEGER NOT NULL,
+    count INTEGER,
+    plan TEXT,
+    assignment TEXT,
+    goal TEXT,
+    date TEXT,
+    kind TEXT,
+    exam TEXT,
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS english_keywords (
+    lecture_id INTEGER NOT NULL,
+    keyword TEXT NOT NULL,
+    PRIMARY KEY (lecture_id, keyword),
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS lecture_contacts (
+    lecture_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    value TEXT NOT NULL,
+    teacher_name TEXT,
+    PRIMARY KEY (lecture_id, kind, value),
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS lecture_office_hours (
+    lecture_id INTEGER NOT NULL,
+    day_of_week TEXT NOT NULL,
+    start_time TEXT NOT NULL,
+    end_time TEXT NOT NULL,
+    note TEXT,
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS related_courses (
     lecture_id INTEGER NOT NULL,
     related_lecture_id INTEGER NOT NULL,
@@ -87,3 +141,155 @@ CREATE TABLE IF NOT EXISTS related_course_codes (
     PRIMARY KEY (lecture_id, code),
     FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
 );
+
+CREATE TABLE IF NOT EXISTS course_references (
+    lecture_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    code TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    resolved INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (lecture_id, kind, code, title),
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS course_relations (
+    lecture_id INTEGER NOT NULL,
+    related_lecture_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    PRIMARY KEY (lecture_id, related_lecture_id, kind),
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE CASCADE,
+    FOREIGN KEY (related_lecture_id) REFERENCES lectures(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS research_filter_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    field TEXT NOT NULL,
+    match_type TEXT NOT NULL,
+    pattern TEXT NOT NULL,
+    enabled INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS scrape_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    site_id TEXT NOT NULL,
+    started_at TEXT NOT NULL,
+    finished_at TEXT,
+    lecture_count INTEGER NOT NULL DEFAULT 0,
+    missing_count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS parse_diagnostics (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    code TEXT,
+    title TEXT,
+    kind TEXT NOT NULL,
+    detail TEXT NOT NULL,
+    FOREIGN KEY (run_id) REFERENCES scrape_runs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_parse_diagnostics_run ON parse_diagnostics(run_id);
+
+CREATE TABLE IF NOT EXISTS requirement_sets (
+    name TEXT PRIMARY KEY,
+    source TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS transcript_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    credit INTEGER NOT NULL DEFAULT 0,
+    grade TEXT NOT NULL DEFAULT '',
+    year INTEGER NOT NULL DEFAULT 0,
+    passed INTEGER NOT NULL DEFAULT 0,
+    lecture_id INTEGER,
+    UNIQUE (code, title, year),
+    FOREIGN KEY (lecture_id) REFERENCES lectures(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_bookmarks (
+    university TEXT NOT NULL,
+    code TEXT NOT NULL,
+    title TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (university, code, title, year)
+);
+
+CREATE TABLE IF NOT EXISTS user_tags (
+    university TEXT NOT NULL,
+    code TEXT NOT NULL,
+    title TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (university, code, title, year, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tags_tag ON user_tags(tag);
+
+CREATE TABLE IF NOT EXISTS user_notes (
+    university TEXT NOT NULL,
+    code TEXT NOT NULL,
+    title TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (university, code, title, year)
+);
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    query TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    checked_at TEXT
+);
+
+CREATE TABLE IF NOT EXISTS saved_search_results (
+    search_id INTEGER NOT NULL,
+    course_key TEXT NOT NULL,
+    lecture_id INTEGER NOT NULL,
+    code TEXT NOT NULL,
+    title TEXT NOT NULL,
+    year INTEGER NOT NULL,
+    timetables TEXT NOT NULL,
+    PRIMARY KEY (search_id, course_key),
+    FOREIGN KEY (search_id) REFERENCES saved_searches(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS saved_search_notifications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    search_id INTEGER NOT NULL,
+    changes TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    read INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (search_id) REFERENCES saved_searches(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_search_notifications_read ON saved_search_notifications(read, created_at);
+
+-- no foreign key to lectures: entries of deleted lectures are removed, and counted, by the next refresh
+CREATE TABLE IF NOT EXISTS recommendation_documents (
+    lecture_id INTEGER PRIMARY KEY,
+    source_title TEXT NOT NULL,
+    source_updated_at TEXT NOT NULL,
+    indexed_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS recommendation_terms (
+    lecture_id INTEGER NOT NULL,
+    term TEXT NOT NULL,
+    count INTEGER NOT NULL,
+    PRIMARY KEY (lecture_id, term),
+    FOREIGN KEY (lecture_id) REFERENCES recommendation_documents(lecture_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS refresh_settings (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    site_id TEXT NOT NULL,
+    spec TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
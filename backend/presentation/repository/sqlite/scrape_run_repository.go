@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+const scrapeRunTimeLayout = time.RFC3339
+
+// ScrapeRunRepository provides SQLite backed storage of scrape runs and their parse diagnostics.
+type ScrapeRunRepository struct {
+	db *sql.DB
+}
+
+// NewScrapeRunRepository creates a scrape run repository for the provided database handle.
+func NewScrapeRunRepository(db *sql.DB) (*ScrapeRunRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &ScrapeRunRepository{db: db}, nil
+}
+
+// Create stores a started run and assigns its ID; a zero StartedAt is set to now.
+func (r *ScrapeRunRepository) Create(run *domain.ScrapeRun) error {
+	if run == nil {
+		return errors.New("nil scrape run")
+	}
+	if run.StartedAt.IsZero() {
+		run.StartedAt = time.Now()
+	}
+
+	res, err := r.db.Exec(`INSERT INTO scrape_runs (site_id, started_at) VALUES (?, ?)`, run.SiteID, run.StartedAt.UTC().Format(scrapeRunTimeLayout))
+	if err != nil {
+		return fmt.Errorf("insert scrape run: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("scrape run id: %w", err)
+	}
+	run.ID = int(id)
+	return nil
+}
+
+// Finish stores the counts and diagnostics of a run; a zero FinishedAt is set to now.
+func (r *ScrapeRunRepository) Finish(run *domain.ScrapeRun) error {
+	if run == nil {
+		return errors.New("nil scrape run")
+	}
+	if run.FinishedAt.IsZero() {
+		run.FinishedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	res, err := tx.Exec(`UPDATE scrape_runs SET finished_at = ?, lecture_count = ?, missing_count = ? WHERE id = ?`,
+		run.FinishedAt.UTC().Format(scrapeRunTimeLayout), run.Lectures, run.MissingLectures, run.ID)
+	if err != nil {
+		return fmt.Errorf("update scrape run: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update scrape run: %w", err)
+	}
+	if affected == 0 {
+		err = fmt.Errorf("scrape run %d not found", run.ID)
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM parse_diagnostics WHERE run_id = ?`, run.ID); err != nil {
+		return fmt.Errorf("clear parse diagnostics: %w", err)
+	}
+	stmt, err := tx.Prepare(`INSERT INTO parse_diagnostics (run_id, url, code, title, kind, detail) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("prepare parse diagnostic insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, diagnostic := range run.Diagnostics {
+		for _, issue := range diagnostic.Issues {
+			if _, err = stmt.Exec(run.ID, diagnostic.URL, nullString(diagnostic.Code), nullString(diagnostic.Title), string(issue.Kind), issue.Detail); err != nil {
+				return fmt.Errorf("insert parse diagnostic: %w", err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit scrape run: %w", err)
+	}
+	return nil
+}
+
+// FindRecent returns the latest finished runs of a site, newest first, without diagnostics.
+func (r *ScrapeRunRepository) FindRecent(siteID string, limit int) ([]domain.ScrapeRun, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := r.db.Query(`SELECT id, site_id, started_at, finished_at, lecture_count, missing_count
+		FROM scrape_runs
+		WHERE site_id = ? AND finished_at IS NOT NULL
+		ORDER BY started_at DESC, id DESC
+		LIMIT ?`, siteID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query scrape runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]domain.ScrapeRun, 0)
+	for rows.Next() {
+		run, err := scanScrapeRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scrape runs: %w", err)
+	}
+	return runs, nil
+}
+
+// FindByID returns a run with its diagnostics grouped by page, or nil when it does not exist.
+func (r *ScrapeRunRepository) FindByID(id int) (*domain.ScrapeRun, error) {
+	row := r.db.QueryRow(`SELECT id, site_id, started_at, finished_at, lecture_count, missing_count FROM scrape_runs WHERE id = ?`, id)
+	run, err := scanScrapeRun(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT url, code, title, kind, detail FROM parse_diagnostics WHERE run_id = ? ORDER BY id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("query parse diagnostics: %w", err)
+	}
+	defer rows.Close()
+
+	index := make(map[string]int)
+	for rows.Next() {
+		var url, kind, detail string
+		var code, title sql.NullString
+		if err := rows.Scan(&url, &code, &title, &kind, &detail); err != nil {
+			return nil, fmt.Errorf("scan parse diagnostic: %w", err)
+		}
+		i, ok := index[url]
+		if !ok {
+			i = len(run.Diagnostics)
+			index[url] = i
+			run.Diagnostics = append(run.Diagnostics, domain.ParseDiagnostic{URL: url, Code: code.String, Title: title.String})
+		}
+		run.Diagnostics[i].Issues = append(run.Diagnostics[i].Issues, domain.ParseIssue{Kind: domain.ParseIssueKind(kind), Detail: detail})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate parse diagnostics: %w", err)
+	}
+	return &run, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScrapeRun(row rowScanner) (domain.ScrapeRun, error) {
+	var run domain.ScrapeRun
+	var startedAt string
+	var finishedAt sql.NullString
+	if err := row.Scan(&run.ID, &run.SiteID, &startedAt, &finishedAt, &run.Lectures, &run.MissingLectures); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return run, err
+		}
+		return run, fmt.Errorf("scan scrape run: %w", err)
+	}
+	if t, err := time.Parse(scrapeRunTimeLayout, startedAt); err == nil {
+		run.StartedAt = t
+	}
+	if finishedAt.Valid {
+		if t, err := time.Parse(scrapeRunTimeLayout, finishedAt.String); err == nil {
+			run.FinishedAt = t
+		}
+	}
+	return run, nil
+}
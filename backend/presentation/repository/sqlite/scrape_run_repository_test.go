@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestScrapeRunRepositoryStoresDiagnostics(t *testing.T) {
+	_, db := newTestRepository(t)
+	runRepo, err := NewScrapeRunRepository(db)
+	if err != nil {
+		t.Fatalf("NewScrapeRunRepository returned error: %v", err)
+	}
+
+	older := &domain.ScrapeRun{SiteID: "isct", StartedAt: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)}
+	if err := runRepo.Create(older); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	older.Add(domain.ParseDiagnostic{URL: "https://example.com/a"})
+	if err := runRepo.Finish(older); err != nil {
+		t.Fatalf("Finish returned error: %v", err)
+	}
+
+	run := &domain.ScrapeRun{SiteID: "isct", StartedAt: time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)}
+	if err := runRepo.Create(run); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	run.Add(domain.ParseDiagnostic{URL: "https://example.com/b", Code: "MTH.A201", Issues: []domain.ParseIssue{
+		{Kind: domain.ParseIssueMissingField, Detail: "teachers"},
+		{Kind: domain.ParseIssueUnknownSection, Detail: "新しい見出し"},
+	}})
+	run.Add(domain.ParseDiagnostic{URL: "https://example.com/c"})
+
+	unfinished := &domain.ScrapeRun{SiteID: "isct"}
+	if err := runRepo.Create(unfinished); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if err := runRepo.Finish(run); err != nil {
+		t.Fatalf("Finish returned error: %v", err)
+	}
+
+	recent, err := runRepo.FindRecent("isct", 10)
+	if err != nil {
+		t.Fatalf("FindRecent returned error: %v", err)
+	}
+	if len(recent) != 2 || recent[0].ID != run.ID || recent[1].ID != older.ID {
+		t.Fatalf("unexpected recent runs: %+v", recent)
+	}
+	if recent[0].Lectures != 2 || recent[0].MissingLectures != 1 {
+		t.Errorf("unexpected counts: %+v", recent[0])
+	}
+
+	stored, err := runRepo.FindByID(run.ID)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if stored == nil || len(stored.Diagnostics) != 1 {
+		t.Fatalf("unexpected stored run: %+v", stored)
+	}
+	diagnostic := stored.Diagnostics[0]
+	if diagnostic.Code != "MTH.A201" || len(diagnostic.Issues) != 2 || diagnostic.Issues[0].Detail != "teachers" {
+		t.Errorf("unexpected diagnostic: %+v", diagnostic)
+	}
+
+	missing, err := runRepo.FindByID(9999)
+	if err != nil || missing != nil {
+		t.Errorf("expected nil for unknown run, got %+v, %v", missing, err)
+	}
+}
@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// TeacherRepository provides SQLite backed access to teachers.
+type TeacherRepository struct {
+	db *sql.DB
+}
+
+// NewTeacherRepository creates a teacher repository for the provided database handle.
+func NewTeacherRepository(db *sql.DB) (*TeacherRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &TeacherRepository{db: db}, nil
+}
+
+// FindByID retrieves a teacher by identifier.
+func (r *TeacherRepository) FindByID(id int) (*domain.Teacher, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid teacher id: %d", id)
+	}
+
+	var (
+		teacher  domain.Teacher
+		urlValue sql.NullString
+	)
+	err := r.db.QueryRow(`SELECT id, name, url FROM teachers WHERE id = ?`, id).Scan(&teacher.ID, &teacher.Name, &urlValue)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select teacher: %w", err)
+	}
+	teacher.Url = urlValue.String
+
+	return &teacher, nil
+}
+
+// FindByName retrieves teachers whose name matches ignoring half- and full-width spaces.
+func (r *TeacherRepository) FindByName(name string) ([]domain.Teacher, error) {
+	name = compactTeacherName(name)
+	if name == "" {
+		return nil, errors.New("empty teacher name")
+	}
+
+	rows, err := r.db.Query(`SELECT id, name, url FROM teachers WHERE REPLACE(REPLACE(name, ' ', ''), '　', '') = ? ORDER BY id`, name)
+	if err != nil {
+		return nil, fmt.Errorf("select teachers by name: %w", err)
+	}
+	defer rows.Close()
+
+	teachers := make([]domain.Teacher, 0)
+	for rows.Next() {
+		var (
+			teacher  domain.Teacher
+			urlValue sql.NullString
+		)
+		if err := rows.Scan(&teacher.ID, &teacher.Name, &urlValue); err != nil {
+			return nil, fmt.Errorf("scan teacher: %w", err)
+		}
+		teacher.Url = urlValue.String
+		teachers = append(teachers, teacher)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teachers: %w", err)
+	}
+
+	return teachers, nil
+}
+
+// Create inserts a single teacher, reusing an existing row with the same name.
+func (r *TeacherRepository) Create(teacher *domain.Teacher) error {
+	if teacher == nil {
+		return errors.New("nil teacher")
+	}
+
+	copies := []domain.Teacher{*teacher}
+	if err := r.Creates(copies); err != nil {
+		return err
+	}
+	teacher.ID = copies[0].ID
+
+	return nil
+}
+
+// Creates inserts teachers within a single transaction and assigns their identifiers.
+func (r *TeacherRepository) Creates(teachers []domain.Teacher) error {
+	if len(teachers) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin teacher transaction: %w", err)
+	}
+
+	for i := range teachers {
+		name := strings.TrimSpace(teachers[i].Name)
+		if name == "" {
+			tx.Rollback()
+			return errors.New("empty teacher name")
+		}
+		id, err := ensureTeacherTx(tx, name, teachers[i].Url)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		teachers[i].ID = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit teacher transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update overwrites the name and url of an existing teacher.
+func (r *TeacherRepository) Update(teacher *domain.Teacher) error {
+	if teacher == nil {
+		return errors.New("nil teacher")
+	}
+	if teacher.ID <= 0 {
+		return fmt.Errorf("invalid teacher id: %d", teacher.ID)
+	}
+
+	result, err := r.db.Exec(`UPDATE teachers SET name = ?, url = ? WHERE id = ?`, strings.TrimSpace(teacher.Name), nullString(teacher.Url), teacher.ID)
+	if err != nil {
+		return fmt.Errorf("update teacher: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("teacher not found: %d", teacher.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a teacher and, through the foreign key, its lecture assignments.
+func (r *TeacherRepository) Delete(id int) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid teacher id: %d", id)
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM teachers WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete teacher: %w", err)
+	}
+
+	return nil
+}
+
+// ensureTeacherTx returns the teacher with the given name, inserting it when missing and refreshing its url.
+func ensureTeacherTx(tx *sql.Tx, name, url string) (int, error) {
+	var id int
+	err := tx.QueryRow(`SELECT id FROM teachers WHERE name = ?`, name).Scan(&id)
+	if err == nil {
+		if strings.TrimSpace(url) != "" {
+			if _, err := tx.Exec(`UPDATE teachers SET url = ? WHERE id = ?`, url, id); err != nil {
+				return 0, fmt.Errorf("update teacher url: %w", err)
+			}
+		}
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("select teacher: %w", err)
+	}
+
+	result, err := tx.Exec(`INSERT INTO teachers (name, url) VALUES (?, ?)`, name, nullString(url))
+	if err != nil {
+		return 0, fmt.Errorf("insert teacher: %w", err)
+	}
+
+	teacherID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("last insert teacher id: %w", err)
+	}
+
+	return int(teacherID), nil
+}
+
+func compactTeacherName(name string) string {
+	return strings.NewReplacer(" ", "", "　", "").Replace(strings.TrimSpace(name))
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestTeacherRepositoryFindByNameIgnoresSpaces(t *testing.T) {
+	_, db := newTestRepository(t)
+	teacherRepo, err := NewTeacherRepository(db)
+	if err != nil {
+		t.Fatalf("NewTeacherRepository returned error: %v", err)
+	}
+
+	mustExec(t, db, `INSERT INTO teachers (id, name) VALUES (?, ?)`, 1, "山田 太郎")
+	mustExec(t, db, `INSERT INTO teachers (id, name) VALUES (?, ?)`, 2, "山田 花子")
+
+	teachers, err := teacherRepo.FindByName("山田　太郎")
+	if err != nil {
+		t.Fatalf("FindByName returned error: %v", err)
+	}
+	if len(teachers) != 1 || teachers[0].ID != 1 {
+		t.Fatalf("unexpected teachers: %+v", teachers)
+	}
+
+	teacher := &domain.Teacher{Name: "山田 太郎", Url: "https://example.com/yamada"}
+	if err := teacherRepo.Create(teacher); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if teacher.ID != 1 {
+		t.Fatalf("expected existing teacher to be reused, got id %d", teacher.ID)
+	}
+
+	found, err := teacherRepo.FindByID(1)
+	if err != nil {
+		t.Fatalf("FindByID returned error: %v", err)
+	}
+	if found == nil || found.Url != "https://example.com/yamada" {
+		t.Fatalf("expected url to be refreshed, got %+v", found)
+	}
+}
+
+func TestLectureRepositoryFindByTeacher(t *testing.T) {
+	repo, db := newTestRepository(t)
+
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, credit, year) VALUES (?, ?, ?, ?, ?, ?, ?)`, 1, "テスト大学", "線形代数", "理学院", "MTH.A201", 2, 2025)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, credit, year) VALUES (?, ?, ?, ?, ?, ?, ?)`, 2, "テスト大学", "線形代数", "理学院", "MTH.A201", 2, 2024)
+	mustExec(t, db, `INSERT INTO lectures (id, university, title, department, code, credit, year) VALUES (?, ?, ?, ?, ?, ?, ?)`, 3, "テスト大学", "解析学", "理学院", "MTH.A202", 2, 2025)
+
+	mustExec(t, db, `INSERT INTO teachers (id, name) VALUES (?, ?)`, 1, "山田 太郎")
+	mustExec(t, db, `INSERT INTO teachers (id, name) VALUES (?, ?)`, 2, "佐藤 次郎")
+	mustExec(t, db, `INSERT INTO lecture_teachers (lecture_id, teacher_id) VALUES (?, ?)`, 1, 1)
+	mustExec(t, db, `INSERT INTO lecture_teachers (lecture_id, teacher_id) VALUES (?, ?)`, 2, 1)
+	mustExec(t, db, `INSERT INTO lecture_teachers (lecture_id, teacher_id) VALUES (?, ?)`, 3, 2)
+
+	mustExec(t, db, `INSERT INTO rooms (id, name) VALUES (?, ?)`, 10, "W5-104")
+	mustExec(t, db, `INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period) VALUES (?, ?, ?, ?, ?)`, 1, string(domain.SemesterSpring), 10, string(domain.DayOfWeekMonday), int(domain.Period3))
+
+	lectures, err := repo.FindByTeacher(1, 2025)
+	if err != nil {
+		t.Fatalf("FindByTeacher returned error: %v", err)
+	}
+	if len(lectures) != 1 || lectures[0].ID != 1 {
+		t.Fatalf("unexpected lectures: %+v", lectures)
+	}
+	if lectures[0].Credit != 2 {
+		t.Errorf("expected credit to be loaded, got %d", lectures[0].Credit)
+	}
+	if len(lectures[0].Timetables) != 1 || lectures[0].Timetables[0].Room.Name != "W5-104" {
+		t.Errorf("expected timetable with room, got %+v", lectures[0].Timetables)
+	}
+}
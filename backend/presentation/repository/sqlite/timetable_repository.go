@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kavos113/desy/backend/domain"
 )
@@ -33,7 +34,7 @@ func (r *TimetableRepository) FindByLectureID(lectureID int) ([]domain.TimeTable
 		return nil, fmt.Errorf("invalid lecture id: %d", lectureID)
 	}
 
-	rows, err := r.db.Query(`SELECT tt.lecture_id, tt.semester, tt.room_id, r.name, tt.day_of_week, tt.period FROM timetables tt LEFT JOIN rooms r ON r.id = tt.room_id WHERE tt.lecture_id = ? ORDER BY tt.semester, tt.day_of_week, tt.period`, lectureID)
+	rows, err := r.db.Query(`SELECT tt.lecture_id, tt.semester, tt.room_id, r.name, r.building, r.campus, r.number, r.virtual, tt.day_of_week, tt.period, tt.kind, tt.start_date, tt.end_date FROM timetables tt LEFT JOIN rooms r ON r.id = tt.room_id WHERE tt.lecture_id = ? ORDER BY tt.semester, tt.day_of_week, tt.period`, lectureID)
 	if err != nil {
 		return nil, fmt.Errorf("select timetables: %w", err)
 	}
@@ -41,33 +42,9 @@ func (r *TimetableRepository) FindByLectureID(lectureID int) ([]domain.TimeTable
 
 	timetables := make([]domain.TimeTable, 0)
 	for rows.Next() {
-		var (
-			lectureIDValue int
-			semesterValue  sql.NullString
-			roomIDValue    sql.NullInt64
-			roomNameValue  sql.NullString
-			dayValue       sql.NullString
-			periodValue    sql.NullInt64
-		)
-		if err := rows.Scan(&lectureIDValue, &semesterValue, &roomIDValue, &roomNameValue, &dayValue, &periodValue); err != nil {
-			return nil, fmt.Errorf("scan timetable: %w", err)
-		}
-
-		timetable := domain.TimeTable{LectureID: lectureIDValue}
-		if semesterValue.Valid {
-			timetable.Semester = domain.Semester(strings.TrimSpace(semesterValue.String))
-		}
-		if dayValue.Valid {
-			timetable.DayOfWeek = domain.DayOfWeek(strings.TrimSpace(dayValue.String))
-		}
-		if periodValue.Valid {
-			timetable.Period = domain.Period(periodValue.Int64)
-		}
-		if roomIDValue.Valid {
-			timetable.Room.ID = int(roomIDValue.Int64)
-		}
-		if roomNameValue.Valid {
-			timetable.Room.Name = roomNameValue.String
+		timetable, err := scanTimetable(rows)
+		if err != nil {
+			return nil, err
 		}
 		timetables = append(timetables, timetable)
 	}
@@ -107,7 +84,7 @@ func (r *TimetableRepository) Creates(timetables []domain.TimeTable) error {
 
 		roomID := 0
 		if name := strings.TrimSpace(timetables[idx].Room.Name); name != "" {
-			id, err := r.ensureRoomTx(tx, name)
+			id, err := ensureRoomTx(tx, name)
 			if err != nil {
 				tx.Rollback()
 				return err
@@ -116,12 +93,15 @@ func (r *TimetableRepository) Creates(timetables []domain.TimeTable) error {
 			timetables[idx].Room.ID = id
 		}
 
-		if _, err := tx.Exec(`INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period) VALUES (?, ?, ?, ?, ?)`,
+		if _, err := tx.Exec(`INSERT INTO timetables (lecture_id, semester, room_id, day_of_week, period, kind, start_date, end_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 			timetables[idx].LectureID,
 			nullString(string(timetables[idx].Semester)),
 			nullInt(roomID),
 			nullString(string(timetables[idx].DayOfWeek)),
 			nullInt(int(timetables[idx].Period)),
+			nullString(string(timetables[idx].Kind)),
+			nullDate(timetables[idx].StartDate),
+			nullDate(timetables[idx].EndDate),
 		); err != nil {
 			tx.Rollback()
 			return fmt.Errorf("insert timetable: %w", err)
@@ -306,35 +286,58 @@ func (r *TimetableRepository) ExpandTimetableRanges(ctx context.Context) (int, e
 	return inserted, nil
 }
 
-func (r *TimetableRepository) ensureRoomTx(tx *sql.Tx, name string) (int, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return 0, nil
+// scanTimetable reads a timetable row selected together with its room name, kind and date range.
+func scanTimetable(rows *sql.Rows) (domain.TimeTable, error) {
+	var (
+		lectureIDValue     int
+		semesterValue      sql.NullString
+		roomIDValue        sql.NullInt64
+		roomNameValue      sql.NullString
+		dayValue           sql.NullString
+		periodValue        sql.NullInt64
+		kindValue          sql.NullString
+		startDate, endDate sql.NullString
+		building, campus   sql.NullString
+		number             sql.NullString
+		virtual            sql.NullInt64
+	)
+	if err := rows.Scan(&lectureIDValue, &semesterValue, &roomIDValue, &roomNameValue, &building, &campus, &number, &virtual, &dayValue, &periodValue, &kindValue, &startDate, &endDate); err != nil {
+		return domain.TimeTable{}, fmt.Errorf("scan timetable: %w", err)
 	}
 
-	var id int
-	err := tx.QueryRow(`SELECT id FROM rooms WHERE name = ?`, name).Scan(&id)
-	if err == nil {
-		return id, nil
+	timetable := domain.TimeTable{LectureID: lectureIDValue, Kind: domain.TimeTableKindRegular}
+	if semesterValue.Valid {
+		timetable.Semester = domain.Semester(strings.TrimSpace(semesterValue.String))
 	}
-	if !errors.Is(err, sql.ErrNoRows) {
-		return 0, fmt.Errorf("select room: %w", err)
+	if dayValue.Valid {
+		timetable.DayOfWeek = domain.DayOfWeek(strings.TrimSpace(dayValue.String))
 	}
-
-	result, err := tx.Exec(`INSERT OR IGNORE INTO rooms (name) VALUES (?)`, name)
-	if err != nil {
-		return 0, fmt.Errorf("insert room: %w", err)
+	if periodValue.Valid {
+		timetable.Period = domain.Period(periodValue.Int64)
 	}
-
-	id64, err := result.LastInsertId()
-	if err == nil && id64 != 0 {
-		return int(id64), nil
+	if roomIDValue.Valid {
+		timetable.Room.ID = int(roomIDValue.Int64)
 	}
-
-	err = tx.QueryRow(`SELECT id FROM rooms WHERE name = ?`, name).Scan(&id)
-	if err != nil {
-		return 0, fmt.Errorf("select ensured room: %w", err)
+	if roomNameValue.Valid {
+		timetable.Room.Name = roomNameValue.String
+	}
+	timetable.Room.Building = building.String
+	timetable.Room.Campus = domain.Campus(campus.String)
+	timetable.Room.Number = number.String
+	timetable.Room.Virtual = virtual.Valid && virtual.Int64 != 0
+	if kindValue.Valid && strings.TrimSpace(kindValue.String) != "" {
+		timetable.Kind = domain.TimeTableKind(strings.TrimSpace(kindValue.String))
+	}
+	if startDate.Valid {
+		if parsed, err := time.ParseInLocation(lectureDateLayout, startDate.String, time.UTC); err == nil {
+			timetable.StartDate = parsed
+		}
+	}
+	if endDate.Valid {
+		if parsed, err := time.ParseInLocation(lectureDateLayout, endDate.String, time.UTC); err == nil {
+			timetable.EndDate = parsed
+		}
 	}
 
-	return id, nil
+	return timetable, nil
 }
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// TranscriptRepository provides SQLite backed storage of the student's completed courses.
+type TranscriptRepository struct {
+	db *sql.DB
+}
+
+// NewTranscriptRepository creates a transcript repository for the provided database handle.
+func NewTranscriptRepository(db *sql.DB) (*TranscriptRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &TranscriptRepository{db: db}, nil
+}
+
+// Save stores entries, replacing the grade, credit and match of entries with the same code, title and year.
+// It returns the number of entries written.
+func (r *TranscriptRepository) Save(entries []domain.TranscriptEntry) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.Prepare(`INSERT INTO transcript_entries (code, title, credit, grade, year, passed, lecture_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(code, title, year) DO UPDATE SET
+			credit = excluded.credit, grade = excluded.grade, passed = excluded.passed, lecture_id = excluded.lecture_id`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare transcript insert: %w", err)
+	}
+	defer stmt.Close()
+
+	saved := 0
+	for _, entry := range entries {
+		code := strings.ToUpper(strings.TrimSpace(entry.Code))
+		title := strings.TrimSpace(entry.Title)
+		if code == "" && title == "" {
+			continue
+		}
+		if _, err = stmt.Exec(code, title, entry.Credit, strings.TrimSpace(entry.Grade), entry.Year, entry.Passed, nullInt(entry.LectureID)); err != nil {
+			return 0, fmt.Errorf("insert transcript entry: %w", err)
+		}
+		saved++
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transcript: %w", err)
+	}
+	return saved, nil
+}
+
+// FindAll returns every entry ordered by year and code.
+func (r *TranscriptRepository) FindAll() ([]domain.TranscriptEntry, error) {
+	rows, err := r.db.Query(`SELECT id, code, title, credit, grade, year, passed, lecture_id
+		FROM transcript_entries
+		ORDER BY year, code, title`)
+	if err != nil {
+		return nil, fmt.Errorf("select transcript entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.TranscriptEntry, 0)
+	for rows.Next() {
+		var entry domain.TranscriptEntry
+		var lectureID sql.NullInt64
+		if err := rows.Scan(&entry.ID, &entry.Code, &entry.Title, &entry.Credit, &entry.Grade, &entry.Year, &entry.Passed, &lectureID); err != nil {
+			return nil, fmt.Errorf("scan transcript entry: %w", err)
+		}
+		entry.LectureID = int(lectureID.Int64)
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate transcript entries: %w", err)
+	}
+	return entries, nil
+}
+
+// Delete removes one entry.
+func (r *TranscriptRepository) Delete(id int) error {
+	if _, err := r.db.Exec(`DELETE FROM transcript_entries WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete transcript entry: %w", err)
+	}
+	return nil
+}
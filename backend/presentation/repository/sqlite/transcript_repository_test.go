@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestTranscriptRepositorySaveAndExcludePassed(t *testing.T) {
+	lectureRepo, db := newTestRepository(t)
+	lectures := []domain.Lecture{
+		{University: "Test University", Department: "Test Department", Title: "線形代数第一", Code: "LAS.M101", Year: 2025},
+		{University: "Test University", Department: "Test Department", Title: "微分積分第一", Code: "LAS.M102", Year: 2025},
+		{University: "Test University", Department: "Test Department", Title: "哲学A", Code: "LAH.S101", Year: 2025},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	repo, err := NewTranscriptRepository(db)
+	if err != nil {
+		t.Fatalf("NewTranscriptRepository returned error: %v", err)
+	}
+	entries := []domain.TranscriptEntry{
+		// passed in an earlier year, matched by code only
+		{Code: "las.m101", Title: "線形代数第一", Credit: 2, Grade: "80", Year: 2024, Passed: true},
+		{Code: "LAS.M102", Title: "微分積分第一", Credit: 2, Grade: "F", Year: 2024},
+		// no code, matched onto the lecture
+		{Title: "哲学A", Credit: 2, Grade: "A", Year: 2025, Passed: true, LectureID: 3},
+	}
+	if saved, err := repo.Save(entries); err != nil || saved != 3 {
+		t.Fatalf("Save returned %d, %v", saved, err)
+	}
+
+	retake := []domain.TranscriptEntry{{Code: "LAS.M102", Title: "微分積分第一", Credit: 2, Grade: "70", Year: 2024, Passed: true}}
+	if _, err := repo.Save(retake); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	stored, err := repo.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll returned error: %v", err)
+	}
+	if len(stored) != 3 {
+		t.Fatalf("expected the retake to replace the entry, got %+v", stored)
+	}
+	if stored[0].Code != "LAS.M101" || stored[1].Grade != "70" || !stored[1].Passed || stored[2].LectureID != 3 {
+		t.Errorf("unexpected entries: %+v", stored)
+	}
+
+	if err := repo.Delete(stored[1].ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	results, err := lectureRepo.Search(domain.SearchQuery{ExcludePassed: true})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Code != "LAS.M102" {
+		t.Errorf("expected only the unpassed lecture, got %+v", results)
+	}
+}
@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// titles are matched to user data with the same normalization domain.IdentityOf applies in Go
+	sqlite.MustRegisterDeterministicScalarFunction("course_identity_title", 1, sqliteCourseIdentityTitle)
+}
+
+func sqliteCourseIdentityTitle(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return domain.CourseIdentityTitle(v), nil
+	case []byte:
+		return domain.CourseIdentityTitle(string(v)), nil
+	default:
+		return domain.CourseIdentityTitle(fmt.Sprint(v)), nil
+	}
+}
+
+// UserDataRepository provides SQLite backed storage of the user's bookmarks, tags and notes. Rows are
+// keyed on the course identity rather than the lecture id so they survive re-scrapes.
+type UserDataRepository struct {
+	db *sql.DB
+}
+
+// NewUserDataRepository creates a user data repository for the provided database handle.
+func NewUserDataRepository(db *sql.DB) (*UserDataRepository, error) {
+	if db == nil {
+		return nil, errors.New("nil database handle")
+	}
+	return &UserDataRepository{db: db}, nil
+}
+
+// SetBookmark adds or removes the bookmark of a course.
+func (r *UserDataRepository) SetBookmark(course domain.CourseIdentity, bookmarked bool) error {
+	course = normalizeIdentity(course)
+	if !bookmarked {
+		if _, err := r.db.Exec(`DELETE FROM user_bookmarks WHERE university = ? AND code = ? AND title = ? AND year = ?`,
+			course.University, course.Code, course.Title, course.Year); err != nil {
+			return fmt.Errorf("delete bookmark: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := r.db.Exec(`INSERT INTO user_bookmarks (university, code, title, year, created_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(university, code, title, year) DO NOTHING`,
+		course.University, course.Code, course.Title, course.Year, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("insert bookmark: %w", err)
+	}
+	return nil
+}
+
+// IsBookmarked reports whether the course is bookmarked.
+func (r *UserDataRepository) IsBookmarked(course domain.CourseIdentity) (bool, error) {
+	course = normalizeIdentity(course)
+	var bookmarked bool
+	if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM user_bookmarks WHERE university = ? AND code = ? AND title = ? AND year = ?)`,
+		course.University, course.Code, course.Title, course.Year).Scan(&bookmarked); err != nil {
+		return false, fmt.Errorf("select bookmark: %w", err)
+	}
+	return bookmarked, nil
+}
+
+// AddTag gives the course a tag. Empty tags are ignored.
+func (r *UserDataRepository) AddTag(course domain.CourseIdentity, tag string) error {
+	course = normalizeIdentity(course)
+	tag = domain.NormalizeTag(tag)
+	if tag == "" {
+		return nil
+	}
+	if _, err := r.db.Exec(`INSERT INTO user_tags (university, code, title, year, tag) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(university, code, title, year, tag) DO NOTHING`,
+		course.University, course.Code, course.Title, course.Year, tag); err != nil {
+		return fmt.Errorf("insert tag: %w", err)
+	}
+	return nil
+}
+
+// RemoveTag removes a tag from the course.
+func (r *UserDataRepository) RemoveTag(course domain.CourseIdentity, tag string) error {
+	course = normalizeIdentity(course)
+	if _, err := r.db.Exec(`DELETE FROM user_tags WHERE university = ? AND code = ? AND title = ? AND year = ? AND tag = ?`,
+		course.University, course.Code, course.Title, course.Year, domain.NormalizeTag(tag)); err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	return nil
+}
+
+// FindTags returns the tags of the course in alphabetical order.
+func (r *UserDataRepository) FindTags(course domain.CourseIdentity) ([]string, error) {
+	course = normalizeIdentity(course)
+	return r.queryTags(`SELECT tag FROM user_tags WHERE university = ? AND code = ? AND title = ? AND year = ? ORDER BY tag`,
+		course.University, course.Code, course.Title, course.Year)
+}
+
+// ListTags returns every tag in use in alphabetical order.
+func (r *UserDataRepository) ListTags() ([]string, error) {
+	return r.queryTags(`SELECT DISTINCT tag FROM user_tags ORDER BY tag`)
+}
+
+func (r *UserDataRepository) queryTags(query string, args ...any) ([]string, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tags: %w", err)
+	}
+	return tags, nil
+}
+
+// SaveNote replaces the note of the course, deleting it when body is blank.
+func (r *UserDataRepository) SaveNote(course domain.CourseIdentity, body string) error {
+	course = normalizeIdentity(course)
+	if strings.TrimSpace(body) == "" {
+		if _, err := r.db.Exec(`DELETE FROM user_notes WHERE university = ? AND code = ? AND title = ? AND year = ?`,
+			course.University, course.Code, course.Title, course.Year); err != nil {
+			return fmt.Errorf("delete note: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := r.db.Exec(`INSERT INTO user_notes (university, code, title, year, body, updated_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(university, code, title, year) DO UPDATE SET body = excluded.body, updated_at = excluded.updated_at`,
+		course.University, course.Code, course.Title, course.Year, body, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("save note: %w", err)
+	}
+	return nil
+}
+
+// FindNote returns the note of the course, or nil when there is none.
+func (r *UserDataRepository) FindNote(course domain.CourseIdentity) (*domain.CourseNote, error) {
+	course = normalizeIdentity(course)
+	note := domain.CourseNote{Course: course}
+	var updatedAt string
+	err := r.db.QueryRow(`SELECT body, updated_at FROM user_notes WHERE university = ? AND code = ? AND title = ? AND year = ?`,
+		course.University, course.Code, course.Title, course.Year).Scan(&note.Body, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select note: %w", err)
+	}
+	note.UpdatedAt = parseStoredTime(updatedAt)
+	return &note, nil
+}
+
+// normalizeIdentity applies the identity rules to identities built by hand.
+func normalizeIdentity(course domain.CourseIdentity) domain.CourseIdentity {
+	return domain.IdentityOf(course.University, course.Code, course.Title, course.Year)
+}
+
+// userDataMatch returns a condition matching rows of a user data table aliased as alias to the lecture l.
+// The title goes through course_identity_title, the same normalization as domain.IdentityOf.
+func userDataMatch(alias string) string {
+	return strings.NewReplacer("@", alias).Replace(`@.university = TRIM(l.university) AND @.year = IFNULL(l.year, 0)` +
+		` AND @.code = UPPER(TRIM(IFNULL(l.code, ''))) AND @.title = course_identity_title(l.title)`)
+}
+
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = domain.NormalizeTag(tag); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestUserDataRepositorySurvivesRescrape(t *testing.T) {
+	lectureRepo, db := newTestRepository(t)
+	lectures := []domain.Lecture{
+		{University: "Test University", Department: "Test Department", Title: "線形代数第一", Code: "LAS.M101", Year: 2025},
+		{University: "Test University", Department: "Test Department", Title: "特別講義", Year: 2025},
+		{University: "Test University", Department: "Test Department", Title: "哲学A", Code: "LAH.S101", Year: 2025},
+		// another section of the same code must not share the user's data
+		{University: "Test University", Department: "Test Department", Title: "線形代数第一 (演習)", Code: "LAS.M101", Year: 2025},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	repo, err := NewUserDataRepository(db)
+	if err != nil {
+		t.Fatalf("NewUserDataRepository returned error: %v", err)
+	}
+	linear := domain.IdentityOf("Test University", "las.m101", "線形代数第一", 2025)
+	special := domain.IdentityOf("Test University", "", "特別講義", 2025)
+	if err := repo.SetBookmark(linear, true); err != nil {
+		t.Fatalf("SetBookmark returned error: %v", err)
+	}
+	if err := repo.SetBookmark(linear, true); err != nil {
+		t.Fatalf("SetBookmark returned error on a second bookmark: %v", err)
+	}
+	for _, tag := range []string{"Math", "required", "math "} {
+		if err := repo.AddTag(linear, tag); err != nil {
+			t.Fatalf("AddTag returned error: %v", err)
+		}
+	}
+	if err := repo.AddTag(special, "required"); err != nil {
+		t.Fatalf("AddTag returned error: %v", err)
+	}
+	if err := repo.SaveNote(linear, "教科書は図書館にある"); err != nil {
+		t.Fatalf("SaveNote returned error: %v", err)
+	}
+
+	// a re-scrape replaces the lecture row and its id, and the page now has "&nbsp;" in the title
+	if _, err := db.Exec(`DELETE FROM lectures WHERE id = 1`); err != nil {
+		t.Fatalf("delete lecture: %v", err)
+	}
+	rescraped := []domain.Lecture{{University: "Test University", Department: "Test Department", Title: "線形代数\u00a0第一", Code: "LAS.M101", Year: 2025}}
+	if err := lectureRepo.Creates(rescraped); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	bookmarked, err := lectureRepo.Search(domain.SearchQuery{BookmarkedOnly: true})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(bookmarked) != 1 || bookmarked[0].Code != "LAS.M101" || bookmarked[0].ID == 1 || !bookmarked[0].Bookmarked {
+		t.Fatalf("expected the re-scraped lecture to stay bookmarked, got %+v", bookmarked)
+	}
+
+	tagged, err := lectureRepo.Search(domain.SearchQuery{Tags: []string{"REQUIRED"}})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	titles := make([]string, 0)
+	for _, summary := range tagged {
+		titles = append(titles, summary.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"特別講義", "線形代数\u00a0第一"}) {
+		t.Errorf("unexpected tagged lectures: %v", titles)
+	}
+
+	tags, err := repo.FindTags(linear)
+	if err != nil {
+		t.Fatalf("FindTags returned error: %v", err)
+	}
+	if !reflect.DeepEqual(tags, []string{"math", "required"}) {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+	if err := repo.RemoveTag(linear, "MATH"); err != nil {
+		t.Fatalf("RemoveTag returned error: %v", err)
+	}
+	if all, err := repo.ListTags(); err != nil || !reflect.DeepEqual(all, []string{"required"}) {
+		t.Errorf("ListTags returned %v, %v", all, err)
+	}
+
+	exercise := domain.IdentityOf("Test University", "LAS.M101", "線形代数第一 (演習)", 2025)
+	if tags, err := repo.FindTags(exercise); err != nil || len(tags) != 0 {
+		t.Errorf("expected no tags on another section, got %v, %v", tags, err)
+	}
+	if note, err := repo.FindNote(exercise); err != nil || note != nil {
+		t.Errorf("expected no note on another section, got %+v, %v", note, err)
+	}
+
+	note, err := repo.FindNote(linear)
+	if err != nil || note == nil || note.Body != "教科書は図書館にある" || note.UpdatedAt.IsZero() {
+		t.Fatalf("FindNote returned %+v, %v", note, err)
+	}
+	if err := repo.SaveNote(linear, "  "); err != nil {
+		t.Fatalf("SaveNote returned error: %v", err)
+	}
+	if note, err := repo.FindNote(linear); err != nil || note != nil {
+		t.Errorf("expected a blank note to be deleted, got %+v, %v", note, err)
+	}
+
+	if err := repo.SetBookmark(linear, false); err != nil {
+		t.Fatalf("SetBookmark returned error: %v", err)
+	}
+	if ok, err := repo.IsBookmarked(linear); err != nil || ok {
+		t.Errorf("IsBookmarked returned %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,121 @@
+package scraper
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// DiagnosingParser is implemented by parsers that can report what they failed to read from a detail page.
+type DiagnosingParser interface {
+	ParseCourseDetailWithDiagnostics(r io.Reader, detailURL string) (*domain.Lecture, domain.ParseDiagnostic, error)
+}
+
+// requiredDetailFields are the lecture fields every syllabus page is expected to fill.
+var requiredDetailFields = []string{"title", "code", "department", "teachers", "credit", "year", "quarter"}
+
+func (p htmlParser) ParseCourseDetailWithDiagnostics(r io.Reader, detailURL string) (*domain.Lecture, domain.ParseDiagnostic, error) {
+	return p.config.parseCourseDetailWithDiagnostics(r, detailURL)
+}
+
+func (c *SelectorConfig) parseCourseDetailWithDiagnostics(r io.Reader, detailURL string) (*domain.Lecture, domain.ParseDiagnostic, error) {
+	if r == nil {
+		return nil, domain.ParseDiagnostic{}, fmt.Errorf("nil reader provided")
+	}
+
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, domain.ParseDiagnostic{}, fmt.Errorf("parse course detail html: %w", err)
+	}
+
+	lecture := c.courseDetail(doc, detailURL)
+	return lecture, c.diagnose(doc, lecture), nil
+}
+
+// diagnose lists missing required fields, headings absent from the configuration and timetable lines that produced nothing.
+func (c *SelectorConfig) diagnose(doc *goquery.Document, lecture *domain.Lecture) domain.ParseDiagnostic {
+	diagnostic := domain.ParseDiagnostic{URL: lecture.Url, Code: lecture.Code, Title: lecture.Title}
+	seen := make(map[domain.ParseIssue]struct{})
+	add := func(kind domain.ParseIssueKind, detail string) {
+		issue := domain.ParseIssue{Kind: kind, Detail: detail}
+		if _, ok := seen[issue]; ok {
+			return
+		}
+		seen[issue] = struct{}{}
+		diagnostic.Issues = append(diagnostic.Issues, issue)
+	}
+
+	for _, field := range requiredDetailFields {
+		if c.missingField(doc, lecture, field) {
+			add(domain.ParseIssueMissingField, field)
+		}
+	}
+
+	c.first(doc, c.Detail.DefinitionItem).Each(func(_ int, item *goquery.Selection) {
+		term := normalizeWhitespace(item.Find(c.Detail.DefinitionTerm).First().Text())
+		if term != "" && !c.knownTerm(term) {
+			add(domain.ParseIssueUnknownTerm, term)
+		}
+	})
+
+	c.first(doc, c.Detail.SectionHeading).Each(func(_ int, heading *goquery.Selection) {
+		text := strings.TrimSpace(heading.Text())
+		if text != "" && !c.knownSection(text) {
+			add(domain.ParseIssueUnknownSection, text)
+		}
+	})
+
+	for _, line := range unparsableTimetableLines(c.definitionRaw(doc, "timetable")) {
+		add(domain.ParseIssueUnparsableTimetable, line)
+	}
+
+	return diagnostic
+}
+
+func (c *SelectorConfig) missingField(doc *goquery.Document, lecture *domain.Lecture, field string) bool {
+	switch field {
+	case "title":
+		return lecture.Title == ""
+	case "code":
+		return lecture.Code == ""
+	case "department":
+		return lecture.Department == ""
+	case "teachers":
+		return len(lecture.Teachers) == 0
+	case "credit":
+		return lecture.Credit == 0
+	case "year":
+		return lecture.Year == 0
+	default:
+		return c.definition(doc, field) == ""
+	}
+}
+
+func (c *SelectorConfig) knownTerm(term string) bool {
+	if containsString(c.IgnoredTerms, term) {
+		return true
+	}
+	for _, aliases := range c.Definitions {
+		for _, alias := range aliases {
+			if term == alias || strings.Contains(term, alias) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *SelectorConfig) knownSection(heading string) bool {
+	if containsString(c.IgnoredSections, heading) {
+		return true
+	}
+	for _, aliases := range c.Sections {
+		if containsString(aliases, heading) {
+			return true
+		}
+	}
+	return false
+}
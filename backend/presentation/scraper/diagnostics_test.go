@@ -0,0 +1,62 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestParseCourseDetailWithDiagnostics(t *testing.T) {
+	page := `<html><body>
+<h1 class="c-h1">代数学</h1>
+<div class="c-dl-2col__item"><dt>開講元</dt><dd>数学系</dd></div>
+<div class="c-dl-2col__item"><dt>科目コード</dt><dd>MTH.A201</dd></div>
+<div class="c-dl-2col__item"><dt>クラス</dt><dd>-</dd></div>
+<div class="c-dl-2col__item"><dt>授業の形式</dt><dd>講義</dd></div>
+<div class="c-dl-2col__item"><dt>曜日・時限(講義室)</dt><dd>月1-2 (M-101)<br>集中講義等<br>要相談</dd></div>
+<h3 class="c-h3">到達目標</h3><p>群論</p>
+<h3 class="c-h3">授業計画・課題</h3><p>-</p>
+<h3 class="c-h3">新しい見出し</h3><p>-</p>
+</body></html>`
+
+	parser, ok := NewParser().(DiagnosingParser)
+	if !ok {
+		t.Fatalf("default parser does not report diagnostics")
+	}
+	lecture, diagnostic, err := parser.ParseCourseDetailWithDiagnostics(strings.NewReader(page), "https://example.com/a")
+	if err != nil {
+		t.Fatalf("ParseCourseDetailWithDiagnostics returned error: %v", err)
+	}
+	if lecture.Code != "MTH.A201" || diagnostic.Code != "MTH.A201" || diagnostic.URL != "https://example.com/a" {
+		t.Fatalf("unexpected lecture or diagnostic: %+v %+v", lecture, diagnostic)
+	}
+
+	got := make(map[domain.ParseIssue]bool)
+	for _, issue := range diagnostic.Issues {
+		got[issue] = true
+	}
+	want := []domain.ParseIssue{
+		{Kind: domain.ParseIssueMissingField, Detail: "teachers"},
+		{Kind: domain.ParseIssueMissingField, Detail: "credit"},
+		{Kind: domain.ParseIssueMissingField, Detail: "quarter"},
+		{Kind: domain.ParseIssueUnknownTerm, Detail: "授業の形式"},
+		{Kind: domain.ParseIssueUnknownSection, Detail: "新しい見出し"},
+		{Kind: domain.ParseIssueUnparsableTimetable, Detail: "要相談"},
+	}
+	for _, issue := range want {
+		if !got[issue] {
+			t.Errorf("missing issue %+v in %+v", issue, diagnostic.Issues)
+		}
+	}
+	for _, issue := range []domain.ParseIssue{
+		{Kind: domain.ParseIssueMissingField, Detail: "title"},
+		{Kind: domain.ParseIssueMissingField, Detail: "code"},
+		{Kind: domain.ParseIssueUnknownTerm, Detail: "クラス"},
+		{Kind: domain.ParseIssueUnknownSection, Detail: "授業計画・課題"},
+	} {
+		if got[issue] {
+			t.Errorf("unexpected issue %+v", issue)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package scraper
 
 import (
+	"errors"
 	"io"
 
 	"github.com/kavos113/desy/backend/domain"
@@ -11,28 +12,42 @@ type Parser interface {
 	ParseCourseList(r io.Reader, base string) ([]CourseListItem, error)
 	ParseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error)
 	ListCoursesPagesURL(r io.Reader, year int) ([]string, error)
-	AddEnglishTitle(r io.Reader, lecture *domain.Lecture) error
+	// AddEnglishDetails fills the English fields of lecture from the English version of its detail page.
+	AddEnglishDetails(r io.Reader, lecture *domain.Lecture) error
 }
 
 // NewParser returns the default parser implementation.
 func NewParser() Parser {
-	return htmlParser{}
+	return htmlParser{config: defaultSelectors}
 }
 
-type htmlParser struct{}
+// NewParserWithConfig returns a parser reading pages through the given selector configuration.
+func NewParserWithConfig(config *SelectorConfig) (Parser, error) {
+	if config == nil {
+		return nil, errors.New("nil selector config")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return htmlParser{config: config}, nil
+}
+
+type htmlParser struct {
+	config *SelectorConfig
+}
 
-func (htmlParser) ParseCourseList(r io.Reader, base string) ([]CourseListItem, error) {
-	return ParseCourseList(r, base)
+func (p htmlParser) ParseCourseList(r io.Reader, base string) ([]CourseListItem, error) {
+	return p.config.parseCourseList(r, base)
 }
 
-func (htmlParser) ParseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error) {
-	return ParseCourseDetail(r, detailURL)
+func (p htmlParser) ParseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error) {
+	return p.config.parseCourseDetail(r, detailURL)
 }
 
 func (htmlParser) ListCoursesPagesURL(r io.Reader, year int) ([]string, error) {
 	return ListCoursesPagesURL(r, year)
 }
 
-func (htmlParser) AddEnglishTitle(r io.Reader, lecture *domain.Lecture) error {
-	return AddEnglishTitle(r, lecture)
+func (p htmlParser) AddEnglishDetails(r io.Reader, lecture *domain.Lecture) error {
+	return p.config.addEnglishDetails(r, lecture)
 }
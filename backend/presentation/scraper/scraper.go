@@ -26,6 +26,24 @@ type CourseListItem struct {
 
 // ParseCourseList extracts course metadata and detail links from a course list HTML document.
 func ParseCourseList(r io.Reader, base string) ([]CourseListItem, error) {
+	return defaultSelectors.parseCourseList(r, base)
+}
+
+// ParseCourseDetail scrapes a lecture aggregate from a course detail HTML document.
+func ParseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error) {
+	return defaultSelectors.parseCourseDetail(r, detailURL)
+}
+
+func AddEnglishTitle(r io.Reader, lecture *domain.Lecture) error {
+	return defaultSelectors.addEnglishTitle(r, lecture)
+}
+
+// AddEnglishDetails parses the English version of a course detail page into the English fields of lecture.
+func AddEnglishDetails(r io.Reader, lecture *domain.Lecture) error {
+	return defaultSelectors.addEnglishDetails(r, lecture)
+}
+
+func (c *SelectorConfig) parseCourseList(r io.Reader, base string) ([]CourseListItem, error) {
 	if r == nil {
 		return nil, fmt.Errorf("nil reader provided")
 	}
@@ -43,15 +61,20 @@ func ParseCourseList(r io.Reader, base string) ([]CourseListItem, error) {
 		}
 	}
 
+	return c.courseListItems(doc, resolvedBase), nil
+}
+
+func (c *SelectorConfig) courseListItems(doc *goquery.Document, resolvedBase *url.URL) []CourseListItem {
+	columns := c.List.Columns
 	items := make([]CourseListItem, 0)
-	doc.Find("table.c-table tbody tr").Each(func(_ int, tr *goquery.Selection) {
+	c.first(doc, c.List.Rows).Each(func(_ int, tr *goquery.Selection) {
 		cols := tr.Find("td")
-		if cols.Length() < 2 {
+		if cols.Length() <= columns.Code || cols.Length() <= columns.Title {
 			return
 		}
 
-		code := strings.TrimSpace(cols.Eq(0).Text())
-		link := cols.Eq(1).Find("a")
+		code := strings.TrimSpace(cols.Eq(columns.Code).Text())
+		link := cols.Eq(columns.Title).Find("a")
 		if code == "" || link.Length() == 0 {
 			return
 		}
@@ -75,25 +98,24 @@ func ParseCourseList(r io.Reader, base string) ([]CourseListItem, error) {
 			Title:     title,
 			DetailURL: detail,
 		}
-		if cols.Length() > 4 {
-			openTerm := strings.TrimSpace(selectionToText(cols.Eq(4)))
+		if columns.OpenTerm >= 0 && cols.Length() > columns.OpenTerm {
+			openTerm := strings.TrimSpace(selectionToText(cols.Eq(columns.OpenTerm)))
 			item.OpenTerm = openTerm
 			if year := parseFirstInt(openTerm); year >= 1900 && year <= 2100 {
 				item.Year = year
 			}
 		}
-		if cols.Length() > 5 {
-			item.UpdatedAt = parseSyllabusDate(selectionToText(cols.Eq(5)))
+		if columns.UpdatedAt >= 0 && cols.Length() > columns.UpdatedAt {
+			item.UpdatedAt = parseSyllabusDate(selectionToText(cols.Eq(columns.UpdatedAt)))
 		}
 
 		items = append(items, item)
 	})
 
-	return items, nil
+	return items
 }
 
-// ParseCourseDetail scrapes a lecture aggregate from a course detail HTML document.
-func ParseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error) {
+func (c *SelectorConfig) parseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error) {
 	if r == nil {
 		return nil, fmt.Errorf("nil reader provided")
 	}
@@ -103,49 +125,55 @@ func ParseCourseDetail(r io.Reader, detailURL string) (*domain.Lecture, error) {
 		return nil, fmt.Errorf("parse course detail html: %w", err)
 	}
 
+	return c.courseDetail(doc, detailURL), nil
+}
+
+func (c *SelectorConfig) courseDetail(doc *goquery.Document, detailURL string) *domain.Lecture {
 	lecture := &domain.Lecture{Url: strings.TrimSpace(detailURL)}
-	lecture.University = "東京科学大学"
 
-	lecture.Title = strings.TrimSpace(doc.Find("h1.c-h1").First().Text())
-	lecture.Department = extractDefinition(doc, "開講元")
-	lecture.Teachers = parseTeachers(extractDefinition(doc, "担当教員"))
-	lecture.LectureType = parseLectureType(extractDefinition(doc, "授業形態"))
-	lecture.Code = extractDefinition(doc, "科目コード")
+	lecture.Title = strings.TrimSpace(c.first(doc, c.Detail.Title).First().Text())
+	lecture.Department = c.definition(doc, "department")
+	lecture.Teachers = parseTeachers(c.definition(doc, "teachers"))
+	lecture.LectureType = parseLectureType(c.definition(doc, "lectureType"))
+	lecture.Code = c.definition(doc, "code")
 	lecture.Level = parseLevelFromCode(lecture.Code)
-	lecture.Credit = parseCredit(extractDefinition(doc, "単位数"))
-	lecture.Year = parseFirstInt(extractDefinition(doc, "開講時期"))
-	quarter := extractDefinition(doc, "開講クォーター")
-	lecture.Language = extractDefinition(doc, "使用言語")
-	lecture.UpdatedAt = parseSyllabusDate(extractDefinition(doc, "シラバス更新日"))
+	lecture.Credit = parseCredit(c.definition(doc, "credit"))
+	lecture.Year = parseFirstInt(c.definition(doc, "year"))
+	quarter := c.definition(doc, "quarter")
+	lecture.Language = c.definition(doc, "language")
+	lecture.UpdatedAt = parseSyllabusDate(c.definition(doc, "updatedAt"))
 	if lecture.OpenTerm == "" && lecture.Year != 0 && strings.TrimSpace(quarter) != "" {
 		lecture.OpenTerm = fmt.Sprintf("%d %s", lecture.Year, strings.TrimSpace(quarter))
 	}
 
-	lecture.Abstract = extractSectionText(doc, "授業の目的（ねらい）、概要")
-	lecture.Goal = extractSectionText(doc, "到達目標")
-	lecture.Experience = extractSectionText(doc, "実務経験のある教員等による授業科目等")
-	lecture.Flow = extractSectionText(doc, "授業の進め方")
-	lecture.OutOfClassWork = extractSectionText(doc, "準備学修(事前学修・復習)等についての指示")
-	lecture.Textbook = extractSectionText(doc, "教科書")
-	lecture.ReferenceBook = extractSectionText(doc, "参考書、講義資料等")
-	lecture.Assessment = extractSectionText(doc, "成績評価の方法及び基準")
-	lecture.Prerequisite = extractSectionText(doc, "履修の条件・注意事項")
-	lecture.Contact = extractSectionText(doc, "連絡先 (メール、電話番号) ※”[at]”を”@”(半角)に変換してください。")
-	if lecture.Contact == "" {
-		lecture.Contact = extractSectionText(doc, "連絡先")
-	}
-	lecture.OfficeHours = extractSectionText(doc, "オフィスアワー")
-	lecture.Note = extractSectionText(doc, "その他")
-
-	lecture.Keywords = parseKeywords(extractSectionText(doc, "キーワード"))
-	lecture.RelatedCourseCodes = parseRelatedCourseCodes(doc)
-	lecture.LecturePlans = parseLecturePlans(doc)
-	lecture.Timetables = parseTimetables(extractDefinitionRaw(doc, "曜日・時限"), quarter)
-
-	return lecture, nil
+	lecture.Abstract = c.section(doc, "abstract")
+	lecture.Goal = c.section(doc, "goal")
+	lecture.Experience = c.section(doc, "experience")
+	lecture.Flow = c.section(doc, "flow")
+	lecture.OutOfClassWork = c.section(doc, "outOfClassWork")
+	lecture.Textbook = c.section(doc, "textbook")
+	lecture.ReferenceBook = c.section(doc, "referenceBook")
+	lecture.Assessment = c.section(doc, "assessment")
+	lecture.Prerequisite = c.section(doc, "prerequisite")
+	lecture.Contact = c.section(doc, "contact")
+	lecture.OfficeHours = c.section(doc, "officeHours")
+	lecture.Note = c.section(doc, "note")
+	lecture.Contacts = domain.ParseContacts(lecture.Contact, lecture.Teachers)
+	lecture.OfficeHourSlots = domain.ParseOfficeHours(lecture.OfficeHours)
+
+	lecture.Keywords = parseKeywords(c.section(doc, "keywords"))
+	lecture.RelatedCourseCodes = c.parseRelatedCourseCodes(doc)
+	lecture.CourseReferences = domain.ExtractCourseReferences(lecture.Prerequisite)
+	lecture.LecturePlans = c.parseLecturePlans(doc, lecture.Year)
+	lecture.Timetables = parseTimetables(c.definitionRaw(doc, "timetable"), quarter, lecture.Year)
+	if len(lecture.Timetables) == 0 && lecture.LectureType == domain.LectureTypeOndemand {
+		lecture.Timetables = ondemandTimetables(quarter)
+	}
+
+	return lecture
 }
 
-func AddEnglishTitle(r io.Reader, lecture *domain.Lecture) error {
+func (c *SelectorConfig) addEnglishTitle(r io.Reader, lecture *domain.Lecture) error {
 	if r == nil {
 		return fmt.Errorf("nil reader provided")
 	}
@@ -158,7 +186,7 @@ func AddEnglishTitle(r io.Reader, lecture *domain.Lecture) error {
 		return fmt.Errorf("parse course detail html for english title: %w", err)
 	}
 
-	title := normalizeWhitespace(doc.Find("h1.c-h1").First().Text())
+	title := normalizeWhitespace(c.first(doc, c.Detail.Title).First().Text())
 	if title == "" {
 		return nil
 	}
@@ -166,60 +194,35 @@ func AddEnglishTitle(r io.Reader, lecture *domain.Lecture) error {
 	return nil
 }
 
-func extractDefinition(doc *goquery.Document, term string) string {
-	sel := extractDefinitionSelection(doc, term)
-	if sel == nil || sel.Length() == 0 {
-		return ""
+func (c *SelectorConfig) addEnglishDetails(r io.Reader, lecture *domain.Lecture) error {
+	if r == nil {
+		return fmt.Errorf("nil reader provided")
 	}
-	return normalizeWhitespace(selectionToText(sel))
-}
-
-func extractDefinitionRaw(doc *goquery.Document, term string) string {
-	sel := extractDefinitionSelection(doc, term)
-	if sel == nil || sel.Length() == 0 {
-		return ""
+	if lecture == nil {
+		return fmt.Errorf("nil lecture provided")
 	}
-	html, err := sel.Html()
+
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return normalizeWhitespace(sel.Text())
+		return fmt.Errorf("parse english course detail html: %w", err)
 	}
-	return normalizeWhitespace(strings.ReplaceAll(strings.ReplaceAll(html, "<br>", "\n"), "<br />", "\n"))
-}
 
-func extractDefinitionSelection(doc *goquery.Document, term string) *goquery.Selection {
-	var result *goquery.Selection
-	doc.Find("div.c-dl-2col__item").EachWithBreak(func(_ int, item *goquery.Selection) bool {
-		dt := normalizeWhitespace(item.Find("dt").First().Text())
-		if dt == term || strings.Contains(dt, term) {
-			result = item.Find("dd").First()
-			return false
-		}
-		return true
-	})
-	if result == nil {
-		return nil
+	english := c.courseDetail(doc, lecture.Url)
+	if title := normalizeWhitespace(english.Title); title != "" {
+		lecture.EnglishTitle = title
 	}
-	return result
-}
-
-func extractSectionText(doc *goquery.Document, heading string) string {
-	var text string
-	doc.Find("h3.c-h3").EachWithBreak(func(_ int, h3 *goquery.Selection) bool {
-		if strings.TrimSpace(h3.Text()) == heading {
-			var sb strings.Builder
-			for s := h3.Next(); s.Length() > 0; s = s.Next() {
-				if goquery.NodeName(s) == "h3" {
-					break
-				}
-				sb.WriteString(selectionToText(s))
-				sb.WriteString("\n")
-			}
-			text = normalizeWhitespace(sb.String())
-			return false
-		}
-		return true
-	})
-	return text
+	lecture.EnglishDepartment = english.Department
+	lecture.EnglishAbstract = english.Abstract
+	lecture.EnglishGoal = english.Goal
+	lecture.EnglishFlow = english.Flow
+	lecture.EnglishAssessment = english.Assessment
+	lecture.EnglishKeywords = english.Keywords
+	lecture.EnglishLecturePlans = english.LecturePlans
+	if lecture.Year != 0 && english.Year != lecture.Year {
+		// plan dates without a year are resolved against the Japanese page's academic year
+		lecture.EnglishLecturePlans = c.parseLecturePlans(doc, lecture.Year)
+	}
+	return nil
 }
 
 func selectionToText(sel *goquery.Selection) string {
@@ -419,34 +422,33 @@ func parseKeywords(raw string) []string {
 	return keywords
 }
 
-func parseRelatedCourseCodes(doc *goquery.Document) []string {
+func (c *SelectorConfig) parseRelatedCourseCodes(doc *goquery.Document) []string {
 	if doc == nil {
 		return nil
 	}
+	heading, _ := c.sectionHeading(doc, "relatedCourses")
+	if heading == nil {
+		return nil
+	}
+	name := goquery.NodeName(heading)
 	codes := make([]string, 0)
-	doc.Find("h3.c-h3").EachWithBreak(func(_ int, h3 *goquery.Selection) bool {
-		if strings.TrimSpace(h3.Text()) != "関連する科目" {
-			return true
-		}
-		for s := h3.Next(); s.Length() > 0; s = s.Next() {
-			nodeName := goquery.NodeName(s)
-			if nodeName == "h3" {
-				break
-			}
-			if nodeName != "ul" {
-				continue
-			}
-			s.Find("li").Each(func(_ int, li *goquery.Selection) {
-				text := normalizeWhitespace(selectionToText(li))
-				code := extractCourseCode(text)
-				if code != "" {
-					codes = append(codes, code)
-				}
-			})
+	for s := heading.Next(); s.Length() > 0; s = s.Next() {
+		nodeName := goquery.NodeName(s)
+		if nodeName == name {
 			break
 		}
-		return false
-	})
+		if nodeName != "ul" {
+			continue
+		}
+		s.Find("li").Each(func(_ int, li *goquery.Selection) {
+			text := normalizeWhitespace(selectionToText(li))
+			code := extractCourseCode(text)
+			if code != "" {
+				codes = append(codes, code)
+			}
+		})
+		break
+	}
 	if len(codes) == 0 {
 		return nil
 	}
@@ -502,24 +504,167 @@ func splitList(raw string) []string {
 	return results
 }
 
-func parseLecturePlans(doc *goquery.Document) []domain.LecturePlan {
+type lecturePlanColumns struct {
+	count      int
+	plan       int
+	assignment int
+	goal       int
+	date       int
+}
+
+var defaultLecturePlanColumns = lecturePlanColumns{count: 0, plan: 1, assignment: 2, goal: -1, date: -1}
+
+func (c *SelectorConfig) parseLecturePlans(doc *goquery.Document, year int) []domain.LecturePlan {
 	plans := make([]domain.LecturePlan, 0)
-	doc.Find("table#lecture_plans tbody tr").Each(func(_ int, row *goquery.Selection) {
+	table := c.first(doc, c.Detail.LecturePlanTable)
+	columns := c.detectLecturePlanColumns(table)
+	table.Find("tbody tr").Each(func(_ int, row *goquery.Selection) {
 		cells := row.Find("td")
 		if cells.Length() < 3 {
 			return
 		}
-		count := parseFirstInt(cells.Eq(0).Text())
-		plan := selectionToText(cells.Eq(1))
-		assignment := selectionToText(cells.Eq(2))
+		cell := func(index int) *goquery.Selection {
+			if index < 0 || index >= cells.Length() {
+				return nil
+			}
+			return cells.Eq(index)
+		}
+		count := parseFirstInt(selectionToText(cell(columns.count)))
+		plan := selectionToText(cell(columns.plan))
+		assignment := selectionToText(cell(columns.assignment))
 		if count == 0 && plan == "" && assignment == "" {
 			return
 		}
-		plans = append(plans, domain.LecturePlan{Count: count, Plan: plan, Assignment: assignment})
+		lp := domain.LecturePlan{
+			Count:      count,
+			Plan:       plan,
+			Assignment: assignment,
+			Goal:       selectionToText(cell(columns.goal)),
+			Date:       parsePlanDate(selectionToText(cell(columns.date)), year),
+		}
+		lp.Kind, lp.Exam = classifyLecturePlan(lp.Plan)
+		plans = append(plans, lp)
 	})
 	return plans
 }
 
+// detectLecturePlanColumns maps header cells of the lecture plan table onto column indexes.
+// Tables without a recognizable header fall back to the count, plan, assignment layout.
+func (c *SelectorConfig) detectLecturePlanColumns(table *goquery.Selection) lecturePlanColumns {
+	headers := table.Find("thead tr").First().Find("th, td")
+	if headers.Length() == 0 {
+		return defaultLecturePlanColumns
+	}
+
+	columns := lecturePlanColumns{count: -1, plan: -1, assignment: -1, goal: -1, date: -1}
+	headers.Each(func(index int, th *goquery.Selection) {
+		label := strings.ToLower(normalizeWhitespace(th.Text()))
+		for _, candidate := range c.PlanColumns {
+			if !containsAny(label, candidate.Labels) {
+				continue
+			}
+			switch candidate.Column {
+			case "date":
+				columns.date = index
+			case "goal":
+				columns.goal = index
+			case "assignment":
+				columns.assignment = index
+			case "plan":
+				columns.plan = index
+			case "count":
+				columns.count = index
+			}
+			return
+		}
+	})
+	if columns.plan < 0 {
+		return defaultLecturePlanColumns
+	}
+	if columns.count < 0 && columns.plan != 0 {
+		columns.count = 0
+	}
+	return columns
+}
+
+var planDateRegexp = regexp.MustCompile(`(?:(\d{4})\s*[/年.-]\s*)?(\d{1,2})\s*[/月.-]\s*(\d{1,2})`)
+
+// parsePlanDate parses session dates such as "10/3", "10月3日" or "2025/10/3".
+// Dates without a year are placed in the academic year, so January to March belong to the following calendar year.
+func parsePlanDate(raw string, year int) time.Time {
+	matches := planDateRegexp.FindStringSubmatch(strings.TrimSpace(raw))
+	if len(matches) == 0 {
+		return time.Time{}
+	}
+	month, err := strconv.Atoi(matches[2])
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}
+	}
+	day, err := strconv.Atoi(matches[3])
+	if err != nil || day < 1 || day > 31 {
+		return time.Time{}
+	}
+	if matches[1] != "" {
+		if parsed, err := strconv.Atoi(matches[1]); err == nil {
+			year = parsed
+		}
+	} else if year > 0 && month < 4 {
+		year++
+	}
+	if year <= 0 {
+		return time.Time{}
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Japanese words are matched as substrings; English words must stand alone so that "final project" or
+// "experimental design" is not mistaken for an exam or a lab.
+var (
+	examWords         = []string{"試験", "期末テスト", "中間テスト"}
+	midtermWords      = []string{"中間"}
+	finalWords        = []string{"期末", "定期試験", "最終試験"}
+	presentationWords = []string{"発表", "プレゼン"}
+	labWords          = []string{"実験", "実習"}
+
+	examRegexp         = regexp.MustCompile(`\bexam(?:s|ination|inations)?\b`)
+	midtermRegexp      = regexp.MustCompile(`\bmid-?term\b`)
+	finalRegexp        = regexp.MustCompile(`\bfinal\s+(?:exam(?:s|ination|inations)?|tests?)\b`)
+	presentationRegexp = regexp.MustCompile(`\bpresentations?\b`)
+	labRegexp          = regexp.MustCompile(`\b(?:laborator(?:y|ies)|lab work|experiments?)\b`)
+)
+
+// classifyLecturePlan classifies a lecture plan entry from its plan text. The goal column is left out:
+// goals such as "prepare for the final exam" describe the session, not what happens in it.
+func classifyLecturePlan(text string) (domain.LecturePlanKind, domain.ExamType) {
+	lower := strings.ToLower(text)
+	switch {
+	case containsAny(lower, examWords) || examRegexp.MatchString(lower):
+		switch {
+		case containsAny(lower, midtermWords) || midtermRegexp.MatchString(lower):
+			return domain.LecturePlanKindExam, domain.ExamTypeMidterm
+		case containsAny(lower, finalWords) || finalRegexp.MatchString(lower):
+			return domain.LecturePlanKindExam, domain.ExamTypeFinal
+		default:
+			return domain.LecturePlanKindExam, ""
+		}
+	case containsAny(lower, presentationWords) || presentationRegexp.MatchString(lower):
+		return domain.LecturePlanKindPresentation, ""
+	case containsAny(lower, labWords) || labRegexp.MatchString(lower):
+		return domain.LecturePlanKindLab, ""
+	default:
+		return domain.LecturePlanKindLecture, ""
+	}
+}
+
+func containsAny(text string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
 var dayOfWeekMap = map[rune]domain.DayOfWeek{
 	'月': domain.DayOfWeekMonday,
 	'火': domain.DayOfWeekTuesday,
@@ -530,27 +675,19 @@ var dayOfWeekMap = map[rune]domain.DayOfWeek{
 	'日': domain.DayOfWeekSunday,
 }
 
-func parseTimetables(raw, quarter string) []domain.TimeTable {
+func parseTimetables(raw, quarter string, year int) []domain.TimeTable {
 	raw = normalizeWhitespace(raw)
 	if raw == "" || raw == "-" {
 		return nil
 	}
-	semesters := domain.FromQuarter(quarter)
-	if len(semesters) == 0 {
-		trimmed := strings.TrimSpace(quarter)
-		if trimmed != "" {
-			semesters = []domain.Semester{domain.Semester(trimmed)}
-		}
-	}
-	if len(semesters) == 0 {
-		semesters = []domain.Semester{""}
-	}
+	semesters := timetableSemesters(quarter)
 	entries := splitLines(raw)
 	timetables := make([]domain.TimeTable, 0, len(entries)*len(semesters))
 	for _, entry := range entries {
 		if entry == "" {
 			continue
 		}
+		original := entry
 		var roomName string
 		if idx := strings.Index(entry, "("); idx != -1 {
 			if end := strings.LastIndex(entry, ")"); end > idx {
@@ -561,6 +698,20 @@ func parseTimetables(raw, quarter string) []domain.TimeTable {
 		if entry == "" {
 			continue
 		}
+		if kind, ok := parseTimetableKind(entry); ok {
+			start, end := parseDateRange(original, year)
+			if dateRangeRegexp.MatchString(roomName) || strings.ContainsAny(roomName, "()（）") {
+				roomName = ""
+			}
+			for _, semester := range semesters {
+				tt := domain.TimeTable{Kind: kind, Semester: semester, StartDate: start, EndDate: end}
+				if roomName != "" {
+					tt.Room.Name = roomName
+				}
+				timetables = append(timetables, tt)
+			}
+			continue
+		}
 		dayRune := []rune(entry)[0]
 		day, ok := dayOfWeekMap[dayRune]
 		if !ok {
@@ -570,7 +721,7 @@ func parseTimetables(raw, quarter string) []domain.TimeTable {
 		matches := periodRangeRegexp.FindAllStringSubmatch(periodPart, -1)
 		if len(matches) == 0 {
 			for _, semester := range semesters {
-				tt := domain.TimeTable{Semester: semester, DayOfWeek: day}
+				tt := domain.TimeTable{Kind: domain.TimeTableKindRegular, Semester: semester, DayOfWeek: day}
 				if roomName != "" {
 					tt.Room.Name = roomName
 				}
@@ -594,7 +745,7 @@ func parseTimetables(raw, quarter string) []domain.TimeTable {
 			}
 			for p := start; p <= end; p++ {
 				for _, semester := range semesters {
-					tt := domain.TimeTable{Semester: semester, DayOfWeek: day, Period: domain.Period(p)}
+					tt := domain.TimeTable{Kind: domain.TimeTableKindRegular, Semester: semester, DayOfWeek: day, Period: domain.Period(p)}
 					if roomName != "" {
 						tt.Room.Name = roomName
 					}
@@ -606,6 +757,108 @@ func parseTimetables(raw, quarter string) []domain.TimeTable {
 	return timetables
 }
 
+// unparsableTimetableLines returns the timetable entries parseTimetables skips because they name neither a weekday nor a known kind.
+func unparsableTimetableLines(raw string) []string {
+	raw = normalizeWhitespace(raw)
+	if raw == "" || raw == "-" {
+		return nil
+	}
+	lines := make([]string, 0)
+	for _, entry := range splitLines(raw) {
+		original := entry
+		if idx := strings.Index(entry, "("); idx != -1 {
+			if end := strings.LastIndex(entry, ")"); end > idx {
+				entry = strings.TrimSpace(entry[:idx])
+			}
+		}
+		if entry == "" {
+			continue
+		}
+		if _, ok := parseTimetableKind(entry); ok {
+			continue
+		}
+		if _, ok := dayOfWeekMap[[]rune(entry)[0]]; ok {
+			continue
+		}
+		lines = append(lines, original)
+	}
+	return lines
+}
+
+func timetableSemesters(quarter string) []domain.Semester {
+	semesters := domain.FromQuarter(quarter)
+	if len(semesters) == 0 {
+		trimmed := strings.TrimSpace(quarter)
+		if trimmed != "" {
+			semesters = []domain.Semester{domain.Semester(trimmed)}
+		}
+	}
+	if len(semesters) == 0 {
+		semesters = []domain.Semester{""}
+	}
+	return semesters
+}
+
+// ondemandTimetables models an on-demand lecture that lists no weekly slot as one entry per semester.
+func ondemandTimetables(quarter string) []domain.TimeTable {
+	semesters := timetableSemesters(quarter)
+	timetables := make([]domain.TimeTable, 0, len(semesters))
+	for _, semester := range semesters {
+		timetables = append(timetables, domain.TimeTable{Kind: domain.TimeTableKindOndemand, Semester: semester})
+	}
+	return timetables
+}
+
+// Like the lecture plan words, English timetable words must stand alone so that a room such as
+// "Football field" is not read as "TBA".
+var (
+	intensiveRegexp = regexp.MustCompile(`\bintensive\b`)
+	ondemandRegexp  = regexp.MustCompile(`\bon-?demand\b`)
+	tbaRegexp       = regexp.MustCompile(`\b(?:tba|to be announced)\b`)
+)
+
+// parseTimetableKind recognizes timetable entries that do not describe a weekly slot.
+func parseTimetableKind(entry string) (domain.TimeTableKind, bool) {
+	lower := strings.ToLower(entry)
+	switch {
+	case strings.Contains(lower, "集中") || intensiveRegexp.MatchString(lower):
+		return domain.TimeTableKindIntensive, true
+	case strings.Contains(lower, "オンデマンド") || ondemandRegexp.MatchString(lower):
+		return domain.TimeTableKindOndemand, true
+	case strings.Contains(lower, "未定") || tbaRegexp.MatchString(lower):
+		return domain.TimeTableKindTBA, true
+	default:
+		return "", false
+	}
+}
+
+var dateRangeRegexp = regexp.MustCompile(`(\d{1,2})\s*[/月]\s*(\d{1,2})\s*日?(?:\s*[(（][^)）]*[)）])?\s*(?:[-－〜～~–—]\s*(?:(\d{1,2})\s*[/月]\s*)?(\d{1,2})\s*日?)?`)
+
+// parseDateRange extracts a date range such as "8/5-8/9" or "8月5日～9日" from a timetable entry.
+// A single date yields the same start and end.
+func parseDateRange(raw string, year int) (time.Time, time.Time) {
+	matches := dateRangeRegexp.FindStringSubmatch(raw)
+	if len(matches) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	start := parsePlanDate(matches[1]+"/"+matches[2], year)
+	if start.IsZero() {
+		return time.Time{}, time.Time{}
+	}
+	if matches[4] == "" {
+		return start, start
+	}
+	endMonth := matches[3]
+	if endMonth == "" {
+		endMonth = matches[1]
+	}
+	end := parsePlanDate(endMonth+"/"+matches[4], year)
+	if end.IsZero() || end.Before(start) {
+		return start, start
+	}
+	return start, end
+}
+
 func splitLines(raw string) []string {
 	if raw == "" {
 		return nil
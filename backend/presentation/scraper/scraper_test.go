@@ -4,7 +4,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/kavos113/desy/backend/domain"
 )
 
@@ -88,7 +90,6 @@ func TestParseCourseDetail(t *testing.T) {
 			detailURL: "https://example.com/courses/2025/LAH.S101",
 			expected: domain.Lecture{
 				ID:             1,
-				University:     "東京科学大学",
 				Title:          "法学（憲法）Ａ",
 				Department:     "文系教養科目",
 				LectureType:    domain.LectureTypeOffline,
@@ -158,7 +159,6 @@ func TestParseCourseDetail(t *testing.T) {
 			detailURL: "https://example.com/courses/2025/LAH.S101",
 			expected: domain.Lecture{
 				ID:             1,
-				University:     "東京科学大学",
 				Title:          "法学（憲法）Ａ",
 				Department:     "文系教養科目",
 				LectureType:    domain.LectureTypeOffline,
@@ -228,7 +228,6 @@ func TestParseCourseDetail(t *testing.T) {
 			detailURL: "https://example.com/courses/2025/LAH.S101",
 			expected: domain.Lecture{
 				ID:             1,
-				University:     "東京科学大学",
 				Title:          "法学（憲法）Ａ",
 				Department:     "文系教養科目",
 				LectureType:    domain.LectureTypeOffline,
@@ -514,7 +513,7 @@ func TestParseTimetablesRangeExpansion(t *testing.T) {
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			got := parseTimetables(tc.raw, "3Q")
+			got := parseTimetables(tc.raw, "3Q", 2025)
 			if len(got) != len(expectedPeriods) {
 				t.Fatalf("unexpected timetable count: got %d, want %d", len(got), len(expectedPeriods))
 			}
@@ -532,3 +531,173 @@ func TestParseTimetablesRangeExpansion(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLecturePlansWithDatesAndGoals(t *testing.T) {
+	const page = `
+<html><body>
+<table id="lecture_plans">
+  <thead><tr><th></th><th>日付</th><th>授業計画</th><th>課題</th><th>目標</th></tr></thead>
+  <tbody>
+    <tr><td>第1回</td><td>10/3</td><td>ガイダンス</td><td>予習</td><td>概要を理解する</td></tr>
+    <tr><td>第2回</td><td>1月20日</td><td>期末試験</td><td></td><td>総合的な理解</td></tr>
+    <tr><td>第3回</td><td></td><td>成果発表会</td><td>スライド作成</td><td></td></tr>
+    <tr><td>第4回</td><td></td><td>演習</td><td></td><td>期末試験に備える</td></tr>
+  </tbody>
+</table>
+</body></html>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("parse html: %v", err)
+	}
+
+	plans := defaultSelectors.parseLecturePlans(doc, 2025)
+	if len(plans) != 4 {
+		t.Fatalf("unexpected number of lecture plans: got %d, want 4", len(plans))
+	}
+
+	first := plans[0]
+	if first.Count != 1 || first.Plan != "ガイダンス" || first.Assignment != "予習" || first.Goal != "概要を理解する" {
+		t.Errorf("unexpected first plan: %+v", first)
+	}
+	if !first.Date.Equal(time.Date(2025, time.October, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected first date: %v", first.Date)
+	}
+	if first.Kind != domain.LecturePlanKindLecture {
+		t.Errorf("unexpected first kind: %s", first.Kind)
+	}
+
+	exam := plans[1]
+	if !exam.Date.Equal(time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected exam date: %v", exam.Date)
+	}
+	if exam.Kind != domain.LecturePlanKindExam || exam.Exam != domain.ExamTypeFinal {
+		t.Errorf("unexpected exam classification: %s %s", exam.Kind, exam.Exam)
+	}
+
+	presentation := plans[2]
+	if !presentation.Date.IsZero() {
+		t.Errorf("expected empty date, got %v", presentation.Date)
+	}
+	if presentation.Kind != domain.LecturePlanKindPresentation {
+		t.Errorf("unexpected presentation kind: %s", presentation.Kind)
+	}
+
+	// the goal mentions the exam, but the session itself is an exercise
+	if exercise := plans[3]; exercise.Kind != domain.LecturePlanKindLecture || exercise.Exam != "" {
+		t.Errorf("unexpected exercise classification: %s %s", exercise.Kind, exercise.Exam)
+	}
+}
+
+func TestClassifyLecturePlan(t *testing.T) {
+	testCases := []struct {
+		text string
+		kind domain.LecturePlanKind
+		exam domain.ExamType
+	}{
+		{text: "総括と試験", kind: domain.LecturePlanKindExam},
+		{text: "中間試験", kind: domain.LecturePlanKindExam, exam: domain.ExamTypeMidterm},
+		{text: "Final examination", kind: domain.LecturePlanKindExam, exam: domain.ExamTypeFinal},
+		{text: "Worked examples", kind: domain.LecturePlanKindLecture},
+		{text: "グループ発表", kind: domain.LecturePlanKindPresentation},
+		{text: "電子回路の実験", kind: domain.LecturePlanKindLab},
+		{text: "憲法の基本理念", kind: domain.LecturePlanKindLecture},
+		{text: "Mid-term exam", kind: domain.LecturePlanKindExam, exam: domain.ExamTypeMidterm},
+		{text: "Exam review and final remarks", kind: domain.LecturePlanKindExam},
+		{text: "Final project", kind: domain.LecturePlanKindLecture},
+		{text: "Finalizing the report", kind: domain.LecturePlanKindLecture},
+		{text: "Experimental design", kind: domain.LecturePlanKindLecture},
+		{text: "Laboratory safety", kind: domain.LecturePlanKindLab},
+		{text: "Student presentations", kind: domain.LecturePlanKindPresentation},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.text, func(t *testing.T) {
+			kind, exam := classifyLecturePlan(tc.text)
+			if kind != tc.kind || exam != tc.exam {
+				t.Errorf("unexpected classification: got %s/%s, want %s/%s", kind, exam, tc.kind, tc.exam)
+			}
+		})
+	}
+}
+
+func TestParseTimetableKind(t *testing.T) {
+	testCases := []struct {
+		entry string
+		kind  domain.TimeTableKind
+		ok    bool
+	}{
+		{entry: "TBA", kind: domain.TimeTableKindTBA, ok: true},
+		{entry: "Room: to be announced", kind: domain.TimeTableKindTBA, ok: true},
+		{entry: "On-demand", kind: domain.TimeTableKindOndemand, ok: true},
+		{entry: "Ondemand (Zoom)", kind: domain.TimeTableKindOndemand, ok: true},
+		{entry: "Intensive", kind: domain.TimeTableKindIntensive, ok: true},
+		{entry: "Mon1-2 (Football field)"},
+		{entry: "Tue3-4 (Outbatch lab)"},
+	}
+
+	for _, tc := range testCases {
+		kind, ok := parseTimetableKind(tc.entry)
+		if kind != tc.kind || ok != tc.ok {
+			t.Errorf("%s: got (%s, %v), want (%s, %v)", tc.entry, kind, ok, tc.kind, tc.ok)
+		}
+	}
+}
+
+func TestParseTimetablesUnscheduledKinds(t *testing.T) {
+	testCases := []struct {
+		name      string
+		raw       string
+		kind      domain.TimeTableKind
+		room      string
+		startDate time.Time
+		endDate   time.Time
+	}{
+		{name: "intensive", raw: "集中講義等", kind: domain.TimeTableKindIntensive},
+		{
+			name:      "intensive with dates",
+			raw:       "集中講義等 8/5-8/9 (W5-104)",
+			kind:      domain.TimeTableKindIntensive,
+			room:      "W5-104",
+			startDate: time.Date(2025, time.August, 5, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2025, time.August, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "intensive with dates in parentheses",
+			raw:       "集中講義等 (2月10日～12日)",
+			kind:      domain.TimeTableKindIntensive,
+			startDate: time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2026, time.February, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{name: "tba", raw: "未定", kind: domain.TimeTableKindTBA},
+		{name: "ondemand", raw: "オンデマンド", kind: domain.TimeTableKindOndemand},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseTimetables(tc.raw, "3-4Q", 2025)
+			if len(got) != 2 {
+				t.Fatalf("unexpected timetable count: got %d, want 2", len(got))
+			}
+			for i, tt := range got {
+				if tt.Kind != tc.kind {
+					t.Errorf("unexpected kind at index %d: got %s, want %s", i, tt.Kind, tc.kind)
+				}
+				if tt.DayOfWeek != "" || tt.Period != 0 {
+					t.Errorf("unexpected weekly slot at index %d: %s %d", i, tt.DayOfWeek, tt.Period)
+				}
+				if tt.Room.Name != tc.room {
+					t.Errorf("unexpected room at index %d: got %q, want %q", i, tt.Room.Name, tc.room)
+				}
+				if !tt.StartDate.Equal(tc.startDate) || !tt.EndDate.Equal(tc.endDate) {
+					t.Errorf("unexpected date range at index %d: %v - %v", i, tt.StartDate, tt.EndDate)
+				}
+			}
+			if got[0].Semester != domain.SemesterFall || got[1].Semester != domain.SemesterWinter {
+				t.Errorf("unexpected semesters: %s, %s", got[0].Semester, got[1].Semester)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package scraper
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+//go:embed selectors.json
+var embeddedSelectors []byte
+
+// defaultSelectors backs the package level parse functions.
+var defaultSelectors = mustParseSelectorConfig(embeddedSelectors)
+
+// SelectorConfig maps lecture fields onto the CSS selectors and headings of a syllabus page.
+// Every selector list is tried in order, so later entries act as fallbacks,
+// and every heading list holds aliases of the same field.
+type SelectorConfig struct {
+	List        ListSelectors       `json:"list"`
+	Detail      DetailSelectors     `json:"detail"`
+	Definitions map[string][]string `json:"definitions"`
+	Sections    map[string][]string `json:"sections"`
+	// IgnoredTerms and IgnoredSections are headings the parser knows but does not read;
+	// they are left out of parse diagnostics.
+	IgnoredTerms    []string           `json:"ignoredTerms"`
+	IgnoredSections []string           `json:"ignoredSections"`
+	PlanColumns     []PlanColumnLabels `json:"planColumns"`
+}
+
+// ListSelectors locates course rows and their cells on a course list page.
+type ListSelectors struct {
+	Rows    []string    `json:"rows"`
+	Columns ListColumns `json:"columns"`
+}
+
+// ListColumns holds zero-based cell indexes of a course list row; negative indexes are skipped.
+type ListColumns struct {
+	Code      int `json:"code"`
+	Title     int `json:"title"`
+	OpenTerm  int `json:"openTerm"`
+	UpdatedAt int `json:"updatedAt"`
+}
+
+// DetailSelectors locates the building blocks of a course detail page.
+type DetailSelectors struct {
+	Title            []string `json:"title"`
+	DefinitionItem   []string `json:"definitionItem"`
+	DefinitionTerm   string   `json:"definitionTerm"`
+	DefinitionValue  string   `json:"definitionValue"`
+	SectionHeading   []string `json:"sectionHeading"`
+	LecturePlanTable []string `json:"lecturePlanTable"`
+}
+
+// PlanColumnLabels lists header labels identifying a lecture plan column; earlier entries win.
+type PlanColumnLabels struct {
+	Column string   `json:"column"`
+	Labels []string `json:"labels"`
+}
+
+var (
+	definitionFields = []string{"department", "teachers", "lectureType", "code", "credit", "year", "quarter", "language", "updatedAt", "timetable"}
+	sectionFields    = []string{"abstract", "goal", "experience", "flow", "outOfClassWork", "textbook", "referenceBook", "assessment", "prerequisite", "contact", "officeHours", "note", "keywords", "relatedCourses"}
+	planColumnNames  = []string{"date", "goal", "assignment", "plan", "count"}
+)
+
+// DefaultSelectorConfig returns a copy of the embedded configuration.
+func DefaultSelectorConfig() *SelectorConfig {
+	return mustParseSelectorConfig(embeddedSelectors)
+}
+
+// LoadSelectorConfig reads a JSON override from path and applies it on top of the embedded configuration.
+func LoadSelectorConfig(path string) (*SelectorConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open selector config: %w", err)
+	}
+	defer file.Close()
+
+	return ParseSelectorConfig(file)
+}
+
+// ParseSelectorConfig applies a JSON override on top of the embedded configuration.
+// Fields missing from the override, including single definition or section entries, keep their defaults.
+func ParseSelectorConfig(r io.Reader) (*SelectorConfig, error) {
+	config := DefaultSelectorConfig()
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("decode selector config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func mustParseSelectorConfig(raw []byte) *SelectorConfig {
+	var config SelectorConfig
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&config); err != nil {
+		panic(fmt.Errorf("decode embedded selector config: %w", err))
+	}
+	if err := config.Validate(); err != nil {
+		panic(fmt.Errorf("embedded selector config: %w", err))
+	}
+	return &config
+}
+
+// Validate checks that every selector compiles and that only known fields are configured.
+func (c *SelectorConfig) Validate() error {
+	var problems []string
+	checkSelectors := func(name string, selectors []string) {
+		if len(selectors) == 0 {
+			problems = append(problems, name+": no selector")
+			return
+		}
+		for _, selector := range selectors {
+			if _, err := cascadia.Compile(selector); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid selector %q: %v", name, selector, err))
+			}
+		}
+	}
+
+	checkSelectors("list.rows", c.List.Rows)
+	checkSelectors("detail.title", c.Detail.Title)
+	checkSelectors("detail.definitionItem", c.Detail.DefinitionItem)
+	checkSelectors("detail.definitionTerm", []string{c.Detail.DefinitionTerm})
+	checkSelectors("detail.definitionValue", []string{c.Detail.DefinitionValue})
+	checkSelectors("detail.sectionHeading", c.Detail.SectionHeading)
+	checkSelectors("detail.lecturePlanTable", c.Detail.LecturePlanTable)
+
+	if c.List.Columns.Code < 0 || c.List.Columns.Title < 0 {
+		problems = append(problems, "list.columns: code and title are required")
+	}
+
+	problems = append(problems, checkHeadings("definitions", c.Definitions, definitionFields)...)
+	problems = append(problems, checkHeadings("sections", c.Sections, sectionFields)...)
+
+	seen := make(map[string]struct{}, len(c.PlanColumns))
+	for _, column := range c.PlanColumns {
+		if !containsString(planColumnNames, column.Column) {
+			problems = append(problems, fmt.Sprintf("planColumns: unknown column %q", column.Column))
+		}
+		if _, ok := seen[column.Column]; ok {
+			problems = append(problems, fmt.Sprintf("planColumns: duplicate column %q", column.Column))
+		}
+		seen[column.Column] = struct{}{}
+		if len(column.Labels) == 0 {
+			problems = append(problems, fmt.Sprintf("planColumns.%s: no label", column.Column))
+		}
+	}
+	if _, ok := seen["plan"]; !ok {
+		problems = append(problems, "planColumns: plan is required")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid selector config: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func checkHeadings(name string, headings map[string][]string, known []string) []string {
+	var problems []string
+	keys := make([]string, 0, len(headings))
+	for key := range headings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !containsString(known, key) {
+			problems = append(problems, fmt.Sprintf("%s: unknown field %q", name, key))
+			continue
+		}
+		if len(headings[key]) == 0 {
+			problems = append(problems, fmt.Sprintf("%s.%s: no heading", name, key))
+		}
+	}
+	return problems
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// first returns the matches of the first selector that finds anything.
+func (c *SelectorConfig) first(doc *goquery.Document, selectors []string) *goquery.Selection {
+	for _, selector := range selectors {
+		if sel := doc.Find(selector); sel.Length() > 0 {
+			return sel
+		}
+	}
+	return doc.Find(selectors[0])
+}
+
+// definitionSelection returns the value of the first definition item whose term matches an alias of field.
+func (c *SelectorConfig) definitionSelection(doc *goquery.Document, field string) (*goquery.Selection, string) {
+	items := c.first(doc, c.Detail.DefinitionItem)
+	for _, term := range c.Definitions[field] {
+		var result *goquery.Selection
+		items.EachWithBreak(func(_ int, item *goquery.Selection) bool {
+			dt := normalizeWhitespace(item.Find(c.Detail.DefinitionTerm).First().Text())
+			if dt == term || strings.Contains(dt, term) {
+				result = item.Find(c.Detail.DefinitionValue).First()
+				return false
+			}
+			return true
+		})
+		if result != nil && result.Length() > 0 {
+			return result, term
+		}
+	}
+	return nil, ""
+}
+
+func (c *SelectorConfig) definition(doc *goquery.Document, field string) string {
+	sel, _ := c.definitionSelection(doc, field)
+	if sel == nil {
+		return ""
+	}
+	return normalizeWhitespace(selectionToText(sel))
+}
+
+// lineBreakReplacer turns the line breaks of rendered definition values into newlines.
+var lineBreakReplacer = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n")
+
+func (c *SelectorConfig) definitionRaw(doc *goquery.Document, field string) string {
+	sel, _ := c.definitionSelection(doc, field)
+	if sel == nil {
+		return ""
+	}
+	html, err := sel.Html()
+	if err != nil {
+		return normalizeWhitespace(sel.Text())
+	}
+	return normalizeWhitespace(lineBreakReplacer.Replace(html))
+}
+
+// sectionHeading returns the first section heading that equals an alias of field.
+func (c *SelectorConfig) sectionHeading(doc *goquery.Document, field string) (*goquery.Selection, string) {
+	headings := c.first(doc, c.Detail.SectionHeading)
+	for _, alias := range c.Sections[field] {
+		var result *goquery.Selection
+		headings.EachWithBreak(func(_ int, heading *goquery.Selection) bool {
+			if strings.TrimSpace(heading.Text()) == alias {
+				result = heading
+				return false
+			}
+			return true
+		})
+		if result != nil {
+			return result, alias
+		}
+	}
+	return nil, ""
+}
+
+// section collects the text between the heading of field and the next heading of the same element type.
+func (c *SelectorConfig) section(doc *goquery.Document, field string) string {
+	heading, _ := c.sectionHeading(doc, field)
+	if heading == nil {
+		return ""
+	}
+	name := goquery.NodeName(heading)
+	var sb strings.Builder
+	for s := heading.Next(); s.Length() > 0; s = s.Next() {
+		if goquery.NodeName(s) == name {
+			break
+		}
+		sb.WriteString(selectionToText(s))
+		sb.WriteString("\n")
+	}
+	return normalizeWhitespace(sb.String())
+}
+
+// SelectorCheck records how one configured field matched a sample page.
+type SelectorCheck struct {
+	Field    string
+	Required bool
+	Found    bool
+	Matched  string
+}
+
+// SelectorReport lists the outcome of every configured field against a sample page.
+type SelectorReport struct {
+	Checks []SelectorCheck
+}
+
+// Missing returns the checks that found nothing.
+func (r SelectorReport) Missing() []SelectorCheck {
+	missing := make([]SelectorCheck, 0)
+	for _, check := range r.Checks {
+		if !check.Found {
+			missing = append(missing, check)
+		}
+	}
+	return missing
+}
+
+// OK reports whether every required field was found.
+func (r SelectorReport) OK() bool {
+	for _, check := range r.Checks {
+		if check.Required && !check.Found {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateDetailPage checks a sample course detail page against the configuration.
+func (c *SelectorConfig) ValidateDetailPage(r io.Reader) (SelectorReport, error) {
+	if r == nil {
+		return SelectorReport{}, fmt.Errorf("nil reader provided")
+	}
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return SelectorReport{}, fmt.Errorf("parse course detail html: %w", err)
+	}
+
+	report := SelectorReport{}
+	report.Checks = append(report.Checks, c.checkSelectors(doc, "detail.title", c.Detail.Title, true))
+	report.Checks = append(report.Checks, c.checkSelectors(doc, "detail.definitionItem", c.Detail.DefinitionItem, true))
+	report.Checks = append(report.Checks, c.checkSelectors(doc, "detail.sectionHeading", c.Detail.SectionHeading, false))
+	report.Checks = append(report.Checks, c.checkSelectors(doc, "detail.lecturePlanTable", c.Detail.LecturePlanTable, false))
+
+	for _, field := range definitionFields {
+		if _, ok := c.Definitions[field]; !ok {
+			continue
+		}
+		_, matched := c.definitionSelection(doc, field)
+		report.Checks = append(report.Checks, SelectorCheck{Field: "definitions." + field, Required: field == "code", Found: matched != "", Matched: matched})
+	}
+	for _, field := range sectionFields {
+		if _, ok := c.Sections[field]; !ok {
+			continue
+		}
+		_, matched := c.sectionHeading(doc, field)
+		report.Checks = append(report.Checks, SelectorCheck{Field: "sections." + field, Found: matched != "", Matched: matched})
+	}
+
+	return report, nil
+}
+
+// ValidateListPage checks a sample course list page against the configuration.
+func (c *SelectorConfig) ValidateListPage(r io.Reader) (SelectorReport, error) {
+	if r == nil {
+		return SelectorReport{}, fmt.Errorf("nil reader provided")
+	}
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return SelectorReport{}, fmt.Errorf("parse course list html: %w", err)
+	}
+
+	items := c.courseListItems(doc, nil)
+
+	report := SelectorReport{}
+	report.Checks = append(report.Checks, c.checkSelectors(doc, "list.rows", c.List.Rows, true))
+	report.Checks = append(report.Checks, SelectorCheck{Field: "list.columns", Required: true, Found: len(items) > 0, Matched: fmt.Sprintf("%d courses", len(items))})
+
+	return report, nil
+}
+
+func (c *SelectorConfig) checkSelectors(doc *goquery.Document, field string, selectors []string, required bool) SelectorCheck {
+	check := SelectorCheck{Field: field, Required: required}
+	for _, selector := range selectors {
+		if doc.Find(selector).Length() > 0 {
+			check.Found = true
+			check.Matched = selector
+			break
+		}
+	}
+	return check
+}
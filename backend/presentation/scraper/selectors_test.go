@@ -0,0 +1,167 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+const aliasDetailPage = `<html><body>
+<h1 class="page-title">代数学</h1>
+<dl>
+  <div class="row"><dt>開講元</dt><dd>数学系</dd></div>
+  <div class="row"><dt>Course number</dt><dd>MTH.A201</dd></div>
+</dl>
+<h2 class="heading">到達目標</h2>
+<p>群論を理解する</p>
+<h2 class="heading">Keywords</h2>
+<p>群、環</p>
+</body></html>`
+
+func TestDefaultSelectorConfigIsValid(t *testing.T) {
+	config := DefaultSelectorConfig()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("embedded config is invalid: %v", err)
+	}
+	if config == defaultSelectors {
+		t.Fatalf("DefaultSelectorConfig must return a copy")
+	}
+}
+
+func TestParseSelectorConfigMergesOverride(t *testing.T) {
+	override := `{
+  "detail": {
+    "title": ["h1.page-title", "h1.c-h1"],
+    "definitionItem": ["div.row", "div.c-dl-2col__item"],
+    "definitionTerm": "dt",
+    "definitionValue": "dd",
+    "sectionHeading": ["h2.heading", "h3.c-h3"],
+    "lecturePlanTable": ["table#lecture_plans"]
+  },
+  "definitions": {"code": ["科目コード", "Course number"]},
+  "sections": {"keywords": ["キーワード", "Keywords"]}
+}`
+	config, err := ParseSelectorConfig(strings.NewReader(override))
+	if err != nil {
+		t.Fatalf("ParseSelectorConfig returned error: %v", err)
+	}
+	if got := config.Definitions["department"]; len(got) == 0 || got[0] != "開講元" {
+		t.Fatalf("expected default department heading to be kept, got %v", got)
+	}
+	if len(config.List.Rows) == 0 {
+		t.Fatalf("expected default list selectors to be kept")
+	}
+
+	parser, err := NewParserWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewParserWithConfig returned error: %v", err)
+	}
+	lecture, err := parser.ParseCourseDetail(strings.NewReader(aliasDetailPage), "https://example.com/a")
+	if err != nil {
+		t.Fatalf("ParseCourseDetail returned error: %v", err)
+	}
+	if lecture.Title != "代数学" || lecture.Code != "MTH.A201" || lecture.Department != "数学系" {
+		t.Errorf("unexpected lecture: title=%q code=%q department=%q", lecture.Title, lecture.Code, lecture.Department)
+	}
+	if lecture.Goal != "群論を理解する" {
+		t.Errorf("unexpected goal: %q", lecture.Goal)
+	}
+	if len(lecture.Keywords) != 2 {
+		t.Errorf("unexpected keywords: %v", lecture.Keywords)
+	}
+
+	defaultLecture, err := ParseCourseDetail(strings.NewReader(aliasDetailPage), "")
+	if err != nil {
+		t.Fatalf("ParseCourseDetail returned error: %v", err)
+	}
+	if defaultLecture.Code != "" {
+		t.Errorf("override must not change the default parser, got code %q", defaultLecture.Code)
+	}
+}
+
+func TestParseSelectorConfigRejectsInvalidConfig(t *testing.T) {
+	cases := map[string]string{
+		"invalid selector": `{"list": {"rows": ["table[["]}}`,
+		"unknown field":    `{"sections": {"summary": ["概要"]}}`,
+		"unknown key":      `{"detials": {}}`,
+		"missing plan":     `{"planColumns": [{"column": "count", "labels": ["回"]}]}`,
+	}
+	for name, raw := range cases {
+		if _, err := ParseSelectorConfig(strings.NewReader(raw)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestValidateDetailPage(t *testing.T) {
+	report, err := DefaultSelectorConfig().ValidateDetailPage(strings.NewReader(aliasDetailPage))
+	if err != nil {
+		t.Fatalf("ValidateDetailPage returned error: %v", err)
+	}
+	if report.OK() {
+		t.Fatalf("expected default selectors to miss required fields of the alias page")
+	}
+
+	valid := `<html><body><h1 class="c-h1">代数学</h1>
+<div class="c-dl-2col__item"><dt>科目コード</dt><dd>MTH.A201</dd></div>
+<h3 class="c-h3">到達目標</h3><p>群論</p></body></html>`
+	report, err = DefaultSelectorConfig().ValidateDetailPage(strings.NewReader(valid))
+	if err != nil {
+		t.Fatalf("ValidateDetailPage returned error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("expected required fields to be found, missing: %+v", report.Missing())
+	}
+	found := make(map[string]string)
+	for _, check := range report.Checks {
+		if check.Found {
+			found[check.Field] = check.Matched
+		}
+	}
+	if found["definitions.code"] != "科目コード" || found["sections.goal"] != "到達目標" {
+		t.Errorf("unexpected matches: %v", found)
+	}
+	if _, ok := found["sections.abstract"]; ok {
+		t.Errorf("abstract should be reported missing")
+	}
+}
+
+func TestAddEnglishDetails(t *testing.T) {
+	page := `<html><body>
+<h1 class="c-h1">Algebra</h1>
+<div class="c-dl-2col__item"><dt>Academic unit or major</dt><dd>Department of Mathematics</dd></div>
+<div class="c-dl-2col__item"><dt>Course number</dt><dd>MTH.A201</dd></div>
+<h3 class="c-h3">Course description and aims</h3><p>Basics of group theory</p>
+<h3 class="c-h3">Student learning outcomes</h3><p>Understand groups</p>
+<h3 class="c-h3">Keywords</h3><p>group, ring</p>
+<h3 class="c-h3">Class flow</h3><p>Lectures</p>
+<h3 class="c-h3">Evaluation methods and criteria</h3><p>Final exam</p>
+<h3 class="c-h3">Course schedule/Required learning</h3>
+<table id="lecture_plans">
+  <thead><tr><th></th><th>Course schedule</th><th>Required learning</th></tr></thead>
+  <tbody><tr><td>Class 1</td><td>Definition of groups</td><td>Review</td></tr></tbody>
+</table>
+</body></html>`
+
+	lecture := &domain.Lecture{Title: "代数学", Department: "数学系", Year: 2025}
+	if err := AddEnglishDetails(strings.NewReader(page), lecture); err != nil {
+		t.Fatalf("AddEnglishDetails returned error: %v", err)
+	}
+	if lecture.Title != "代数学" || lecture.Department != "数学系" {
+		t.Errorf("japanese fields must be kept: %+v", lecture)
+	}
+	if lecture.EnglishTitle != "Algebra" || lecture.EnglishDepartment != "Department of Mathematics" {
+		t.Errorf("unexpected english title or department: %q %q", lecture.EnglishTitle, lecture.EnglishDepartment)
+	}
+	if lecture.EnglishAbstract != "Basics of group theory" || lecture.EnglishGoal != "Understand groups" ||
+		lecture.EnglishFlow != "Lectures" || lecture.EnglishAssessment != "Final exam" {
+		t.Errorf("unexpected english sections: %+v", lecture)
+	}
+	if len(lecture.EnglishKeywords) != 2 {
+		t.Errorf("unexpected english keywords: %v", lecture.EnglishKeywords)
+	}
+	if len(lecture.EnglishLecturePlans) != 1 || lecture.EnglishLecturePlans[0].Count != 1 || lecture.EnglishLecturePlans[0].Plan != "Definition of groups" {
+		t.Errorf("unexpected english plans: %+v", lecture.EnglishLecturePlans)
+	}
+}
@@ -0,0 +1,194 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultSiteID identifies the Institute of Science Tokyo syllabus, the site used when none is given.
+const DefaultSiteID = "isct"
+
+const isctUniversity = "東京科学大学"
+
+// PageFetcher loads a syllabus page. Sites crawl through the caller's fetcher so that the caller keeps
+// control of timeouts and recorded responses.
+type PageFetcher interface {
+	Fetch(ctx context.Context, url string) (io.ReadCloser, error)
+}
+
+// Site describes one university syllabus: where its pages live, how to parse them and how to crawl a year.
+// Crawling a year starts with ListPages; every list page is parsed into rows linking detail pages, which
+// are parsed and stored as lectures of University.
+type Site interface {
+	ID() string
+	University() string
+	BaseURL() string
+	// ListPages returns the course list pages of year without duplicates, fetching whatever index pages
+	// the site needs through fetcher.
+	ListPages(ctx context.Context, fetcher PageFetcher, year int) ([]string, error)
+	// EnglishURL returns the English version of a detail page, or "" when the site has none.
+	EnglishURL(detailURL string) string
+	Parser() Parser
+}
+
+// CrawlIndex fetches the year index at indexURL and returns the course list pages parser finds on it,
+// the crawl of sites linking every list page of a year from a single index page.
+func CrawlIndex(ctx context.Context, fetcher PageFetcher, parser Parser, indexURL string, year int) ([]string, error) {
+	if fetcher == nil {
+		return nil, errors.New("nil page fetcher")
+	}
+	reader, err := fetcher.Fetch(ctx, indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch top page %s: %w", indexURL, err)
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("fetch top page %s: empty response", indexURL)
+	}
+	defer reader.Close()
+
+	urls, err := parser.ListCoursesPagesURL(reader, year)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make([]string, 0, len(urls))
+	seen := make(map[string]struct{}, len(urls))
+	for _, listURL := range urls {
+		listURL = strings.TrimSpace(listURL)
+		if listURL == "" {
+			continue
+		}
+		if _, ok := seen[listURL]; ok {
+			continue
+		}
+		seen[listURL] = struct{}{}
+		unique = append(unique, listURL)
+	}
+	return unique, nil
+}
+
+// NewISCTSite returns the Institute of Science Tokyo syllabus site using the given parser, or the default one when nil.
+func NewISCTSite(parser Parser) Site {
+	if parser == nil {
+		parser = NewParser()
+	}
+	return isctSite{parser: parser}
+}
+
+type isctSite struct {
+	parser Parser
+}
+
+func (isctSite) ID() string { return DefaultSiteID }
+
+func (isctSite) University() string { return isctUniversity }
+
+func (isctSite) BaseURL() string { return TopPageURL }
+
+// ListPages crawls the year page, which links every course list page of the year.
+func (s isctSite) ListPages(ctx context.Context, fetcher PageFetcher, year int) ([]string, error) {
+	return CrawlIndex(ctx, fetcher, s.parser, fmt.Sprintf("%s/courses/%d", TopPageURL, year), year)
+}
+
+func (isctSite) EnglishURL(detailURL string) string {
+	detailURL = strings.TrimSpace(detailURL)
+	if detailURL == "" {
+		return ""
+	}
+	u, err := url.Parse(detailURL)
+	if err != nil {
+		if strings.Contains(detailURL, "?") {
+			return detailURL + "&hl=en"
+		}
+		return detailURL + "?hl=en"
+	}
+	query := u.Query()
+	query.Set("hl", "en")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (s isctSite) Parser() Parser { return s.parser }
+
+// Registry holds the sites that can be scraped, keyed by their ID.
+type Registry struct {
+	sites map[string]Site
+}
+
+// NewRegistry creates a registry containing the given sites.
+func NewRegistry(sites ...Site) (*Registry, error) {
+	registry := &Registry{sites: make(map[string]Site, len(sites))}
+	for _, site := range sites {
+		if err := registry.Register(site); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// DefaultRegistry returns a registry with every built-in site.
+func DefaultRegistry() *Registry {
+	return &Registry{sites: map[string]Site{DefaultSiteID: NewISCTSite(nil)}}
+}
+
+// Register adds a site; IDs must be unique.
+func (r *Registry) Register(site Site) error {
+	if site == nil {
+		return errors.New("nil site")
+	}
+	id := strings.TrimSpace(site.ID())
+	if id == "" {
+		return errors.New("site id is required")
+	}
+	if _, ok := r.sites[id]; ok {
+		return fmt.Errorf("site already registered: %s", id)
+	}
+	r.sites[id] = site
+	return nil
+}
+
+// Site returns the site registered under id; an empty id selects DefaultSiteID.
+func (r *Registry) Site(id string) (Site, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		id = DefaultSiteID
+	}
+	site, ok := r.sites[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown site: %s", id)
+	}
+	return site, nil
+}
+
+// SiteForURL returns the site whose base URL has the same host as rawURL.
+func (r *Registry) SiteForURL(rawURL string) (Site, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return nil, false
+	}
+	for _, site := range r.Sites() {
+		base, err := url.Parse(site.BaseURL())
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(base.Host, u.Host) {
+			return site, true
+		}
+	}
+	return nil, false
+}
+
+// Sites returns the registered sites ordered by ID.
+func (r *Registry) Sites() []Site {
+	sites := make([]Site, 0, len(r.sites))
+	for _, site := range r.sites {
+		sites = append(sites, site)
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].ID() < sites[j].ID() })
+	return sites
+}
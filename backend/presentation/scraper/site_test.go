@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mapFetcher map[string]string
+
+func (f mapFetcher) Fetch(_ context.Context, url string) (io.ReadCloser, error) {
+	body, ok := f[url]
+	if !ok {
+		return nil, fmt.Errorf("unexpected url: %s", url)
+	}
+	return io.NopCloser(strings.NewReader(body)), nil
+}
+
+func TestRegistryResolvesSites(t *testing.T) {
+	registry := DefaultRegistry()
+
+	site, err := registry.Site("")
+	if err != nil {
+		t.Fatalf("Site returned error: %v", err)
+	}
+	if site.ID() != DefaultSiteID {
+		t.Fatalf("expected default site, got %s", site.ID())
+	}
+	if site.University() != "東京科学大学" {
+		t.Fatalf("unexpected university: %s", site.University())
+	}
+
+	if _, err := registry.Site("unknown"); err == nil {
+		t.Fatal("expected unknown site to fail")
+	}
+
+	if err := registry.Register(NewISCTSite(nil)); err == nil {
+		t.Fatal("expected duplicate site id to be rejected")
+	}
+
+	found, ok := registry.SiteForURL(TopPageURL + "/courses/2025/4/list")
+	if !ok || found.ID() != DefaultSiteID {
+		t.Fatalf("expected site for syllabus URL, got %v %v", found, ok)
+	}
+	if _, ok := registry.SiteForURL("https://example.com/courses"); ok {
+		t.Fatal("expected no site for a foreign host")
+	}
+}
+
+func TestISCTSiteURLs(t *testing.T) {
+	site := NewISCTSite(nil)
+
+	listA := TopPageURL + "/courses/2025/4/list-a"
+	listB := TopPageURL + "/courses/2025/4/list-b"
+	fetcher := mapFetcher{
+		TopPageURL + "/courses/2025": fmt.Sprintf(`<a href="%s">A</a><a href="%s">B</a><a href="%s">A</a>`, listA, listB, listA),
+	}
+	pages, err := site.ListPages(context.Background(), fetcher, 2025)
+	if err != nil {
+		t.Fatalf("ListPages returned error: %v", err)
+	}
+	if !reflect.DeepEqual(pages, []string{listA, listB}) {
+		t.Errorf("unexpected list pages: %v", pages)
+	}
+	if _, err := site.ListPages(context.Background(), fetcher, 2024); err == nil {
+		t.Error("expected a missing year page to fail")
+	}
+	if got := site.EnglishURL(TopPageURL + "/courses/2025/LAH.S101"); got != TopPageURL+"/courses/2025/LAH.S101?hl=en" {
+		t.Errorf("unexpected english url: %s", got)
+	}
+	if got := site.EnglishURL(TopPageURL + "/courses/2025/LAH.S101?tab=plan"); got != TopPageURL+"/courses/2025/LAH.S101?hl=en&tab=plan" {
+		t.Errorf("unexpected english url with query: %s", got)
+	}
+}
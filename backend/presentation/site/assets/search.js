@@ -0,0 +1,48 @@
+(function () {
+  var index = window.desySearchIndex || [];
+  var root = window.desySearchRoot || "";
+  var input = document.getElementById("search-input");
+  var status = document.getElementById("search-status");
+  var results = document.getElementById("search-results");
+  var limit = 200;
+
+  function normalize(text) {
+    return (text || "").normalize("NFKC").toLowerCase();
+  }
+
+  index.forEach(function (entry) {
+    entry.haystack = normalize([entry.code, entry.title, entry.english_title, entry.department, entry.year]
+      .concat(entry.teachers || [], entry.keywords || []).join(" "));
+  });
+
+  function render() {
+    var terms = normalize(input.value).split(/\s+/).filter(Boolean);
+    results.innerHTML = "";
+    if (terms.length === 0) {
+      status.textContent = index.length + " 科目";
+      return;
+    }
+    var matches = index.filter(function (entry) {
+      return terms.every(function (term) { return entry.haystack.indexOf(term) !== -1; });
+    });
+    status.textContent = matches.length + " 件" + (matches.length > limit ? "（先頭 " + limit + " 件を表示）" : "");
+    matches.slice(0, limit).forEach(function (entry) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = root + entry.url;
+      link.textContent = entry.title;
+      var detail = document.createElement("small");
+      detail.textContent = " " + [entry.code, entry.year + "年度", entry.department, (entry.teachers || []).join(", ")].filter(Boolean).join(" / ");
+      item.appendChild(link);
+      item.appendChild(detail);
+      results.appendChild(item);
+    });
+  }
+
+  var query = new URLSearchParams(window.location.search).get("q");
+  if (query) {
+    input.value = query;
+  }
+  input.addEventListener("input", render);
+  render();
+})();
@@ -0,0 +1,443 @@
+package site
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+//go:embed templates/*.html assets/*
+var embeddedFiles embed.FS
+
+// pageTemplates are the page bodies; each is rendered inside layout.html and may use lecture_list.html.
+var pageTemplates = []string{"index.html", "year.html", "department.html", "lecture.html", "teachers.html", "teacher.html", "search.html"}
+
+// sharedTemplates are parsed into every page.
+var sharedTemplates = []string{"layout.html", "lecture_list.html"}
+
+// undecidedDepartment names the group of lectures without a department.
+const undecidedDepartment = "開講元未設定"
+
+// Site is the content of a generated syllabus site.
+type Site struct {
+	Title       string
+	GeneratedAt time.Time
+	Lectures    []domain.Lecture
+}
+
+// Report counts what Generate wrote.
+type Report struct {
+	Lectures int
+	Teachers int
+	Pages    int
+}
+
+// Generator renders a static syllabus site and printable reports from lectures.
+// Every page links relatively, so the output can be served from any path or opened from disk.
+type Generator struct {
+	pages  map[string]*template.Template
+	report *template.Template
+	assets map[string][]byte
+}
+
+// NewGenerator parses the embedded templates and assets. A file in overrideDir with the name of a
+// template (layout.html, lecture.html, report.html, ...) or asset (style.css, search.js) replaces the
+// embedded one; an empty overrideDir keeps every default.
+func NewGenerator(overrideDir string) (*Generator, error) {
+	read := func(name string) ([]byte, error) {
+		if overrideDir != "" {
+			data, err := os.ReadFile(filepath.Join(overrideDir, path.Base(name)))
+			if err == nil {
+				return data, nil
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("read template override %s: %w", path.Base(name), err)
+			}
+		}
+		return embeddedFiles.ReadFile(name)
+	}
+
+	base := template.New("layout").Funcs(templateFuncs)
+	for _, name := range sharedTemplates {
+		data, err := read("templates/" + name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := base.New(name).Parse(string(data)); err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", name, err)
+		}
+	}
+
+	g := &Generator{pages: make(map[string]*template.Template), assets: make(map[string][]byte)}
+	for _, name := range pageTemplates {
+		data, err := read("templates/" + name)
+		if err != nil {
+			return nil, err
+		}
+		page, err := base.Clone()
+		if err != nil {
+			return nil, fmt.Errorf("clone layout: %w", err)
+		}
+		if _, err := page.New(name).Parse(string(data)); err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", name, err)
+		}
+		g.pages[name] = page
+	}
+
+	data, err := read("templates/report.html")
+	if err != nil {
+		return nil, err
+	}
+	if g.report, err = template.New("report.html").Funcs(templateFuncs).Parse(string(data)); err != nil {
+		return nil, fmt.Errorf("parse template report.html: %w", err)
+	}
+
+	assets, err := fs.ReadDir(embeddedFiles, "assets")
+	if err != nil {
+		return nil, fmt.Errorf("read assets: %w", err)
+	}
+	for _, entry := range assets {
+		data, err := read("assets/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		g.assets[entry.Name()] = data
+	}
+	return g, nil
+}
+
+// page is the data every template receives. Root is the relative path from the page to the site root.
+type page struct {
+	Root        string
+	SiteTitle   string
+	Title       string
+	GeneratedAt time.Time
+	Years       []*yearEntry
+	Year        *yearEntry
+	Department  *departmentEntry
+	Lecture     *domain.Lecture
+	Teachers    []*teacherEntry
+	Teacher     *teacherEntry
+}
+
+type yearEntry struct {
+	Year        int
+	Lectures    int
+	Departments []*departmentEntry
+}
+
+type departmentEntry struct {
+	Year     int
+	Name     string
+	Lectures []domain.Lecture
+}
+
+type teacherEntry struct {
+	Teacher  domain.Teacher
+	Lectures []domain.Lecture
+}
+
+// searchEntry is one lecture in search-index.json.
+type searchEntry struct {
+	ID           int      `json:"id"`
+	URL          string   `json:"url"`
+	Code         string   `json:"code"`
+	Title        string   `json:"title"`
+	EnglishTitle string   `json:"english_title,omitempty"`
+	Department   string   `json:"department"`
+	Year         int      `json:"year"`
+	Teachers     []string `json:"teachers"`
+	Keywords     []string `json:"keywords"`
+}
+
+// Generate writes the site into outDir: an index of years and departments, a page per year, department,
+// lecture and teacher, a search page and its index as search-index.json and search-index.js.
+func (g *Generator) Generate(outDir string, site Site) (*Report, error) {
+	if g == nil {
+		return nil, errors.New("site generator is not initialized")
+	}
+
+	lectures := append([]domain.Lecture(nil), site.Lectures...)
+	sort.SliceStable(lectures, func(i, j int) bool {
+		if lectures[i].Code != lectures[j].Code {
+			return lectures[i].Code < lectures[j].Code
+		}
+		return lectures[i].Title < lectures[j].Title
+	})
+	years := groupByYear(lectures)
+	teachers := groupByTeacher(lectures)
+
+	report := &Report{Lectures: len(lectures), Teachers: len(teachers)}
+	render := func(name, rel string, data page) error {
+		data.Root = strings.Repeat("../", strings.Count(rel, "/"))
+		data.SiteTitle = site.Title
+		data.GeneratedAt = site.GeneratedAt
+		if err := g.render(name, filepath.Join(outDir, filepath.FromSlash(rel)), data); err != nil {
+			return err
+		}
+		report.Pages++
+		return nil
+	}
+
+	if err := render("index.html", "index.html", page{Years: years}); err != nil {
+		return nil, err
+	}
+	for _, year := range years {
+		if err := render("year.html", yearPath(year.Year), page{Title: fmt.Sprintf("%d年度", year.Year), Year: year}); err != nil {
+			return nil, err
+		}
+		for _, department := range year.Departments {
+			title := fmt.Sprintf("%s (%d年度)", department.Name, department.Year)
+			if err := render("department.html", departmentPath(department.Year, department.Name), page{Title: title, Department: department}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i := range lectures {
+		if err := render("lecture.html", lecturePath(lectures[i]), page{Title: lectures[i].Title, Lecture: &lectures[i]}); err != nil {
+			return nil, err
+		}
+	}
+	if err := render("teachers.html", "teachers/index.html", page{Title: "教員", Teachers: teachers}); err != nil {
+		return nil, err
+	}
+	for _, teacher := range teachers {
+		if err := render("teacher.html", teacherPath(teacher.Teacher), page{Title: teacher.Teacher.Name, Teacher: teacher}); err != nil {
+			return nil, err
+		}
+	}
+	if err := render("search.html", "search.html", page{Title: "検索"}); err != nil {
+		return nil, err
+	}
+
+	if err := writeSearchIndex(outDir, lectures); err != nil {
+		return nil, err
+	}
+	for name, data := range g.assets {
+		if err := writeFile(filepath.Join(outDir, "assets", name), data); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// WriteReport writes report as a single HTML document with print styles and no external resources,
+// so it can be printed, or saved as PDF, from any browser.
+func (g *Generator) WriteReport(w io.Writer, report domain.TimetableReport, generatedAt time.Time) error {
+	if g == nil || g.report == nil {
+		return errors.New("site generator is not initialized")
+	}
+	data := struct {
+		Report      domain.TimetableReport
+		GeneratedAt time.Time
+	}{Report: report, GeneratedAt: generatedAt}
+	if err := g.report.ExecuteTemplate(w, "report", data); err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	return nil
+}
+
+func (g *Generator) render(name, target string, data page) error {
+	var b strings.Builder
+	if err := g.pages[name].ExecuteTemplate(&b, "layout", data); err != nil {
+		return fmt.Errorf("render %s: %w", name, err)
+	}
+	return writeFile(target, []byte(b.String()))
+}
+
+func writeSearchIndex(outDir string, lectures []domain.Lecture) error {
+	entries := make([]searchEntry, 0, len(lectures))
+	for _, lecture := range lectures {
+		teachers := make([]string, 0, len(lecture.Teachers))
+		for _, teacher := range lecture.Teachers {
+			teachers = append(teachers, teacher.Name)
+		}
+		keywords := lecture.Keywords
+		if keywords == nil {
+			keywords = []string{}
+		}
+		entries = append(entries, searchEntry{
+			ID:           lecture.ID,
+			URL:          lecturePath(lecture),
+			Code:         lecture.Code,
+			Title:        lecture.Title,
+			EnglishTitle: lecture.EnglishTitle,
+			Department:   lecture.Department,
+			Year:         lecture.Year,
+			Teachers:     teachers,
+			Keywords:     keywords,
+		})
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encode search index: %w", err)
+	}
+	if err := writeFile(filepath.Join(outDir, "search-index.json"), data); err != nil {
+		return err
+	}
+	// the script form lets search.html load the index from file://, where fetch is blocked
+	script := append([]byte("window.desySearchIndex = "), data...)
+	script = append(script, ";\n"...)
+	return writeFile(filepath.Join(outDir, "search-index.js"), script)
+}
+
+func writeFile(target string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", filepath.Base(target), err)
+	}
+	return nil
+}
+
+// groupByYear groups lectures by year, newest first, then by department name.
+func groupByYear(lectures []domain.Lecture) []*yearEntry {
+	byYear := make(map[int]*yearEntry)
+	departments := make(map[string]*departmentEntry)
+	for _, lecture := range lectures {
+		year, ok := byYear[lecture.Year]
+		if !ok {
+			year = &yearEntry{Year: lecture.Year}
+			byYear[lecture.Year] = year
+		}
+		year.Lectures++
+
+		name := departmentName(lecture.Department)
+		key := fmt.Sprintf("%d\x00%s", lecture.Year, name)
+		department, ok := departments[key]
+		if !ok {
+			department = &departmentEntry{Year: lecture.Year, Name: name}
+			departments[key] = department
+			year.Departments = append(year.Departments, department)
+		}
+		department.Lectures = append(department.Lectures, lecture)
+	}
+
+	years := make([]*yearEntry, 0, len(byYear))
+	for _, year := range byYear {
+		sort.Slice(year.Departments, func(i, j int) bool { return year.Departments[i].Name < year.Departments[j].Name })
+		years = append(years, year)
+	}
+	sort.Slice(years, func(i, j int) bool { return years[i].Year > years[j].Year })
+	return years
+}
+
+// groupByTeacher collects the lectures of every teacher, ordered by name.
+func groupByTeacher(lectures []domain.Lecture) []*teacherEntry {
+	byKey := make(map[string]*teacherEntry)
+	for _, lecture := range lectures {
+		for _, teacher := range lecture.Teachers {
+			if strings.TrimSpace(teacher.Name) == "" {
+				continue
+			}
+			key := teacherSlug(teacher)
+			entry, ok := byKey[key]
+			if !ok {
+				entry = &teacherEntry{Teacher: teacher}
+				byKey[key] = entry
+			}
+			entry.Lectures = append(entry.Lectures, lecture)
+		}
+	}
+
+	teachers := make([]*teacherEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		teachers = append(teachers, entry)
+	}
+	sort.Slice(teachers, func(i, j int) bool {
+		if teachers[i].Teacher.Name != teachers[j].Teacher.Name {
+			return teachers[i].Teacher.Name < teachers[j].Teacher.Name
+		}
+		return teachers[i].Teacher.ID < teachers[j].Teacher.ID
+	})
+	return teachers
+}
+
+func departmentName(department string) string {
+	if department = strings.TrimSpace(department); department == "" {
+		return undecidedDepartment
+	}
+	return department
+}
+
+func yearPath(year int) string {
+	return fmt.Sprintf("years/%d/index.html", year)
+}
+
+// departmentPath hashes the department name, which is Japanese and may contain slashes, into a file name.
+func departmentPath(year int, department string) string {
+	return fmt.Sprintf("years/%d/d%s.html", year, nameHash(departmentName(department)))
+}
+
+func lecturePath(lecture domain.Lecture) string {
+	return fmt.Sprintf("lectures/%d.html", lecture.ID)
+}
+
+func teacherPath(teacher domain.Teacher) string {
+	return "teachers/" + teacherSlug(teacher) + ".html"
+}
+
+// teacherSlug uses the stored ID and falls back on the name of teachers that were never stored.
+func teacherSlug(teacher domain.Teacher) string {
+	if teacher.ID > 0 {
+		return fmt.Sprintf("%d", teacher.ID)
+	}
+	return "n" + nameHash(strings.TrimSpace(teacher.Name))
+}
+
+func nameHash(name string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+var templateFuncs = template.FuncMap{
+	"yearPath":       yearPath,
+	"departmentPath": departmentPath,
+	"lecturePath":    lecturePath,
+	"teacherPath":    teacherPath,
+	"dayLabel":       dayLabel,
+	"semesterLabel":  semesterLabel,
+	"join":           strings.Join,
+	"formatDate": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	},
+	"lectureList": func(root string, lectures []domain.Lecture) map[string]any {
+		return map[string]any{"Root": root, "Lectures": lectures}
+	},
+	"section": func(heading, body string) map[string]any {
+		return map[string]any{"Heading": heading, "Body": strings.TrimSpace(body)}
+	},
+}
+
+func dayLabel(day domain.DayOfWeek) string {
+	labels := []string{"月", "火", "水", "木", "金", "土", "日"}
+	if i := day.Index(); i >= 0 {
+		return labels[i]
+	}
+	return string(day)
+}
+
+func semesterLabel(semester domain.Semester) string {
+	if i := semester.Index(); i >= 0 {
+		return fmt.Sprintf("%dQ", i+1)
+	}
+	return string(semester)
+}
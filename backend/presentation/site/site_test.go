@@ -0,0 +1,160 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func siteFixture() Site {
+	return Site{
+		Title:       "テストシラバス",
+		GeneratedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Lectures: []domain.Lecture{
+			{
+				ID:         1,
+				Title:      "線形代数 <入門>",
+				Code:       "MTH.A201",
+				Department: "数学系",
+				Year:       2025,
+				Abstract:   "行列と線形写像",
+				Keywords:   []string{"行列"},
+				Teachers:   []domain.Teacher{{ID: 7, Name: "山田 太郎"}},
+				Timetables: []domain.TimeTable{{Semester: domain.SemesterSpring, DayOfWeek: domain.DayOfWeekMonday, Period: domain.Period1}},
+			},
+			{
+				ID:       2,
+				Title:    "解析学",
+				Code:     "MTH.A202",
+				Year:     2024,
+				Teachers: []domain.Teacher{{Name: "佐藤 花子"}},
+			},
+		},
+	}
+}
+
+func readOutput(t *testing.T, dir, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatalf("read %s: %v", rel, err)
+	}
+	return string(data)
+}
+
+func TestGenerateWritesPages(t *testing.T) {
+	generator, err := NewGenerator("")
+	if err != nil {
+		t.Fatalf("NewGenerator returned error: %v", err)
+	}
+	dir := t.TempDir()
+	report, err := generator.Generate(dir, siteFixture())
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	// index, 2 years, 2 departments, 2 lectures, teacher index, 2 teachers, search
+	if report.Lectures != 2 || report.Teachers != 2 || report.Pages != 11 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	lecture := readOutput(t, dir, "lectures/1.html")
+	if !strings.Contains(lecture, "線形代数 &lt;入門&gt;") {
+		t.Fatalf("lecture title should be escaped: %s", lecture)
+	}
+	if !strings.Contains(lecture, `href="../assets/style.css"`) || !strings.Contains(lecture, `href="../teachers/7.html"`) {
+		t.Fatalf("lecture page should link relatively: %s", lecture)
+	}
+	if !strings.Contains(lecture, "1Q 月1限") {
+		t.Fatalf("lecture page should label the timetable: %s", lecture)
+	}
+	department := readOutput(t, dir, departmentPath(2025, "数学系"))
+	if !strings.Contains(department, `href="../../lectures/1.html"`) {
+		t.Fatalf("department page should list the lecture: %s", department)
+	}
+	if !strings.Contains(readOutput(t, dir, departmentPath(2024, "")), undecidedDepartment) {
+		t.Fatalf("lectures without a department should be grouped")
+	}
+	if !strings.Contains(readOutput(t, dir, "teachers/index.html"), "佐藤 花子") {
+		t.Fatalf("teacher index should list teachers without an ID")
+	}
+	readOutput(t, dir, "assets/search.js")
+
+	var entries []searchEntry
+	if err := json.Unmarshal([]byte(readOutput(t, dir, "search-index.json")), &entries); err != nil {
+		t.Fatalf("decode search index: %v", err)
+	}
+	if len(entries) != 2 || entries[0].URL != "lectures/1.html" || entries[0].Teachers[0] != "山田 太郎" {
+		t.Fatalf("unexpected search index: %+v", entries)
+	}
+	if !strings.HasPrefix(readOutput(t, dir, "search-index.js"), "window.desySearchIndex = [") {
+		t.Fatalf("search index script should assign the index")
+	}
+}
+
+func TestGeneratorUsesOverrides(t *testing.T) {
+	overrides := t.TempDir()
+	if err := os.WriteFile(filepath.Join(overrides, "lecture.html"), []byte(`{{define "content"}}<h1 class="custom">{{.Lecture.Code}}</h1>{{end}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(overrides, "style.css"), []byte("body{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	generator, err := NewGenerator(overrides)
+	if err != nil {
+		t.Fatalf("NewGenerator returned error: %v", err)
+	}
+	dir := t.TempDir()
+	if _, err := generator.Generate(dir, siteFixture()); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if lecture := readOutput(t, dir, "lectures/2.html"); !strings.Contains(lecture, `<h1 class="custom">MTH.A202</h1>`) {
+		t.Fatalf("lecture template should be overridden: %s", lecture)
+	}
+	if readOutput(t, dir, "assets/style.css") != "body{}" {
+		t.Fatalf("asset should be overridden")
+	}
+	if !strings.Contains(readOutput(t, dir, "index.html"), "テストシラバス") {
+		t.Fatalf("templates without an override should keep the default")
+	}
+}
+
+func TestNewGeneratorRejectsBrokenOverride(t *testing.T) {
+	overrides := t.TempDir()
+	if err := os.WriteFile(filepath.Join(overrides, "layout.html"), []byte(`{{define "layout"}}{{.Missing`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGenerator(overrides); err == nil {
+		t.Fatalf("expected a parse error")
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	generator, err := NewGenerator("")
+	if err != nil {
+		t.Fatalf("NewGenerator returned error: %v", err)
+	}
+	lectures := siteFixture().Lectures
+	lectures[0].Timetables[0].Room = domain.Room{Name: "W5-104"}
+	lectures[0].Assessment = "期末試験"
+	lectures[0].LecturePlans = []domain.LecturePlan{{Count: 1, Plan: "行列の演算", Assignment: "演習問題"}}
+
+	var b strings.Builder
+	if err := generator.WriteReport(&b, domain.NewTimetableReport("時間割 2025", lectures), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteReport returned error: %v", err)
+	}
+	html := b.String()
+	for _, want := range []string{"<title>時間割 2025</title>", "@media screen", "<h2>1Q</h2>", "W5-104 / 山田 太郎", "期末試験", "行列の演算", "2025-04-01"} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("report should contain %q: %s", want, html)
+		}
+	}
+	if strings.Contains(html, "<link") || strings.Contains(html, "<script") {
+		t.Fatalf("report should be self-contained: %s", html)
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// GraphFormat names an export format of the course graph.
+type GraphFormat string
+
+const (
+	GraphFormatDOT  GraphFormat = "dot"
+	GraphFormatJSON GraphFormat = "json"
+)
+
+// orderedRelationKinds are used for study orders when no kinds are given; related links have no direction to follow.
+var orderedRelationKinds = []domain.CourseRelationKind{domain.CourseRelationPrerequisite, domain.CourseRelationRecommended}
+
+// CourseGraphUsecase answers questions over the graph of course relations. Empty kinds select every relation kind.
+type CourseGraphUsecase interface {
+	GetPrerequisiteGraph(lectureID int, kinds []domain.CourseRelationKind) (*domain.CourseGraph, error)
+	FindPath(fromID, toID int, kinds []domain.CourseRelationKind) ([]domain.CourseNode, error)
+	GetStudyOrder(lectureIDs []int, kinds []domain.CourseRelationKind) ([]domain.CourseNode, error)
+	FindCycles(year int, kinds []domain.CourseRelationKind) ([][]domain.CourseNode, error)
+	ExportGraph(w io.Writer, format GraphFormat, year int, lectureIDs []int, kinds []domain.CourseRelationKind) error
+}
+
+type courseGraphUsecase struct {
+	graphRepo domain.CourseGraphRepository
+}
+
+func NewCourseGraphUsecase(graphRepo domain.CourseGraphRepository) CourseGraphUsecase {
+	return &courseGraphUsecase{
+		graphRepo: graphRepo,
+	}
+}
+
+// GetPrerequisiteGraph returns the lecture together with everything that leads into it.
+func (uc *courseGraphUsecase) GetPrerequisiteGraph(lectureID int, kinds []domain.CourseRelationKind) (*domain.CourseGraph, error) {
+	graph, err := uc.loadGraph(0, kinds)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := graph.Node(lectureID); !ok {
+		return nil, fmt.Errorf("lecture %d not found", lectureID)
+	}
+
+	return graph.Subgraph(append([]int{lectureID}, graph.Ancestors(lectureID)...)), nil
+}
+
+// FindPath returns the lectures on a shortest path from one lecture to another, or nil when there is none.
+func (uc *courseGraphUsecase) FindPath(fromID, toID int, kinds []domain.CourseRelationKind) ([]domain.CourseNode, error) {
+	graph, err := uc.loadGraph(0, kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodesOf(graph, graph.ShortestPath(fromID, toID)), nil
+}
+
+// GetStudyOrder orders the lectures and everything leading into them so that each comes after its prerequisites.
+func (uc *courseGraphUsecase) GetStudyOrder(lectureIDs []int, kinds []domain.CourseRelationKind) ([]domain.CourseNode, error) {
+	if len(kinds) == 0 {
+		kinds = orderedRelationKinds
+	}
+	graph, err := uc.loadGraph(0, kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	return graph.Subgraph(closureOf(graph, lectureIDs)).TopologicalOrder()
+}
+
+// FindCycles returns the groups of lectures of a year that lead into each other.
+func (uc *courseGraphUsecase) FindCycles(year int, kinds []domain.CourseRelationKind) ([][]domain.CourseNode, error) {
+	graph, err := uc.loadGraph(year, kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := make([][]domain.CourseNode, 0)
+	for _, cycle := range graph.Cycles() {
+		cycles = append(cycles, nodesOf(graph, cycle))
+	}
+	return cycles, nil
+}
+
+// ExportGraph writes the lectures and everything leading into them, or the whole graph of the year when
+// lectureIDs is empty, in the given format.
+func (uc *courseGraphUsecase) ExportGraph(w io.Writer, format GraphFormat, year int, lectureIDs []int, kinds []domain.CourseRelationKind) error {
+	graph, err := uc.loadGraph(year, kinds)
+	if err != nil {
+		return err
+	}
+	if len(lectureIDs) > 0 {
+		graph = graph.Subgraph(closureOf(graph, lectureIDs))
+	}
+
+	switch format {
+	case GraphFormatDOT:
+		return graph.WriteDOT(w)
+	case GraphFormatJSON, "":
+		return graph.WriteJSON(w)
+	default:
+		return fmt.Errorf("unknown graph format %q", format)
+	}
+}
+
+func (uc *courseGraphUsecase) loadGraph(year int, kinds []domain.CourseRelationKind) (*domain.CourseGraph, error) {
+	if uc == nil || uc.graphRepo == nil {
+		return nil, errors.New("course graph repository is not initialized")
+	}
+
+	return uc.graphRepo.FindCourseGraph(year, kinds)
+}
+
+// closureOf returns the lectures and all their ancestors, each once.
+func closureOf(graph *domain.CourseGraph, lectureIDs []int) []int {
+	ids := make([]int, 0, len(lectureIDs))
+	seen := make(map[int]struct{})
+	for _, id := range lectureIDs {
+		for _, member := range append([]int{id}, graph.Ancestors(id)...) {
+			if _, ok := seen[member]; ok {
+				continue
+			}
+			seen[member] = struct{}{}
+			ids = append(ids, member)
+		}
+	}
+	return ids
+}
+
+func nodesOf(graph *domain.CourseGraph, ids []int) []domain.CourseNode {
+	if ids == nil {
+		return nil
+	}
+	nodes := make([]domain.CourseNode, 0, len(ids))
+	for _, id := range ids {
+		if node, ok := graph.Node(id); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
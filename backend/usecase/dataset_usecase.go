@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// datasetImportBatch is how many lectures are stored per transaction when importing a dataset.
+const datasetImportBatch = 200
+
+// DatasetUsecase moves the whole lecture database between machines as a single dataset file.
+type DatasetUsecase interface {
+	ExportDataset(w io.Writer) (*domain.DatasetManifest, error)
+	ImportDataset(ctx context.Context, r io.Reader) (*domain.DatasetImportResult, error)
+}
+
+type datasetUsecase struct {
+	lectureRepo domain.LectureRepository
+	datasetRepo domain.DatasetRepository
+}
+
+func NewDatasetUsecase(lectureRepo domain.LectureRepository, datasetRepo domain.DatasetRepository) DatasetUsecase {
+	return &datasetUsecase{
+		lectureRepo: lectureRepo,
+		datasetRepo: datasetRepo,
+	}
+}
+
+// ExportDataset writes every lecture with its child rows, preceded by a manifest describing them. A lecture
+// stored again by a re-scrape is written once, from its newest row.
+func (uc *datasetUsecase) ExportDataset(w io.Writer) (*domain.DatasetManifest, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+
+	summaries, err := uc.lectureRepo.Search(domain.SearchQuery{})
+	if err != nil {
+		return nil, err
+	}
+	summaries = newestDatasetLectures(summaries)
+	sites, scrapedAt, err := uc.datasetRepo.FindDatasetSources()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := domain.DatasetManifest{
+		SchemaVersion: domain.DatasetSchemaVersion,
+		Sites:         sites,
+		ScrapedAt:     scrapedAt,
+		ExportedAt:    time.Now().UTC(),
+		Lectures:      len(summaries),
+	}
+	universities := make(map[string]struct{})
+	years := make(map[int]struct{})
+	for _, summary := range summaries {
+		universities[summary.University] = struct{}{}
+		if summary.Year != 0 {
+			years[summary.Year] = struct{}{}
+		}
+	}
+	for university := range universities {
+		manifest.Universities = append(manifest.Universities, university)
+	}
+	sort.Strings(manifest.Universities)
+	for year := range years {
+		manifest.Years = append(manifest.Years, year)
+	}
+	sort.Ints(manifest.Years)
+
+	writer, err := domain.NewDatasetWriter(w, manifest)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		lecture, err := uc.lectureRepo.FindByID(summary.ID)
+		if err != nil {
+			return nil, err
+		}
+		if lecture == nil {
+			continue
+		}
+		if err := writer.Write(*lecture); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("close dataset: %w", err)
+	}
+	return &manifest, nil
+}
+
+// ImportDataset merges a dataset into the database. Lectures whose code, title, open term and year match
+// a stored lecture are skipped unless the dataset copy was updated on a later date; then it is stored
+// again, the way a re-scrape stores a changed lecture. Of several copies within the dataset the newest is
+// kept, so importing the same dataset twice adds nothing. Relations between lectures are resolved once
+// everything is stored.
+func (uc *datasetUsecase) ImportDataset(ctx context.Context, r io.Reader) (*domain.DatasetImportResult, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+
+	reader, err := domain.NewDatasetReader(r)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := uc.datasetRepo.FindDatasetKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// datasetCopy is the newest copy of a lecture seen so far; position is its index in the unflushed
+	// batch, or -1 once it is stored. skipped is set while the stored lecture is newer than every copy.
+	type datasetCopy struct {
+		updatedAt time.Time
+		position  int
+		skipped   bool
+	}
+	result := &domain.DatasetImportResult{Manifest: reader.Manifest}
+	seen := make(map[string]*datasetCopy)
+	batch := make([]domain.Lecture, 0, datasetImportBatch)
+	batchKeys := make([]string, 0, datasetImportBatch)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := uc.lectureRepo.Creates(batch); err != nil {
+			return err
+		}
+		for _, key := range batchKeys {
+			seen[key].position = -1
+		}
+		batch = batch[:0]
+		batchKeys = batchKeys[:0]
+		return nil
+	}
+	add := func(key string, lecture domain.Lecture) error {
+		seen[key].position = len(batch)
+		batch = append(batch, domain.PortableLecture(lecture))
+		batchKeys = append(batchKeys, key)
+		if len(batch) == datasetImportBatch {
+			return flush()
+		}
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		lecture, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key := domain.DatasetLectureKey(lecture.Code, lecture.Title, lecture.OpenTerm, lecture.Year)
+		if previous, ok := seen[key]; ok {
+			// a newer copy replaces the pending one or, once that is stored, is stored after it so that
+			// its row is the newest
+			if !normalizeDate(lecture.UpdatedAt).After(normalizeDate(previous.updatedAt)) {
+				result.Skipped++
+				continue
+			}
+			previous.updatedAt = lecture.UpdatedAt
+			if previous.skipped {
+				previous.skipped = false
+				result.Updated++
+			} else {
+				result.Skipped++
+			}
+			if previous.position >= 0 {
+				batch[previous.position] = domain.PortableLecture(*lecture)
+				continue
+			}
+			if err := add(key, *lecture); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if stored, ok := keys[key]; ok {
+			if !normalizeDate(lecture.UpdatedAt).After(normalizeDate(stored.UpdatedAt)) {
+				result.Skipped++
+				seen[key] = &datasetCopy{updatedAt: stored.UpdatedAt, position: -1, skipped: true}
+				continue
+			}
+			result.Updated++
+		} else {
+			result.Imported++
+		}
+		seen[key] = &datasetCopy{updatedAt: lecture.UpdatedAt}
+		if err := add(key, *lecture); err != nil {
+			return nil, err
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if result.Imported > 0 || result.Updated > 0 {
+		if _, err := uc.lectureRepo.MigrateRelatedCourses(ctx); err != nil {
+			return nil, fmt.Errorf("resolve related courses: %w", err)
+		}
+		if _, err := uc.lectureRepo.MigrateCourseReferences(ctx); err != nil {
+			return nil, fmt.Errorf("resolve course references: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// newestDatasetLectures keeps one summary per DatasetLectureKey, the one with the highest id, in the
+// order they were found. Re-scrapes store a lecture again under a new id, so that is its newest row.
+func newestDatasetLectures(summaries []domain.LectureSummary) []domain.LectureSummary {
+	newest := make(map[string]int, len(summaries))
+	for i, summary := range summaries {
+		key := domain.DatasetLectureKey(summary.Code, summary.Title, summary.OpenTerm, summary.Year)
+		if j, ok := newest[key]; !ok || summary.ID > summaries[j].ID {
+			newest[key] = i
+		}
+	}
+	unique := make([]domain.LectureSummary, 0, len(newest))
+	for i, summary := range summaries {
+		key := domain.DatasetLectureKey(summary.Code, summary.Title, summary.OpenTerm, summary.Year)
+		if newest[key] == i {
+			unique = append(unique, summary)
+		}
+	}
+	return unique
+}
+
+func (uc *datasetUsecase) ready() error {
+	if uc == nil || uc.lectureRepo == nil {
+		return errors.New("lecture repository is not initialized")
+	}
+	if uc.datasetRepo == nil {
+		return errors.New("dataset repository is not initialized")
+	}
+	return nil
+}
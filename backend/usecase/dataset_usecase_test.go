@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+)
+
+func TestDatasetUsecaseExportAndMerge(t *testing.T) {
+	sourceRepo, _, sourceDB := newUsecaseTestRepository(t)
+	lectures := []domain.Lecture{
+		{University: "Test University", Department: "情報工学系", Title: "情報理論", Code: "CSC.T351", Year: 2025, OpenTerm: "3Q",
+			Teachers: []domain.Teacher{{Name: "山田 太郎"}}, Keywords: []string{"符号"}},
+		{University: "Test University", Department: "情報工学系", Title: "形式言語とオートマトン", Code: "CSC.T341", Year: 2025, OpenTerm: "2Q"},
+		{University: "Test University", Department: "情報工学系", Title: "情報理論", Code: "CSC.T351", Year: 2024, OpenTerm: "3Q", RelatedCourseCodes: []string{"CSC.T341"}},
+	}
+	if err := sourceRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	sourceDatasetRepo, err := sqlite.NewDatasetRepository(sourceDB)
+	if err != nil {
+		t.Fatalf("NewDatasetRepository returned error: %v", err)
+	}
+
+	var dataset bytes.Buffer
+	manifest, err := NewDatasetUsecase(sourceRepo, sourceDatasetRepo).ExportDataset(&dataset)
+	if err != nil {
+		t.Fatalf("ExportDataset returned error: %v", err)
+	}
+	if manifest.Lectures != 3 || len(manifest.Years) != 2 || manifest.Years[0] != 2024 || manifest.Universities[0] != "Test University" {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+
+	targetDB, err := sql.Open("sqlite", "file:dataset-target?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	targetDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { targetDB.Close() })
+	targetRepo, err := sqlite.NewLectureRepository(targetDB)
+	if err != nil {
+		t.Fatalf("NewLectureRepository returned error: %v", err)
+	}
+	// the target already has one of the lectures
+	if err := targetRepo.Creates([]domain.Lecture{{University: "Test University", Department: "情報工学系", Title: "情報理論", Code: "csc.t351", Year: 2025, OpenTerm: "3Q"}}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	targetDatasetRepo, err := sqlite.NewDatasetRepository(targetDB)
+	if err != nil {
+		t.Fatalf("NewDatasetRepository returned error: %v", err)
+	}
+	uc := NewDatasetUsecase(targetRepo, targetDatasetRepo)
+
+	raw := dataset.Bytes()
+	result, err := uc.ImportDataset(context.Background(), bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ImportDataset returned error: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 1 || result.Manifest.Lectures != 3 {
+		t.Errorf("unexpected import result: %+v", result)
+	}
+
+	again, err := uc.ImportDataset(context.Background(), bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ImportDataset returned error: %v", err)
+	}
+	if again.Imported != 0 || again.Skipped != 3 {
+		t.Errorf("expected a second import to add nothing, got %+v", again)
+	}
+
+	summaries, err := targetRepo.Search(domain.SearchQuery{})
+	if err != nil || len(summaries) != 3 {
+		t.Fatalf("expected 3 lectures in the target, got %+v, %v", summaries, err)
+	}
+	imported, err := targetRepo.FindAllByCode("CSC.T341")
+	if err != nil || len(imported) != 1 {
+		t.Fatalf("FindAllByCode returned %+v, %v", imported, err)
+	}
+	related := 0
+	for _, summary := range summaries {
+		lecture, err := targetRepo.FindByID(summary.ID)
+		if err != nil {
+			t.Fatalf("FindByID returned error: %v", err)
+		}
+		for _, id := range lecture.RelatedCourses {
+			if id == imported[0].ID {
+				related++
+			}
+		}
+	}
+	if related == 0 {
+		t.Errorf("expected related courses to be resolved after the import")
+	}
+}
+
+func TestDatasetUsecaseImportUpdatesNewerLectures(t *testing.T) {
+	sourceRepo, _, sourceDB := newUsecaseTestRepository(t)
+	if err := sourceRepo.Creates([]domain.Lecture{
+		{University: "Test University", Title: "情報理論", Code: "CSC.T351", Year: 2025, OpenTerm: "3Q", Abstract: "改訂版",
+			UpdatedAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{University: "Test University", Title: "形式言語とオートマトン", Code: "CSC.T341", Year: 2025, OpenTerm: "2Q",
+			UpdatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	sourceDatasetRepo, err := sqlite.NewDatasetRepository(sourceDB)
+	if err != nil {
+		t.Fatalf("NewDatasetRepository returned error: %v", err)
+	}
+	var dataset bytes.Buffer
+	if _, err := NewDatasetUsecase(sourceRepo, sourceDatasetRepo).ExportDataset(&dataset); err != nil {
+		t.Fatalf("ExportDataset returned error: %v", err)
+	}
+
+	targetDB, err := sql.Open("sqlite", "file:dataset-update-target?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	targetDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { targetDB.Close() })
+	targetRepo, err := sqlite.NewLectureRepository(targetDB)
+	if err != nil {
+		t.Fatalf("NewLectureRepository returned error: %v", err)
+	}
+	// the target has an older copy of one lecture and a newer copy of the other
+	if err := targetRepo.Creates([]domain.Lecture{
+		{University: "Test University", Title: "情報理論", Code: "CSC.T351", Year: 2025, OpenTerm: "3Q", Abstract: "初版",
+			UpdatedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{University: "Test University", Title: "形式言語とオートマトン", Code: "CSC.T341", Year: 2025, OpenTerm: "2Q",
+			UpdatedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	targetDatasetRepo, err := sqlite.NewDatasetRepository(targetDB)
+	if err != nil {
+		t.Fatalf("NewDatasetRepository returned error: %v", err)
+	}
+	uc := NewDatasetUsecase(targetRepo, targetDatasetRepo)
+
+	raw := dataset.Bytes()
+	result, err := uc.ImportDataset(context.Background(), bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ImportDataset returned error: %v", err)
+	}
+	if result.Imported != 0 || result.Updated != 1 || result.Skipped != 1 {
+		t.Errorf("unexpected import result: %+v", result)
+	}
+
+	again, err := uc.ImportDataset(context.Background(), bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ImportDataset returned error: %v", err)
+	}
+	if again.Updated != 0 || again.Skipped != 2 {
+		t.Errorf("expected a second import to update nothing, got %+v", again)
+	}
+
+	stored, err := targetRepo.FindAllByCode("CSC.T351")
+	if err != nil {
+		t.Fatalf("FindAllByCode returned error: %v", err)
+	}
+	newest := stored[0]
+	for _, lecture := range stored {
+		if lecture.ID > newest.ID {
+			newest = lecture
+		}
+	}
+	if newest.Abstract != "改訂版" {
+		t.Errorf("expected the newer copy to be stored, got %+v", newest)
+	}
+}
+
+func TestDatasetUsecaseKeepsNewestCopyOfRescrapedLectures(t *testing.T) {
+	stale := domain.Lecture{University: "Test University", Title: "情報理論", Code: "CSC.T351", Year: 2025, OpenTerm: "3Q",
+		Abstract: "old", UpdatedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)}
+	rescraped := stale
+	rescraped.Abstract = "new"
+	rescraped.UpdatedAt = time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	sourceRepo, _, sourceDB := newUsecaseTestRepository(t)
+	if err := sourceRepo.Creates([]domain.Lecture{stale, rescraped}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	sourceDatasetRepo, err := sqlite.NewDatasetRepository(sourceDB)
+	if err != nil {
+		t.Fatalf("NewDatasetRepository returned error: %v", err)
+	}
+	var exported bytes.Buffer
+	manifest, err := NewDatasetUsecase(sourceRepo, sourceDatasetRepo).ExportDataset(&exported)
+	if err != nil {
+		t.Fatalf("ExportDataset returned error: %v", err)
+	}
+	if manifest.Lectures != 1 {
+		t.Errorf("expected the lecture to be exported once, got %d", manifest.Lectures)
+	}
+
+	// datasets written before exports kept only the newest row may hold both copies in either order
+	written := func(lectures ...domain.Lecture) []byte {
+		var b bytes.Buffer
+		writer, err := domain.NewDatasetWriter(&b, domain.DatasetManifest{Lectures: len(lectures)})
+		if err != nil {
+			t.Fatalf("NewDatasetWriter returned error: %v", err)
+		}
+		for _, lecture := range lectures {
+			if err := writer.Write(lecture); err != nil {
+				t.Fatalf("Write returned error: %v", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+		return b.Bytes()
+	}
+
+	cases := []struct {
+		name    string
+		dataset []byte
+		skipped int
+	}{
+		{name: "export", dataset: exported.Bytes(), skipped: 0},
+		{name: "stale first", dataset: written(stale, rescraped), skipped: 1},
+		{name: "rescraped first", dataset: written(rescraped, stale), skipped: 1},
+	}
+	for i, c := range cases {
+		targetDB, err := sql.Open("sqlite", fmt.Sprintf("file:dataset-newest-%d?mode=memory&cache=shared", i))
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		targetDB.SetMaxOpenConns(1)
+		t.Cleanup(func() { targetDB.Close() })
+		targetRepo, err := sqlite.NewLectureRepository(targetDB)
+		if err != nil {
+			t.Fatalf("NewLectureRepository returned error: %v", err)
+		}
+		targetDatasetRepo, err := sqlite.NewDatasetRepository(targetDB)
+		if err != nil {
+			t.Fatalf("NewDatasetRepository returned error: %v", err)
+		}
+
+		result, err := NewDatasetUsecase(targetRepo, targetDatasetRepo).ImportDataset(context.Background(), bytes.NewReader(c.dataset))
+		if err != nil {
+			t.Fatalf("%s: ImportDataset returned error: %v", c.name, err)
+		}
+		if result.Imported != 1 || result.Updated != 0 || result.Skipped != c.skipped {
+			t.Errorf("%s: unexpected import result: %+v", c.name, result)
+		}
+		stored, err := targetRepo.FindAllByCode("CSC.T351")
+		if err != nil || len(stored) != 1 || stored[0].Abstract != "new" {
+			t.Errorf("%s: expected only the re-scraped copy to be stored, got %+v, %v", c.name, stored, err)
+		}
+	}
+}
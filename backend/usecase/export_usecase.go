@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// ExportFormat names a file format lectures can be exported to.
+type ExportFormat string
+
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+	ExportFormatXLSX  ExportFormat = "xlsx"
+)
+
+// LectureExportRequest selects the lectures and columns to export. Lectures are taken from LectureIDs in
+// the given order, or from the results of Query when LectureIDs is empty. Empty Columns selects the
+// default columns.
+type LectureExportRequest struct {
+	LectureIDs []int
+	Query      domain.SearchQuery
+	Columns    []string
+}
+
+// ExportUsecase writes lectures to files for use in spreadsheets and scripts.
+type ExportUsecase interface {
+	ExportLectures(w io.Writer, format ExportFormat, request LectureExportRequest) (int, error)
+}
+
+type exportUsecase struct {
+	lectureRepo domain.LectureRepository
+}
+
+func NewExportUsecase(lectureRepo domain.LectureRepository) ExportUsecase {
+	return &exportUsecase{
+		lectureRepo: lectureRepo,
+	}
+}
+
+// ExportLectures writes the selected lectures in full detail and returns how many were written.
+func (uc *exportUsecase) ExportLectures(w io.Writer, format ExportFormat, request LectureExportRequest) (int, error) {
+	if uc == nil || uc.lectureRepo == nil {
+		return 0, errors.New("lecture repository is not initialized")
+	}
+
+	columns, err := domain.SelectExportColumns(request.Columns)
+	if err != nil {
+		return 0, err
+	}
+	write, err := exportWriter(format)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := request.LectureIDs
+	if len(ids) == 0 {
+		summaries, err := uc.lectureRepo.Search(request.Query)
+		if err != nil {
+			return 0, err
+		}
+		for _, summary := range summaries {
+			ids = append(ids, summary.ID)
+		}
+	}
+
+	lectures := make([]domain.Lecture, 0, len(ids))
+	for _, id := range ids {
+		lecture, err := uc.lectureRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		if lecture == nil {
+			return 0, fmt.Errorf("lecture %d not found", id)
+		}
+		lectures = append(lectures, *lecture)
+	}
+
+	if err := write(w, lectures, columns); err != nil {
+		return 0, fmt.Errorf("write %s export: %w", format, err)
+	}
+	return len(lectures), nil
+}
+
+func exportWriter(format ExportFormat) (func(io.Writer, []domain.Lecture, []domain.ExportColumn) error, error) {
+	switch format {
+	case ExportFormatCSV, "":
+		return domain.WriteLecturesCSV, nil
+	case ExportFormatJSONL:
+		return domain.WriteLecturesJSONL, nil
+	case ExportFormatXLSX:
+		return domain.WriteLecturesXLSX, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+func TestExportUsecaseExportLectures(t *testing.T) {
+	lectureRepo, _, _ := newUsecaseTestRepository(t)
+	lectures := []domain.Lecture{
+		{University: "Test University", Department: "情報工学系", Title: "情報理論", Code: "CSC.T351", Year: 2025, Keywords: []string{"符号"}},
+		{University: "Test University", Department: "数学系", Title: "代数学", Code: "MTH.A201", Year: 2025},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	uc := NewExportUsecase(lectureRepo)
+
+	var b bytes.Buffer
+	count, err := uc.ExportLectures(&b, ExportFormatJSONL, LectureExportRequest{
+		Query:   domain.SearchQuery{Departments: []string{"情報工学系"}},
+		Columns: []string{"code", "keywords"},
+	})
+	if err != nil {
+		t.Fatalf("ExportLectures returned error: %v", err)
+	}
+	if count != 1 || b.String() != "{\"code\":\"CSC.T351\",\"keywords\":[\"符号\"]}\n" {
+		t.Errorf("unexpected export of the search results: %d %q", count, b.String())
+	}
+
+	b.Reset()
+	count, err = uc.ExportLectures(&b, ExportFormatCSV, LectureExportRequest{LectureIDs: []int{2, 1}, Columns: []string{"code"}})
+	if err != nil {
+		t.Fatalf("ExportLectures returned error: %v", err)
+	}
+	if count != 2 || b.String() != "\ufeff科目コード\nMTH.A201\nCSC.T351\n" {
+		t.Errorf("expected lectures in the given order, got %d %q", count, b.String())
+	}
+
+	if _, err := uc.ExportLectures(&b, "pdf", LectureExportRequest{}); err == nil || !strings.Contains(err.Error(), "pdf") {
+		t.Errorf("expected an unknown format error, got %v", err)
+	}
+	if _, err := uc.ExportLectures(&b, ExportFormatCSV, LectureExportRequest{LectureIDs: []int{99}}); err == nil {
+		t.Errorf("expected a missing lecture error")
+	}
+}
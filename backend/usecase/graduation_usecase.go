@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// requirementSuggestions is how many candidate courses are suggested per unmet requirement.
+const requirementSuggestions = 10
+
+// GraduationUsecase manages graduation requirement sets and checks course plans against them.
+type GraduationUsecase interface {
+	SaveRequirementSet(name, source string) (*domain.RequirementSet, error)
+	ListRequirementSets() ([]domain.RequirementSet, error)
+	DeleteRequirementSet(name string) error
+	CheckRequirements(name string, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error)
+	CheckRequirementSource(source string, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error)
+}
+
+type graduationUsecase struct {
+	setRepo     domain.RequirementSetRepository
+	lectureRepo domain.LectureRepository
+}
+
+func NewGraduationUsecase(setRepo domain.RequirementSetRepository, lectureRepo domain.LectureRepository) GraduationUsecase {
+	return &graduationUsecase{
+		setRepo:     setRepo,
+		lectureRepo: lectureRepo,
+	}
+}
+
+// SaveRequirementSet parses source and stores it under name, rejecting sources with errors.
+func (uc *graduationUsecase) SaveRequirementSet(name, source string) (*domain.RequirementSet, error) {
+	if uc == nil || uc.setRepo == nil {
+		return nil, errors.New("requirement set repository is not initialized")
+	}
+
+	set, err := domain.ParseRequirementSet(name, source)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setRepo.Save(set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (uc *graduationUsecase) ListRequirementSets() ([]domain.RequirementSet, error) {
+	if uc == nil || uc.setRepo == nil {
+		return nil, errors.New("requirement set repository is not initialized")
+	}
+
+	return uc.setRepo.FindAll()
+}
+
+func (uc *graduationUsecase) DeleteRequirementSet(name string) error {
+	if uc == nil || uc.setRepo == nil {
+		return errors.New("requirement set repository is not initialized")
+	}
+
+	return uc.setRepo.Delete(name)
+}
+
+// CheckRequirements evaluates completed and planned lectures against a stored set, suggesting
+// lectures of year that would fill unmet requirements.
+func (uc *graduationUsecase) CheckRequirements(name string, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error) {
+	if uc == nil || uc.setRepo == nil {
+		return nil, errors.New("requirement set repository is not initialized")
+	}
+
+	set, err := uc.setRepo.FindByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, fmt.Errorf("requirement set %q not found", name)
+	}
+	return uc.evaluate(set, completedIDs, plannedIDs, year)
+}
+
+// CheckRequirementSource evaluates against an unsaved requirement source, such as one being edited.
+func (uc *graduationUsecase) CheckRequirementSource(source string, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error) {
+	set, err := domain.ParseRequirementSet("", source)
+	if err != nil {
+		return nil, err
+	}
+	return uc.evaluate(set, completedIDs, plannedIDs, year)
+}
+
+func (uc *graduationUsecase) evaluate(set *domain.RequirementSet, completedIDs, plannedIDs []int, year int) (*domain.RequirementReport, error) {
+	if uc == nil || uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	completed, err := uc.requirementCourses(completedIDs)
+	if err != nil {
+		return nil, err
+	}
+	planned, err := uc.requirementCourses(plannedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]domain.RequirementCourse, 0)
+	if year > 0 {
+		summaries, err := uc.lectureRepo.Search(domain.SearchQuery{Year: year})
+		if err != nil {
+			return nil, fmt.Errorf("search candidate lectures: %w", err)
+		}
+		for _, summary := range summaries {
+			candidates = append(candidates, domain.CourseFromSummary(summary))
+		}
+	}
+
+	report := domain.EvaluateRequirements(set, completed, planned, candidates, requirementSuggestions)
+	return &report, nil
+}
+
+func (uc *graduationUsecase) requirementCourses(lectureIDs []int) ([]domain.RequirementCourse, error) {
+	courses := make([]domain.RequirementCourse, 0, len(lectureIDs))
+	for _, id := range lectureIDs {
+		lecture, err := uc.lectureRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if lecture == nil {
+			return nil, fmt.Errorf("lecture %d not found", id)
+		}
+		courses = append(courses, domain.CourseFromLecture(*lecture))
+	}
+	return courses, nil
+}
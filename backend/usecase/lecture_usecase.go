@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/kavos113/desy/backend/domain"
 )
@@ -10,8 +11,13 @@ import (
 // LectureUsecase defines application logic related to lectures.
 type LectureUsecase interface {
 	SearchLectures(query domain.SearchQuery) ([]domain.LectureSummary, error)
-	GetLectureDetails(lectureID int) (*domain.Lecture, error)
+	GetLectureDetails(lectureID int, lang string) (*domain.Lecture, error)
 	MigrateRelatedCourses(ctx context.Context) (int, error)
+	MigrateCourseReferences(ctx context.Context) (int, error)
+	GetLectureSessions(lectureIDs []int, kinds []domain.LecturePlanKind) ([]domain.LectureSession, error)
+	GetOfficeHoursOn(lectureID int, day domain.DayOfWeek) ([]domain.OfficeHour, error)
+	GetCourseOfferings(code string) ([]domain.CourseOffering, error)
+	CompareCourseOfferings(code string) ([]domain.OfferingComparison, error)
 }
 
 // lectureUsecase is a concrete implementation of LectureUsecase.
@@ -35,13 +41,19 @@ func (uc *lectureUsecase) SearchLectures(query domain.SearchQuery) ([]domain.Lec
 	return uc.lectureRepo.Search(query)
 }
 
-// GetLectureDetails retrieves a full lecture aggregate by its identifier.
-func (uc *lectureUsecase) GetLectureDetails(lectureID int) (*domain.Lecture, error) {
+// GetLectureDetails retrieves a full lecture aggregate by its identifier, shown in lang ("ja" or "en").
+// An empty lang keeps the Japanese fields as the main ones.
+func (uc *lectureUsecase) GetLectureDetails(lectureID int, lang string) (*domain.Lecture, error) {
 	if uc.lectureRepo == nil {
 		return nil, errors.New("lecture repository is not initialized")
 	}
 
-	return uc.lectureRepo.FindByID(lectureID)
+	lecture, err := uc.lectureRepo.FindByID(lectureID)
+	if err != nil || lecture == nil {
+		return lecture, err
+	}
+	localized := lecture.Localized(lang)
+	return &localized, nil
 }
 
 // MigrateRelatedCourses resolves related course IDs based on stored course codes.
@@ -52,3 +64,72 @@ func (uc *lectureUsecase) MigrateRelatedCourses(ctx context.Context) (int, error
 
 	return uc.lectureRepo.MigrateRelatedCourses(ctx)
 }
+
+// MigrateCourseReferences resolves courses named in prerequisite texts into prerequisite and recommended links.
+func (uc *lectureUsecase) MigrateCourseReferences(ctx context.Context) (int, error) {
+	if uc.lectureRepo == nil {
+		return 0, errors.New("lecture repository is not initialized")
+	}
+
+	return uc.lectureRepo.MigrateCourseReferences(ctx)
+}
+
+// GetLectureSessions retrieves the planned sessions of the given lectures, such as their exams.
+func (uc *lectureUsecase) GetLectureSessions(lectureIDs []int, kinds []domain.LecturePlanKind) ([]domain.LectureSession, error) {
+	if uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	return uc.lectureRepo.FindSessions(lectureIDs, kinds)
+}
+
+// GetOfficeHoursOn returns the office hours of a lecture held on day.
+// Lectures stored before office hours were structured are parsed from their text.
+func (uc *lectureUsecase) GetOfficeHoursOn(lectureID int, day domain.DayOfWeek) ([]domain.OfficeHour, error) {
+	if uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	lecture, err := uc.lectureRepo.FindByID(lectureID)
+	if err != nil {
+		return nil, err
+	}
+	if lecture == nil {
+		return nil, fmt.Errorf("lecture %d not found", lectureID)
+	}
+
+	slots := lecture.OfficeHourSlots
+	if len(slots) == 0 {
+		slots = domain.ParseOfficeHours(lecture.OfficeHours)
+	}
+	return domain.OfficeHoursOn(slots, day), nil
+}
+
+// GetCourseOfferings returns every year's offering of the courses with the given code, oldest first.
+// Offerings with the same CourseKey are the same course.
+func (uc *lectureUsecase) GetCourseOfferings(code string) ([]domain.CourseOffering, error) {
+	if uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	lectures, err := uc.lectureRepo.FindAllByCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	offerings := make([]domain.CourseOffering, 0, len(lectures))
+	for _, lecture := range lectures {
+		offerings = append(offerings, domain.NewCourseOffering(lecture))
+	}
+	return offerings, nil
+}
+
+// CompareCourseOfferings lists the changes of the courses with the given code from each year to the next.
+func (uc *lectureUsecase) CompareCourseOfferings(code string) ([]domain.OfferingComparison, error) {
+	offerings, err := uc.GetCourseOfferings(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.CompareConsecutiveOfferings(offerings), nil
+}
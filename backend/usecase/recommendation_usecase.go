@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// defaultRecommendationLimit is used when no positive limit is given.
+const defaultRecommendationLimit = 10
+
+// RecommendationUsecase suggests lectures similar to a set of lectures.
+type RecommendationUsecase interface {
+	RecommendLectures(lectureIDs []int, limit int, filters domain.SearchQuery) ([]domain.Recommendation, error)
+	RefreshIndex(ctx context.Context) (int, error)
+}
+
+type recommendationUsecase struct {
+	recommendationRepo domain.RecommendationRepository
+	lectureRepo        domain.LectureRepository
+
+	mu    sync.Mutex
+	index *domain.RecommendationIndex
+}
+
+func NewRecommendationUsecase(recommendationRepo domain.RecommendationRepository, lectureRepo domain.LectureRepository) RecommendationUsecase {
+	return &recommendationUsecase{
+		recommendationRepo: recommendationRepo,
+		lectureRepo:        lectureRepo,
+	}
+}
+
+// RecommendLectures returns lectures similar to the given ones with the reasons each was suggested.
+// Suggestions are limited to lectures matching filters; an empty query allows every lecture.
+func (uc *recommendationUsecase) RecommendLectures(lectureIDs []int, limit int, filters domain.SearchQuery) ([]domain.Recommendation, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+
+	index, err := uc.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var allow func(int) bool
+	if !reflect.DeepEqual(filters, domain.SearchQuery{}) {
+		candidates, err := uc.lectureRepo.Search(filters)
+		if err != nil {
+			return nil, err
+		}
+		allowed := make(map[int]struct{}, len(candidates))
+		for _, candidate := range candidates {
+			allowed[candidate.ID] = struct{}{}
+		}
+		allow = func(id int) bool {
+			_, ok := allowed[id]
+			return ok
+		}
+	}
+
+	return index.Recommend(lectureIDs, limit, allow), nil
+}
+
+// RefreshIndex updates the index for lectures added or changed since the last refresh.
+func (uc *recommendationUsecase) RefreshIndex(ctx context.Context) (int, error) {
+	if err := uc.ready(); err != nil {
+		return 0, err
+	}
+
+	changed, err := uc.recommendationRepo.RefreshRecommendationIndex(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if changed > 0 {
+		uc.mu.Lock()
+		uc.index = nil
+		uc.mu.Unlock()
+	}
+	return changed, nil
+}
+
+// loadIndex returns the cached index, refreshing and loading it on first use.
+func (uc *recommendationUsecase) loadIndex() (*domain.RecommendationIndex, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.index != nil {
+		return uc.index, nil
+	}
+
+	if _, err := uc.recommendationRepo.RefreshRecommendationIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	documents, err := uc.recommendationRepo.FindRecommendationDocuments()
+	if err != nil {
+		return nil, err
+	}
+	uc.index = domain.NewRecommendationIndex(documents)
+	return uc.index, nil
+}
+
+func (uc *recommendationUsecase) ready() error {
+	if uc == nil || uc.recommendationRepo == nil {
+		return errors.New("recommendation repository is not initialized")
+	}
+	if uc.lectureRepo == nil {
+		return errors.New("lecture repository is not initialized")
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// RefreshOutcome reports one refresh of the current academic year. Stale counts the list rows found
+// changed and Updated the lectures stored from them. Skipped is set when another scrape was running;
+// Error holds the message of a failed refresh.
+type RefreshOutcome struct {
+	SiteID     string
+	Year       int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Stale      int
+	Updated    int
+	Skipped    bool
+	Error      string
+}
+
+// RefreshStatus describes the background refresh for display. NextRun is zero while no schedule runs.
+type RefreshStatus struct {
+	Setting     *domain.RefreshSetting
+	NextRun     time.Time
+	LastOutcome *RefreshOutcome
+}
+
+// RefreshUsecase keeps the syllabus of the current academic year up to date on a cron-style schedule.
+// A refresh re-reads the list pages and only fetches the details whose list entry changed.
+type RefreshUsecase interface {
+	GetStatus() (*RefreshStatus, error)
+	SaveSetting(siteID, spec string) (*domain.RefreshSetting, error)
+	DeleteSetting() error
+	RefreshCurrentYear(ctx context.Context, siteID string) (RefreshOutcome, error)
+	RunSchedule(ctx context.Context, schedule domain.RefreshSchedule, siteID string, notify func(RefreshOutcome)) error
+}
+
+type refreshUsecase struct {
+	settingRepo    domain.RefreshSettingRepository
+	scraperUsecase ScraperUsecase
+	scrapeLock     *sync.Mutex
+
+	mu          sync.Mutex
+	nextRun     time.Time
+	lastOutcome *RefreshOutcome
+}
+
+// NewRefreshUsecase creates a refresh usecase. scrapeLock is shared with every other user of the scraper
+// so that a scheduled refresh never overlaps a manual scrape; nil uses a lock of its own.
+func NewRefreshUsecase(settingRepo domain.RefreshSettingRepository, scraperUsecase ScraperUsecase, scrapeLock *sync.Mutex) RefreshUsecase {
+	if scrapeLock == nil {
+		scrapeLock = &sync.Mutex{}
+	}
+	return &refreshUsecase{
+		settingRepo:    settingRepo,
+		scraperUsecase: scraperUsecase,
+		scrapeLock:     scrapeLock,
+	}
+}
+
+func (uc *refreshUsecase) GetStatus() (*RefreshStatus, error) {
+	if uc == nil || uc.settingRepo == nil {
+		return nil, errors.New("refresh setting repository is not initialized")
+	}
+
+	setting, err := uc.settingRepo.Find()
+	if err != nil {
+		return nil, err
+	}
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	status := &RefreshStatus{Setting: setting, NextRun: uc.nextRun}
+	if uc.lastOutcome != nil {
+		outcome := *uc.lastOutcome
+		status.LastOutcome = &outcome
+	}
+	return status, nil
+}
+
+// SaveSetting validates spec and stores it as the schedule of siteID; an empty siteID selects the default site.
+func (uc *refreshUsecase) SaveSetting(siteID, spec string) (*domain.RefreshSetting, error) {
+	if uc == nil || uc.settingRepo == nil {
+		return nil, errors.New("refresh setting repository is not initialized")
+	}
+
+	schedule, err := domain.ParseRefreshSchedule(spec)
+	if err != nil {
+		return nil, err
+	}
+	setting := domain.RefreshSetting{SiteID: strings.TrimSpace(siteID), Spec: schedule.Spec, UpdatedAt: time.Now()}
+	if err := uc.settingRepo.Save(setting); err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (uc *refreshUsecase) DeleteSetting() error {
+	if uc == nil || uc.settingRepo == nil {
+		return errors.New("refresh setting repository is not initialized")
+	}
+
+	return uc.settingRepo.Delete()
+}
+
+// RefreshCurrentYear checks the academic year containing today for updates and stores the changed
+// lectures, unless another scrape holds the lock.
+// The returned outcome is also recorded as the last outcome, with the error message of a failed refresh.
+func (uc *refreshUsecase) RefreshCurrentYear(ctx context.Context, siteID string) (RefreshOutcome, error) {
+	if uc == nil || uc.scraperUsecase == nil {
+		return RefreshOutcome{}, errors.New("scraper usecase is not initialized")
+	}
+
+	started := time.Now()
+	outcome := RefreshOutcome{SiteID: siteID, Year: domain.AcademicYear(started), StartedAt: started}
+	if !uc.scrapeLock.TryLock() {
+		outcome.Skipped = true
+		outcome.FinishedAt = started
+		uc.record(outcome)
+		return outcome, nil
+	}
+	check, err := uc.scraperUsecase.CheckForUpdates(ctx, siteID, outcome.Year, false)
+	uc.scrapeLock.Unlock()
+
+	outcome.FinishedAt = time.Now()
+	if err != nil {
+		outcome.Error = err.Error()
+	} else {
+		outcome.Stale = len(check.Stale)
+		outcome.Updated = len(check.Lectures)
+	}
+	uc.record(outcome)
+	return outcome, err
+}
+
+// RunSchedule refreshes the current year of siteID at every time matching schedule and passes each outcome
+// to notify. It returns the context error once ctx is cancelled; a failed refresh does not stop it.
+func (uc *refreshUsecase) RunSchedule(ctx context.Context, schedule domain.RefreshSchedule, siteID string, notify func(RefreshOutcome)) error {
+	if uc == nil || uc.scraperUsecase == nil {
+		return errors.New("scraper usecase is not initialized")
+	}
+	defer uc.setNextRun(time.Time{})
+
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			return fmt.Errorf("schedule %q never runs", schedule.Spec)
+		}
+		uc.setNextRun(next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		outcome, _ := uc.RefreshCurrentYear(ctx, siteID)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if notify != nil {
+			notify(outcome)
+		}
+	}
+}
+
+func (uc *refreshUsecase) record(outcome RefreshOutcome) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.lastOutcome = &outcome
+}
+
+func (uc *refreshUsecase) setNextRun(next time.Time) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.nextRun = next
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+type stubRefreshScraper struct {
+	ScraperUsecase
+	years []int
+	err   error
+}
+
+func (s *stubRefreshScraper) CheckForUpdates(_ context.Context, siteID string, year int, dryRun bool) (*UpdateCheck, error) {
+	s.years = append(s.years, year)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &UpdateCheck{
+		SiteID:   siteID,
+		Year:     year,
+		DryRun:   dryRun,
+		Stale:    []StaleLecture{{Code: "MTH.A201"}, {Code: "MTH.A202"}, {Code: "MTH.A203"}},
+		Lectures: []domain.Lecture{{Code: "MTH.A201"}, {Code: "MTH.A202"}},
+	}, nil
+}
+
+func TestRefreshCurrentYear(t *testing.T) {
+	scraper := &stubRefreshScraper{}
+	lock := &sync.Mutex{}
+	uc := NewRefreshUsecase(nil, scraper, lock)
+
+	outcome, err := uc.RefreshCurrentYear(context.Background(), "isct")
+	if err != nil {
+		t.Fatalf("RefreshCurrentYear returned error: %v", err)
+	}
+	year := domain.AcademicYear(time.Now())
+	if outcome.Year != year || outcome.Stale != 3 || outcome.Updated != 2 || outcome.Skipped || len(scraper.years) != 1 || scraper.years[0] != year {
+		t.Fatalf("unexpected outcome %+v for scraped years %v", outcome, scraper.years)
+	}
+
+	lock.Lock()
+	outcome, err = uc.RefreshCurrentYear(context.Background(), "isct")
+	lock.Unlock()
+	if err != nil || !outcome.Skipped || len(scraper.years) != 1 {
+		t.Fatalf("a refresh during another scrape should be skipped, got %+v, %v", outcome, err)
+	}
+
+	scraper.err = errors.New("fetch failed")
+	outcome, err = uc.RefreshCurrentYear(context.Background(), "isct")
+	if err == nil || outcome.Error != "fetch failed" {
+		t.Fatalf("expected the failure to be reported, got %+v, %v", outcome, err)
+	}
+	if !lock.TryLock() {
+		t.Fatalf("the scrape lock should be released after a failure")
+	}
+	lock.Unlock()
+}
+
+func TestRunScheduleStopsOnCancel(t *testing.T) {
+	uc := NewRefreshUsecase(nil, &stubRefreshScraper{}, nil)
+	schedule, err := domain.ParseRefreshSchedule("@daily")
+	if err != nil {
+		t.Fatalf("ParseRefreshSchedule returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- uc.RunSchedule(ctx, schedule, "isct", nil) }()
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("RunSchedule did not stop after cancel")
+	}
+}
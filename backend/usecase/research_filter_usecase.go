@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+type ResearchFilterUsecase interface {
+	ListRules() ([]domain.ResearchFilterRule, error)
+	CreateRule(rule domain.ResearchFilterRule) (*domain.ResearchFilterRule, error)
+	UpdateRule(rule domain.ResearchFilterRule) error
+	DeleteRule(id int) error
+}
+
+type researchFilterUsecase struct {
+	ruleRepo domain.ResearchFilterRepository
+}
+
+func NewResearchFilterUsecase(ruleRepo domain.ResearchFilterRepository) ResearchFilterUsecase {
+	return &researchFilterUsecase{
+		ruleRepo: ruleRepo,
+	}
+}
+
+func (uc *researchFilterUsecase) ListRules() ([]domain.ResearchFilterRule, error) {
+	if uc == nil || uc.ruleRepo == nil {
+		return nil, errors.New("research filter repository is not initialized")
+	}
+
+	return uc.ruleRepo.FindAll()
+}
+
+func (uc *researchFilterUsecase) CreateRule(rule domain.ResearchFilterRule) (*domain.ResearchFilterRule, error) {
+	if uc == nil || uc.ruleRepo == nil {
+		return nil, errors.New("research filter repository is not initialized")
+	}
+
+	if err := uc.ruleRepo.Create(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (uc *researchFilterUsecase) UpdateRule(rule domain.ResearchFilterRule) error {
+	if uc == nil || uc.ruleRepo == nil {
+		return errors.New("research filter repository is not initialized")
+	}
+
+	return uc.ruleRepo.Update(&rule)
+}
+
+func (uc *researchFilterUsecase) DeleteRule(id int) error {
+	if uc == nil || uc.ruleRepo == nil {
+		return errors.New("research filter repository is not initialized")
+	}
+
+	return uc.ruleRepo.Delete(id)
+}
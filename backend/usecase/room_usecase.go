@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+type RoomUsecase interface {
+	ListRooms() ([]domain.Room, error)
+	FindFreeRooms(year int, semester domain.Semester, day domain.DayOfWeek, period domain.Period, building string) ([]domain.Room, error)
+	GetRoomSchedule(roomID int, year int) (*domain.RoomSchedule, error)
+	NormalizeRooms(ctx context.Context) (int, error)
+}
+
+type roomUsecase struct {
+	roomRepo domain.RoomRepository
+}
+
+func NewRoomUsecase(roomRepo domain.RoomRepository) RoomUsecase {
+	return &roomUsecase{
+		roomRepo: roomRepo,
+	}
+}
+
+func (uc *roomUsecase) ListRooms() ([]domain.Room, error) {
+	if uc == nil || uc.roomRepo == nil {
+		return nil, errors.New("room repository is not initialized")
+	}
+
+	return uc.roomRepo.FindAll()
+}
+
+// FindFreeRooms lists the rooms no regular timetable of year occupies in the slot. A zero year means the
+// newest year with room timetables. A year without any is an error rather than every room being free.
+func (uc *roomUsecase) FindFreeRooms(year int, semester domain.Semester, day domain.DayOfWeek, period domain.Period, building string) ([]domain.Room, error) {
+	if uc == nil || uc.roomRepo == nil {
+		return nil, errors.New("room repository is not initialized")
+	}
+	if semester.Index() < 0 {
+		return nil, fmt.Errorf("invalid semester: %q", semester)
+	}
+	if day.Index() < 0 {
+		return nil, fmt.Errorf("invalid day of week: %q", day)
+	}
+	if period < domain.Period1 || period > domain.Period12 {
+		return nil, fmt.Errorf("invalid period: %d", period)
+	}
+
+	years, err := uc.roomRepo.FindBookedYears()
+	if err != nil {
+		return nil, err
+	}
+	if len(years) == 0 {
+		return nil, errors.New("no room timetables are stored; scrape the syllabus first")
+	}
+	if year == 0 {
+		year = years[0]
+	} else if !slices.Contains(years, year) {
+		return nil, fmt.Errorf("no room timetables are stored for %d", year)
+	}
+
+	return uc.roomRepo.FindFree(year, semester, day, period, normalizeBuildingFilter(building))
+}
+
+func (uc *roomUsecase) GetRoomSchedule(roomID int, year int) (*domain.RoomSchedule, error) {
+	if uc == nil || uc.roomRepo == nil {
+		return nil, errors.New("room repository is not initialized")
+	}
+
+	room, err := uc.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, fmt.Errorf("room not found: %d", roomID)
+	}
+
+	bookings, err := uc.roomRepo.FindBookings(roomID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := domain.NewRoomSchedule(*room, year, bookings)
+	return &schedule, nil
+}
+
+func (uc *roomUsecase) NormalizeRooms(ctx context.Context) (int, error) {
+	if uc == nil || uc.roomRepo == nil {
+		return 0, errors.New("room repository is not initialized")
+	}
+
+	return uc.roomRepo.NormalizeRooms(ctx)
+}
+
+// normalizeBuildingFilter maps user input such as "西5号館" or "w5" onto the stored building code.
+func normalizeBuildingFilter(building string) string {
+	building = strings.TrimSpace(building)
+	if building == "" {
+		return ""
+	}
+	if normalized := domain.NormalizeRoom(building).Building; normalized != "" {
+		return normalized
+	}
+	return strings.ToUpper(building)
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+)
+
+func TestRoomUsecaseFindFreeRoomsDefaultsToLatestYear(t *testing.T) {
+	lectureRepo, _, db := newUsecaseTestRepository(t)
+	roomRepo, err := sqlite.NewRoomRepository(db)
+	if err != nil {
+		t.Fatalf("NewRoomRepository returned error: %v", err)
+	}
+	uc := NewRoomUsecase(roomRepo)
+
+	if _, err := uc.FindFreeRooms(0, domain.SemesterSpring, domain.DayOfWeekMonday, domain.Period1, ""); err == nil {
+		t.Fatalf("expected an error without room timetables")
+	}
+
+	slot := func(room string) []domain.TimeTable {
+		return []domain.TimeTable{{Semester: domain.SemesterSpring, DayOfWeek: domain.DayOfWeekMonday, Period: domain.Period1, Room: domain.Room{Name: room}}}
+	}
+	if err := lectureRepo.Creates([]domain.Lecture{
+		{University: "Test University", Title: "線形代数", Code: "MTH.A201", Year: 2025, Timetables: slot("W5-104")},
+		{University: "Test University", Title: "解析学", Code: "MTH.A202", Year: 2024, Timetables: slot("W5-105")},
+	}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	free, err := uc.FindFreeRooms(0, domain.SemesterSpring, domain.DayOfWeekMonday, domain.Period1, "")
+	if err != nil {
+		t.Fatalf("FindFreeRooms returned error: %v", err)
+	}
+	if len(free) != 1 || free[0].Name != "W5-105" {
+		t.Errorf("expected only W5-105 to be free in 2025, got %+v", free)
+	}
+
+	free, err = uc.FindFreeRooms(2024, domain.SemesterSpring, domain.DayOfWeekMonday, domain.Period1, "")
+	if err != nil {
+		t.Fatalf("FindFreeRooms returned error: %v", err)
+	}
+	if len(free) != 1 || free[0].Name != "W5-104" {
+		t.Errorf("expected only W5-104 to be free in 2024, got %+v", free)
+	}
+
+	if _, err := uc.FindFreeRooms(2023, domain.SemesterSpring, domain.DayOfWeekMonday, domain.Period1, ""); err == nil {
+		t.Errorf("expected an error for a year without room timetables")
+	}
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// SavedSearchUsecase keeps named searches and reports how their results change between scrapes.
+type SavedSearchUsecase interface {
+	SaveSearch(name string, query domain.SearchQuery) (*domain.SavedSearch, error)
+	ListSearches() ([]domain.SavedSearch, error)
+	DeleteSearch(id int) error
+	RunSearch(id int) ([]domain.LectureSummary, error)
+	CheckSearches() ([]domain.SearchNotification, error)
+	ListNotifications(unreadOnly bool) ([]domain.SearchNotification, error)
+	MarkNotificationRead(id int) error
+}
+
+type savedSearchUsecase struct {
+	searchRepo  domain.SavedSearchRepository
+	lectureRepo domain.LectureRepository
+}
+
+func NewSavedSearchUsecase(searchRepo domain.SavedSearchRepository, lectureRepo domain.LectureRepository) SavedSearchUsecase {
+	return &savedSearchUsecase{
+		searchRepo:  searchRepo,
+		lectureRepo: lectureRepo,
+	}
+}
+
+// SaveSearch stores the query under name, replacing a search of the same name. The current result is
+// recorded so that the next check only reports what changes from now on.
+func (uc *savedSearchUsecase) SaveSearch(name string, query domain.SearchQuery) (*domain.SavedSearch, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+
+	search := &domain.SavedSearch{Name: name, Query: query}
+	if err := uc.searchRepo.Save(search); err != nil {
+		return nil, err
+	}
+	entries, err := uc.evaluate(*search)
+	if err != nil {
+		return nil, err
+	}
+	search.CheckedAt = time.Now()
+	if err := uc.searchRepo.ReplaceResults(search.ID, entries, search.CheckedAt); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (uc *savedSearchUsecase) ListSearches() ([]domain.SavedSearch, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+	return uc.searchRepo.FindAll()
+}
+
+func (uc *savedSearchUsecase) DeleteSearch(id int) error {
+	if err := uc.ready(); err != nil {
+		return err
+	}
+	return uc.searchRepo.Delete(id)
+}
+
+// RunSearch runs the saved query without recording its result.
+func (uc *savedSearchUsecase) RunSearch(id int) ([]domain.LectureSummary, error) {
+	search, err := uc.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.lectureRepo.Search(search.Query)
+}
+
+// CheckSearches re-runs every saved search, diffs it against the result it was last checked against
+// and stores a notification for each search whose result changed. The new notifications are returned.
+func (uc *savedSearchUsecase) CheckSearches() ([]domain.SearchNotification, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+
+	searches, err := uc.searchRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]domain.SearchNotification, 0)
+	for _, search := range searches {
+		previous, err := uc.searchRepo.FindResults(search.ID)
+		if err != nil {
+			return nil, err
+		}
+		current, err := uc.evaluate(search)
+		if err != nil {
+			return nil, fmt.Errorf("check saved search %q: %w", search.Name, err)
+		}
+
+		checkedAt := time.Now()
+		if changes := domain.DiffSearchResults(previous, current); len(changes) > 0 {
+			notification := domain.SearchNotification{
+				SearchID:   search.ID,
+				SearchName: search.Name,
+				Changes:    changes,
+				CreatedAt:  checkedAt,
+			}
+			if err := uc.searchRepo.AddNotification(&notification); err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, notification)
+		}
+		if err := uc.searchRepo.ReplaceResults(search.ID, current, checkedAt); err != nil {
+			return nil, err
+		}
+	}
+	return notifications, nil
+}
+
+func (uc *savedSearchUsecase) ListNotifications(unreadOnly bool) ([]domain.SearchNotification, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+	return uc.searchRepo.FindNotifications(unreadOnly)
+}
+
+func (uc *savedSearchUsecase) MarkNotificationRead(id int) error {
+	if err := uc.ready(); err != nil {
+		return err
+	}
+	return uc.searchRepo.MarkNotificationRead(id)
+}
+
+func (uc *savedSearchUsecase) evaluate(search domain.SavedSearch) ([]domain.SearchResultEntry, error) {
+	summaries, err := uc.lectureRepo.Search(search.Query)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewSearchResultEntries(summaries), nil
+}
+
+func (uc *savedSearchUsecase) find(id int) (*domain.SavedSearch, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+	search, err := uc.searchRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if search == nil {
+		return nil, fmt.Errorf("saved search %d not found", id)
+	}
+	return search, nil
+}
+
+func (uc *savedSearchUsecase) ready() error {
+	if uc == nil || uc.searchRepo == nil {
+		return errors.New("saved search repository is not initialized")
+	}
+	if uc.lectureRepo == nil {
+		return errors.New("lecture repository is not initialized")
+	}
+	return nil
+}
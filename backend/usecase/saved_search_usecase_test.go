@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+)
+
+func TestSavedSearchUsecaseCheckSearchesReportsChanges(t *testing.T) {
+	lectureRepo, _, db := newUsecaseTestRepository(t)
+	tuesday := func(period domain.Period) []domain.TimeTable {
+		return []domain.TimeTable{{Semester: domain.SemesterFall, DayOfWeek: domain.DayOfWeekTuesday, Period: period}}
+	}
+	lectures := []domain.Lecture{
+		{University: "Test University", Department: "情報工学系", Title: "情報理論", Code: "CSC.T351", Year: 2025, Timetables: tuesday(5)},
+		{University: "Test University", Department: "情報工学系", Title: "計算機アーキテクチャ", Code: "CSC.T363", Year: 2025, Timetables: tuesday(3)},
+		{University: "Test University", Department: "数学系", Title: "代数学", Code: "MTH.A201", Year: 2025, Timetables: tuesday(5)},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	searchRepo, err := sqlite.NewSavedSearchRepository(db)
+	if err != nil {
+		t.Fatalf("NewSavedSearchRepository returned error: %v", err)
+	}
+
+	uc := NewSavedSearchUsecase(searchRepo, lectureRepo)
+	search, err := uc.SaveSearch("情報工学系", domain.SearchQuery{Departments: []string{"情報工学系"}})
+	if err != nil {
+		t.Fatalf("SaveSearch returned error: %v", err)
+	}
+	if notifications, err := uc.CheckSearches(); err != nil || len(notifications) != 0 {
+		t.Fatalf("expected no changes right after saving, got %+v, %v", notifications, err)
+	}
+
+	// a scrape replaces one lecture with a moved timetable, drops one and adds one
+	if _, err := db.Exec(`DELETE FROM lectures WHERE id IN (1, 2)`); err != nil {
+		t.Fatalf("delete lectures: %v", err)
+	}
+	rescraped := []domain.Lecture{
+		{University: "Test University", Department: "情報工学系", Title: "情報理論", Code: "CSC.T351", Year: 2025, Timetables: tuesday(7)},
+		{University: "Test University", Department: "情報工学系", Title: "形式言語とオートマトン", Code: "CSC.T341", Year: 2025, Timetables: tuesday(3)},
+	}
+	if err := lectureRepo.Creates(rescraped); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	notifications, err := uc.CheckSearches()
+	if err != nil {
+		t.Fatalf("CheckSearches returned error: %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].SearchID != search.ID || notifications[0].ID == 0 {
+		t.Fatalf("expected one stored notification, got %+v", notifications)
+	}
+	kinds := make(map[domain.SearchChangeKind]string)
+	for _, change := range notifications[0].Changes {
+		kinds[change.Kind] = change.Code
+	}
+	want := map[domain.SearchChangeKind]string{
+		domain.SearchChangeAdded:            "CSC.T341",
+		domain.SearchChangeRemoved:          "CSC.T363",
+		domain.SearchChangeTimetableChanged: "CSC.T351",
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("unexpected changes: %+v", notifications[0].Changes)
+	}
+	for kind, code := range want {
+		if kinds[kind] != code {
+			t.Errorf("expected %s change of %s, got %+v", kind, code, notifications[0].Changes)
+		}
+	}
+
+	if notifications, err := uc.CheckSearches(); err != nil || len(notifications) != 0 {
+		t.Errorf("expected changes to be reported once, got %+v, %v", notifications, err)
+	}
+	inbox, err := uc.ListNotifications(true)
+	if err != nil || len(inbox) != 1 {
+		t.Errorf("expected one unread inbox item, got %+v, %v", inbox, err)
+	}
+}
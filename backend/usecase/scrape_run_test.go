@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/scraper"
+)
+
+// diagnosingParser lists count courses whose detail pages all lack teachers.
+type diagnosingParser struct {
+	stubParser
+	count int
+}
+
+func (p diagnosingParser) ParseCourseList(io.Reader, string) ([]scraper.CourseListItem, error) {
+	items := make([]scraper.CourseListItem, 0, p.count)
+	for i := 0; i < p.count; i++ {
+		code := fmt.Sprintf("EX.%d", 100+i)
+		items = append(items, scraper.CourseListItem{Code: code, Title: "例示学" + code, DetailURL: testSiteBaseURL + "/2025/" + code, Year: 2025})
+	}
+	return items, nil
+}
+
+func (p diagnosingParser) ParseCourseDetailWithDiagnostics(r io.Reader, detailURL string) (*domain.Lecture, domain.ParseDiagnostic, error) {
+	lecture, err := p.ParseCourseDetail(r, detailURL)
+	diagnostic := domain.ParseDiagnostic{URL: detailURL, Issues: []domain.ParseIssue{{Kind: domain.ParseIssueMissingField, Detail: "teachers"}}}
+	return lecture, diagnostic, err
+}
+
+type memoryScrapeRunRepository struct {
+	runs []domain.ScrapeRun
+}
+
+func (m *memoryScrapeRunRepository) Create(run *domain.ScrapeRun) error {
+	run.ID = len(m.runs) + 1
+	m.runs = append(m.runs, *run)
+	return nil
+}
+
+func (m *memoryScrapeRunRepository) Finish(run *domain.ScrapeRun) error {
+	m.runs[run.ID-1] = *run
+	return nil
+}
+
+func (m *memoryScrapeRunRepository) FindRecent(string, int) ([]domain.ScrapeRun, error) {
+	recent := make([]domain.ScrapeRun, 0, len(m.runs))
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		recent = append(recent, m.runs[i])
+	}
+	return recent, nil
+}
+
+func (m *memoryScrapeRunRepository) FindByID(id int) (*domain.ScrapeRun, error) {
+	if id < 1 || id > len(m.runs) {
+		return nil, nil
+	}
+	run := m.runs[id-1]
+	return &run, nil
+}
+
+type driftCollectingReporter struct {
+	collectingProgressReporter
+	drifts []domain.ParseDrift
+}
+
+func (r *driftCollectingReporter) ReportParseDrift(drift domain.ParseDrift) {
+	r.drifts = append(r.drifts, drift)
+}
+
+func TestScraperUsecaseRecordsRun(t *testing.T) {
+	repo, timetableRepo, _ := newUsecaseTestRepository(t)
+
+	parser := diagnosingParser{count: 2}
+	sites, err := scraper.NewRegistry(stubSite{parser: parser})
+	if err != nil {
+		t.Fatalf("NewRegistry returned error: %v", err)
+	}
+	responses := map[string]string{
+		testSiteBaseURL + "/2025/list": "<html></html>",
+	}
+	items, _ := parser.ParseCourseList(nil, "")
+	for _, item := range items {
+		responses[item.DetailURL] = "<html></html>"
+	}
+
+	runRepo := &memoryScrapeRunRepository{}
+
+	uc := NewScraperUsecaseWithSites(newMockFetcher(responses), repo, timetableRepo, sites, 0)
+	uc.SetScrapeRunRepository(runRepo)
+
+	if _, err := uc.ScrapeTopPageAndSave(context.Background(), "example", 2025); err != nil {
+		t.Fatalf("ScrapeTopPageAndSave returned error: %v", err)
+	}
+
+	if len(runRepo.runs) != 1 {
+		t.Fatalf("expected one recorded run, got %+v", runRepo.runs)
+	}
+	run := runRepo.runs[0]
+	if run.SiteID != "example" || run.Lectures != 2 || run.MissingLectures != 2 || len(run.Diagnostics) != 2 {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+}
+
+func TestScraperUsecaseReportsParseDrift(t *testing.T) {
+	runRepo := &memoryScrapeRunRepository{runs: []domain.ScrapeRun{{ID: 1, SiteID: "example", Lectures: 20}}}
+	reporter := &driftCollectingReporter{}
+	uc := &scraperUsecase{runRepo: runRepo, reporter: reporter}
+
+	finish := uc.beginRun("example")
+	for i := 0; i < 12; i++ {
+		uc.run.Add(domain.ParseDiagnostic{URL: fmt.Sprint(i), Issues: []domain.ParseIssue{{Kind: domain.ParseIssueMissingField, Detail: "teachers"}}})
+	}
+	finish()
+
+	if uc.run != nil {
+		t.Fatalf("expected run to be cleared")
+	}
+	if len(reporter.drifts) != 1 {
+		t.Fatalf("expected a drift warning, got %+v", reporter.drifts)
+	}
+	if drift := reporter.drifts[0]; drift.RunID != 2 || drift.Rate != 1 || drift.Baseline != 0 || drift.MissingFields["teachers"] != 12 {
+		t.Errorf("unexpected drift: %+v", drift)
+	}
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// ScrapeRunReport is the parse quality report of one scrape run.
+type ScrapeRunReport struct {
+	Run              domain.ScrapeRun
+	MissingFieldRate float64
+	Issues           []domain.ParseIssueSummary
+}
+
+type ScrapeRunUsecase interface {
+	ListRuns(siteID string, limit int) ([]domain.ScrapeRun, error)
+	GetReport(runID int) (*ScrapeRunReport, error)
+}
+
+type scrapeRunUsecase struct {
+	runRepo domain.ScrapeRunRepository
+}
+
+func NewScrapeRunUsecase(runRepo domain.ScrapeRunRepository) ScrapeRunUsecase {
+	return &scrapeRunUsecase{
+		runRepo: runRepo,
+	}
+}
+
+func (uc *scrapeRunUsecase) ListRuns(siteID string, limit int) ([]domain.ScrapeRun, error) {
+	if uc == nil || uc.runRepo == nil {
+		return nil, errors.New("scrape run repository is not initialized")
+	}
+
+	return uc.runRepo.FindRecent(siteID, limit)
+}
+
+func (uc *scrapeRunUsecase) GetReport(runID int) (*ScrapeRunReport, error) {
+	if uc == nil || uc.runRepo == nil {
+		return nil, errors.New("scrape run repository is not initialized")
+	}
+
+	run, err := uc.runRepo.FindByID(runID)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, fmt.Errorf("scrape run %d not found", runID)
+	}
+
+	return &ScrapeRunReport{
+		Run:              *run,
+		MissingFieldRate: run.MissingFieldRate(),
+		Issues:           domain.SummarizeParseIssues(run.Diagnostics),
+	}, nil
+}
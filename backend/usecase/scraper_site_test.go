@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/scraper"
+)
+
+const testSiteBaseURL = "https://syllabus.example.ac.jp"
+
+type stubSite struct {
+	parser scraper.Parser
+}
+
+func (stubSite) ID() string         { return "example" }
+func (stubSite) University() string { return "例示大学" }
+func (stubSite) BaseURL() string    { return testSiteBaseURL }
+
+// ListPages returns the single list page of year without an index page to crawl.
+func (stubSite) ListPages(_ context.Context, _ scraper.PageFetcher, year int) ([]string, error) {
+	return []string{fmt.Sprintf("%s/%d/list", testSiteBaseURL, year)}, nil
+}
+func (stubSite) EnglishURL(string) string { return "" }
+func (s stubSite) Parser() scraper.Parser { return s.parser }
+
+type stubParser struct{}
+
+func (stubParser) ParseCourseList(io.Reader, string) ([]scraper.CourseListItem, error) {
+	return []scraper.CourseListItem{{Code: "EX.101", Title: "例示学", DetailURL: testSiteBaseURL + "/2025/EX.101", Year: 2025}}, nil
+}
+
+func (stubParser) ParseCourseDetail(_ io.Reader, detailURL string) (*domain.Lecture, error) {
+	return &domain.Lecture{Title: "例示学", Code: "EX.101", Department: "例示学部", Year: 2025, Url: detailURL}, nil
+}
+
+func (stubParser) ListCoursesPagesURL(io.Reader, int) ([]string, error) {
+	return []string{testSiteBaseURL + "/2025/list"}, nil
+}
+
+func (stubParser) AddEnglishDetails(io.Reader, *domain.Lecture) error {
+	return nil
+}
+
+func TestScraperUsecaseScrapesRegisteredSite(t *testing.T) {
+	repo, timetableRepo, _ := newUsecaseTestRepository(t)
+
+	sites, err := scraper.NewRegistry(scraper.NewISCTSite(nil), stubSite{parser: stubParser{}})
+	if err != nil {
+		t.Fatalf("NewRegistry returned error: %v", err)
+	}
+
+	fetcher := newMockFetcher(map[string]string{
+		testSiteBaseURL + "/2025/list":   "<html></html>",
+		testSiteBaseURL + "/2025/EX.101": "<html></html>",
+	})
+
+	uc := NewScraperUsecaseWithSites(fetcher, repo, timetableRepo, sites, 0)
+
+	infos := uc.Sites()
+	if len(infos) != 2 || infos[0].ID != "example" || infos[1].ID != scraper.DefaultSiteID {
+		t.Fatalf("unexpected sites: %+v", infos)
+	}
+
+	lectures, err := uc.ScrapeTopPageAndSave(context.Background(), "example", 2025)
+	if err != nil {
+		t.Fatalf("ScrapeTopPageAndSave returned error: %v", err)
+	}
+	if len(lectures) != 1 {
+		t.Fatalf("expected 1 lecture, got %d", len(lectures))
+	}
+	if lectures[0].University != "例示大学" {
+		t.Fatalf("expected university from site, got %s", lectures[0].University)
+	}
+
+	results, err := repo.Search(domain.SearchQuery{University: "例示"})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Code != "EX.101" {
+		t.Fatalf("expected lecture to be found by university, got %+v", results)
+	}
+
+	if _, err := uc.ScrapeTopPageAndSave(context.Background(), "missing", 2025); err == nil {
+		t.Fatal("expected unknown site to fail")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/url"
 	"strings"
 	"time"
 
@@ -34,29 +33,56 @@ type ScrapeProgressReporter interface {
 	Report(ScrapeProgress)
 }
 
+// ParseDriftReporter is optionally implemented by a ScrapeProgressReporter to be warned
+// when a scrape run fails to read noticeably more fields than earlier runs.
+type ParseDriftReporter interface {
+	ReportParseDrift(domain.ParseDrift)
+}
+
+// parseDriftHistory is the number of earlier runs a finished run is compared with.
+const parseDriftHistory = 5
+
 // ScraperUsecase orchestrates scraping workflow and persistence.
 type ScraperUsecase interface {
 	ScrapeCourseList(ctx context.Context, listURL, baseURL string) ([]scraper.CourseListItem, error)
 	ScrapeCourseListAndSave(ctx context.Context, listURL, baseURL string) ([]domain.Lecture, error)
 	ScrapeCourseDetail(ctx context.Context, detailURL string) (*domain.Lecture, error)
 	ScrapeCourseDetailAndSave(ctx context.Context, detailURL string) (*domain.Lecture, error)
-	ScrapeTopPageAndSave(ctx context.Context, year int) ([]domain.Lecture, error)
+	ScrapeTopPageAndSave(ctx context.Context, siteID string, year int) ([]domain.Lecture, error)
+	CheckForUpdates(ctx context.Context, siteID string, year int, dryRun bool) (*UpdateCheck, error)
+	Sites() []SiteInfo
 	SetProgressReporter(ScrapeProgressReporter)
+	SetScrapeRunRepository(domain.ScrapeRunRepository)
+}
+
+// SiteInfo describes a scrapable site for selection in the UI.
+type SiteInfo struct {
+	ID         string
+	University string
+	BaseURL    string
 }
 
 type scraperUsecase struct {
 	fetcher       Fetcher
 	lectureRepo   domain.LectureRepository
 	timetableRepo domain.TimeTableRepository
-	parser        scraper.Parser
+	sites         *scraper.Registry
 	delay         time.Duration
 	reporter      ScrapeProgressReporter
+	runRepo       domain.ScrapeRunRepository
+	run           *domain.ScrapeRun
 }
 
-// NewScraperUsecase constructs a scraper usecase instance.
+// NewScraperUsecase constructs a scraper usecase instance for the default site parsed with parser.
 func NewScraperUsecase(fetcher Fetcher, lectureRepo domain.LectureRepository, timetableRepo domain.TimeTableRepository, parser scraper.Parser, delay time.Duration) ScraperUsecase {
-	if parser == nil {
-		parser = scraper.NewParser()
+	sites, _ := scraper.NewRegistry(scraper.NewISCTSite(parser))
+	return NewScraperUsecaseWithSites(fetcher, lectureRepo, timetableRepo, sites, delay)
+}
+
+// NewScraperUsecaseWithSites constructs a scraper usecase instance that can scrape every site in the registry.
+func NewScraperUsecaseWithSites(fetcher Fetcher, lectureRepo domain.LectureRepository, timetableRepo domain.TimeTableRepository, sites *scraper.Registry, delay time.Duration) ScraperUsecase {
+	if sites == nil {
+		sites = scraper.DefaultRegistry()
 	}
 	if delay < 0 {
 		delay = defaultScrapeDelay
@@ -65,16 +91,77 @@ func NewScraperUsecase(fetcher Fetcher, lectureRepo domain.LectureRepository, ti
 		fetcher:       fetcher,
 		lectureRepo:   lectureRepo,
 		timetableRepo: timetableRepo,
-		parser:        parser,
+		sites:         sites,
 		delay:         delay,
 	}
 }
 
+// Sites lists the sites that can be passed to ScrapeTopPageAndSave.
+func (uc *scraperUsecase) Sites() []SiteInfo {
+	sites := uc.sites.Sites()
+	infos := make([]SiteInfo, 0, len(sites))
+	for _, site := range sites {
+		infos = append(infos, SiteInfo{ID: site.ID(), University: site.University(), BaseURL: site.BaseURL()})
+	}
+	return infos
+}
+
+// siteFor picks the site serving rawURL, falling back to the default site.
+func (uc *scraperUsecase) siteFor(rawURL string) (scraper.Site, error) {
+	if site, ok := uc.sites.SiteForURL(rawURL); ok {
+		return site, nil
+	}
+	return uc.sites.Site(scraper.DefaultSiteID)
+}
+
 // SetProgressReporter registers a progress reporter to receive scraping updates.
 func (uc *scraperUsecase) SetProgressReporter(reporter ScrapeProgressReporter) {
 	uc.reporter = reporter
 }
 
+// SetScrapeRunRepository enables recording parse diagnostics for every scrape run.
+func (uc *scraperUsecase) SetScrapeRunRepository(repo domain.ScrapeRunRepository) {
+	uc.runRepo = repo
+}
+
+// beginRun starts recording a scrape run of siteID unless one is already in progress.
+// The returned function stores the run and reports drift; it must be called once the scrape ends.
+func (uc *scraperUsecase) beginRun(siteID string) func() {
+	if uc.runRepo == nil || uc.run != nil {
+		return func() {}
+	}
+	run := &domain.ScrapeRun{SiteID: siteID}
+	if err := uc.runRepo.Create(run); err != nil {
+		log.Printf("create scrape run: %v", err)
+		return func() {}
+	}
+	uc.run = run
+	return func() {
+		uc.run = nil
+		uc.finishRun(run)
+	}
+}
+
+func (uc *scraperUsecase) finishRun(run *domain.ScrapeRun) {
+	if err := uc.runRepo.Finish(run); err != nil {
+		log.Printf("finish scrape run %d: %v", run.ID, err)
+		return
+	}
+	previous, err := uc.runRepo.FindRecent(run.SiteID, parseDriftHistory+1)
+	if err != nil {
+		log.Printf("find previous scrape runs: %v", err)
+		return
+	}
+	drift, ok := domain.DetectParseDrift(*run, previous)
+	if !ok {
+		return
+	}
+	log.Printf("parse drift on %s: missing field rate %.0f%% (baseline %.0f%%)", run.SiteID, drift.Rate*100, drift.Baseline*100)
+	if reporter, ok := uc.reporter.(ParseDriftReporter); ok {
+		reporter.ReportParseDrift(drift)
+	}
+}
+
 // ScrapeCourseList retrieves course list entries from the specified URL.
 func (uc *scraperUsecase) ScrapeCourseList(ctx context.Context, listURL, baseURL string) ([]scraper.CourseListItem, error) {
 	if uc.fetcher == nil {
@@ -85,13 +172,18 @@ func (uc *scraperUsecase) ScrapeCourseList(ctx context.Context, listURL, baseURL
 		return nil, errors.New("list url is required")
 	}
 
+	site, err := uc.siteFor(listURL)
+	if err != nil {
+		return nil, err
+	}
+
 	reader, err := uc.fetcher.Fetch(ctx, listURL)
 	if err != nil {
 		return nil, fmt.Errorf("fetch list %s: %w", listURL, err)
 	}
 	defer reader.Close()
 
-	items, err := uc.parser.ParseCourseList(reader, baseURL)
+	items, err := site.Parser().ParseCourseList(reader, baseURL)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +201,9 @@ func (uc *scraperUsecase) ScrapeCourseListAndSave(ctx context.Context, listURL,
 	if err != nil {
 		return nil, err
 	}
+	if site, err := uc.siteFor(listURL); err == nil {
+		defer uc.beginRun(site.ID())()
+	}
 	if len(items) == 0 {
 		uc.reportProgress(ScrapeProgress{Total: 0})
 		return []domain.Lecture{}, nil
@@ -137,6 +232,11 @@ func (uc *scraperUsecase) ScrapeCourseListAndSave(ctx context.Context, listURL,
 
 	uc.reportProgress(ScrapeProgress{Total: total})
 
+	index, err := uc.updateIndex()
+	if err != nil {
+		return nil, err
+	}
+
 	lectures := make([]domain.Lecture, 0, total)
 	firstFetch := true
 
@@ -145,13 +245,12 @@ func (uc *scraperUsecase) ScrapeCourseListAndSave(ctx context.Context, listURL,
 			return nil, ctx.Err()
 		}
 
-		detailURL := item.DetailURL
-		log.Printf("Scraping detail page: %s %s: %s", item.Code, item.Title, detailURL)
+		log.Printf("Scraping detail page: %s %s: %s", item.Code, item.Title, item.DetailURL)
 		uc.reportProgress(ScrapeProgress{Total: total, Current: idx + 1, Code: strings.TrimSpace(item.Code), Title: strings.TrimSpace(item.Title)})
 
-		existing, err := uc.lectureRepo.FindByCode(item.Code, item.Title, item.OpenTerm)
+		existing, err := uc.storedLecture(index, item)
 		if err != nil {
-			return nil, fmt.Errorf("find lecture by code %s: %w", item.Code, err)
+			return nil, err
 		}
 
 		if shouldSkipLecture(existing, item) {
@@ -165,23 +264,13 @@ func (uc *scraperUsecase) ScrapeCourseListAndSave(ctx context.Context, listURL,
 		}
 		firstFetch = false
 
-		lecture, err := uc.ScrapeCourseDetail(ctx, detailURL)
+		lecture, err := uc.scrapeListedDetail(ctx, item)
 		if err != nil {
 			return nil, err
 		}
 		if lecture == nil {
 			continue
 		}
-		if lecture.Code == "" {
-			lecture.Code = item.Code
-		}
-		if lecture.Title == "" {
-			lecture.Title = item.Title
-		}
-		if openTerm := strings.TrimSpace(item.OpenTerm); openTerm != "" {
-			lecture.OpenTerm = openTerm
-		}
-		lecture.UpdatedAt = normalizeDate(selectUpdatedAt(lecture.UpdatedAt, item.UpdatedAt))
 		lectures = append(lectures, *lecture)
 	}
 
@@ -212,29 +301,38 @@ func (uc *scraperUsecase) ScrapeCourseDetail(ctx context.Context, detailURL stri
 		return nil, errors.New("detail url is required")
 	}
 
+	site, err := uc.siteFor(detailURL)
+	if err != nil {
+		return nil, err
+	}
+	parser := site.Parser()
+
 	reader, err := uc.fetcher.Fetch(ctx, detailURL)
 	if err != nil {
 		return nil, fmt.Errorf("fetch detail %s: %w", detailURL, err)
 	}
 	defer reader.Close()
 
-	lecture, err := uc.parser.ParseCourseDetail(reader, detailURL)
+	lecture, err := uc.parseCourseDetail(parser, reader, detailURL)
 	if err != nil {
 		return nil, err
 	}
+	if lecture != nil {
+		lecture.University = site.University()
+	}
 
 	time.Sleep(1 * time.Second)
 
-	// english title
-	englishURL := buildEnglishDetailURL(detailURL)
+	// english version
+	englishURL := site.EnglishURL(detailURL)
 	if englishURL != "" {
 		engReader, err := uc.fetcher.Fetch(ctx, englishURL)
 		if err != nil {
 			log.Printf("fetch english title %s: %v", englishURL, err)
 		} else {
 			defer engReader.Close()
-			if err := uc.parser.AddEnglishTitle(engReader, lecture); err != nil {
-				log.Printf("add english title from %s: %v", englishURL, err)
+			if err := parser.AddEnglishDetails(engReader, lecture); err != nil {
+				log.Printf("add english details from %s: %v", englishURL, err)
 			}
 		}
 	}
@@ -244,6 +342,20 @@ func (uc *scraperUsecase) ScrapeCourseDetail(ctx context.Context, detailURL stri
 	return lecture, nil
 }
 
+// parseCourseDetail parses a detail page, recording its diagnostics in the current run when the parser provides them.
+func (uc *scraperUsecase) parseCourseDetail(parser scraper.Parser, r io.Reader, detailURL string) (*domain.Lecture, error) {
+	diagnosing, ok := parser.(scraper.DiagnosingParser)
+	if !ok || uc.run == nil {
+		return parser.ParseCourseDetail(r, detailURL)
+	}
+	lecture, diagnostic, err := diagnosing.ParseCourseDetailWithDiagnostics(r, detailURL)
+	if err != nil {
+		return nil, err
+	}
+	uc.run.Add(diagnostic)
+	return lecture, nil
+}
+
 // ScrapeCourseDetailAndSave scrapes a detail page and persists the aggregate.
 func (uc *scraperUsecase) ScrapeCourseDetailAndSave(ctx context.Context, detailURL string) (*domain.Lecture, error) {
 	if uc.lectureRepo == nil {
@@ -272,31 +384,27 @@ func (uc *scraperUsecase) ScrapeCourseDetailAndSave(ctx context.Context, detailU
 	return lecture, nil
 }
 
-// ScrapeTopPageAndSave fetches the top page, scrapes each listed course page, and persists their lectures.
-func (uc *scraperUsecase) ScrapeTopPageAndSave(ctx context.Context, year int) ([]domain.Lecture, error) {
+// ScrapeTopPageAndSave crawls the course list pages of a year on a site, scrapes each of them, and persists their lectures.
+// An empty siteID selects the default site.
+func (uc *scraperUsecase) ScrapeTopPageAndSave(ctx context.Context, siteID string, year int) ([]domain.Lecture, error) {
 	if uc.fetcher == nil {
 		return nil, errors.New("scraper fetcher is not initialized")
 	}
 
-	url := fmt.Sprintf("%s/courses/%d", scraper.TopPageURL, year)
-	reader, err := uc.fetcher.Fetch(ctx, url)
+	site, err := uc.sites.Site(siteID)
 	if err != nil {
-		return nil, fmt.Errorf("fetch top page %s: %w", url, err)
-	}
-	if reader == nil {
-		return nil, fmt.Errorf("fetch top page %s: empty response", url)
+		return nil, err
 	}
-	defer reader.Close()
 
-	urls, err := uc.parser.ListCoursesPagesURL(reader, year)
+	urls, err := site.ListPages(ctx, uc.fetcher, year)
 	if err != nil {
 		return nil, err
 	}
+	defer uc.beginRun(site.ID())()
 	if len(urls) == 0 {
 		return []domain.Lecture{}, nil
 	}
 
-	seen := make(map[string]struct{}, len(urls))
 	aggregated := make([]domain.Lecture, 0)
 	firstList := true
 
@@ -305,15 +413,6 @@ func (uc *scraperUsecase) ScrapeTopPageAndSave(ctx context.Context, year int) ([
 			return nil, ctx.Err()
 		}
 
-		listURL = strings.TrimSpace(listURL)
-		if listURL == "" {
-			continue
-		}
-		if _, ok := seen[listURL]; ok {
-			continue
-		}
-		seen[listURL] = struct{}{}
-
 		if !firstList {
 			if err := uc.sleep(ctx); err != nil {
 				return nil, err
@@ -321,24 +420,92 @@ func (uc *scraperUsecase) ScrapeTopPageAndSave(ctx context.Context, year int) ([
 		}
 		firstList = false
 
-		lectures, err := uc.ScrapeCourseListAndSave(ctx, listURL, scraper.TopPageURL)
+		lectures, err := uc.ScrapeCourseListAndSave(ctx, listURL, site.BaseURL())
 		if err != nil {
 			return nil, fmt.Errorf("scrape course list %s: %w", listURL, err)
 		}
 		aggregated = append(aggregated, lectures...)
 	}
 
+	if err := uc.resolveStoredLectures(ctx); err != nil {
+		return nil, err
+	}
+	return aggregated, nil
+}
+
+// resolveStoredLectures links the relations and expands the timetables of newly stored lectures.
+func (uc *scraperUsecase) resolveStoredLectures(ctx context.Context) error {
 	if _, err := uc.lectureRepo.MigrateRelatedCourses(ctx); err != nil {
-		return nil, fmt.Errorf("migrate related courses: %w", err)
+		return fmt.Errorf("migrate related courses: %w", err)
+	}
+	if _, err := uc.lectureRepo.MigrateCourseReferences(ctx); err != nil {
+		return fmt.Errorf("migrate course references: %w", err)
 	}
 
 	if uc.timetableRepo != nil {
 		if _, err := uc.timetableRepo.ExpandTimetableRanges(ctx); err != nil {
-			return nil, fmt.Errorf("expand timetable ranges: %w", err)
+			return fmt.Errorf("expand timetable ranges: %w", err)
 		}
 	}
+	return nil
+}
 
-	return aggregated, nil
+// scrapeListedDetail fetches the detail page of a list row and completes the lecture with the row's
+// code, title, open term and update date.
+func (uc *scraperUsecase) scrapeListedDetail(ctx context.Context, item scraper.CourseListItem) (*domain.Lecture, error) {
+	lecture, err := uc.ScrapeCourseDetail(ctx, item.DetailURL)
+	if err != nil || lecture == nil {
+		return nil, err
+	}
+	if lecture.Code == "" {
+		lecture.Code = item.Code
+	}
+	if lecture.Title == "" {
+		lecture.Title = item.Title
+	}
+	if openTerm := strings.TrimSpace(item.OpenTerm); openTerm != "" {
+		lecture.OpenTerm = openTerm
+	}
+	lecture.UpdatedAt = normalizeDate(selectUpdatedAt(lecture.UpdatedAt, item.UpdatedAt))
+	return lecture, nil
+}
+
+// updateIndex loads the update stamps of every stored lecture when the repository supports it;
+// a nil index makes storedLecture fall back on FindByCode.
+func (uc *scraperUsecase) updateIndex() (*domain.LectureUpdateIndex, error) {
+	repo, ok := uc.lectureRepo.(domain.LectureUpdateStampRepository)
+	if !ok {
+		return nil, nil
+	}
+	stamps, err := repo.FindUpdateStamps()
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewLectureUpdateIndex(stamps), nil
+}
+
+// storedLecture returns the stored lecture matching a list row with the fields shouldSkipLecture compares.
+func (uc *scraperUsecase) storedLecture(index *domain.LectureUpdateIndex, item scraper.CourseListItem) (*domain.Lecture, error) {
+	if index == nil {
+		existing, err := uc.lectureRepo.FindByCode(item.Code, item.Title, item.OpenTerm)
+		if err != nil {
+			return nil, fmt.Errorf("find lecture by code %s: %w", item.Code, err)
+		}
+		return existing, nil
+	}
+
+	stamp := index.Find(item.Code, item.Title, item.OpenTerm)
+	if stamp == nil {
+		return nil, nil
+	}
+	return &domain.Lecture{
+		ID:        stamp.LectureID,
+		Code:      stamp.Code,
+		Title:     stamp.Title,
+		OpenTerm:  stamp.OpenTerm,
+		Year:      stamp.Year,
+		UpdatedAt: stamp.UpdatedAt,
+	}, nil
 }
 
 func (uc *scraperUsecase) sleep(ctx context.Context) error {
@@ -362,24 +529,6 @@ func (uc *scraperUsecase) sleep(ctx context.Context) error {
 	}
 }
 
-func buildEnglishDetailURL(detailURL string) string {
-	detailURL = strings.TrimSpace(detailURL)
-	if detailURL == "" {
-		return ""
-	}
-	u, err := url.Parse(detailURL)
-	if err != nil {
-		if strings.Contains(detailURL, "?") {
-			return detailURL + "&hl=en"
-		}
-		return detailURL + "?hl=en"
-	}
-	query := u.Query()
-	query.Set("hl", "en")
-	u.RawQuery = query.Encode()
-	return u.String()
-}
-
 func (uc *scraperUsecase) reportProgress(progress ScrapeProgress) {
 	if uc.reporter == nil {
 		return
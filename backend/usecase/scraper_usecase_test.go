@@ -22,7 +22,7 @@ const testDataSourceName = "file::memory:?cache=shared"
 func TestScraperUsecaseScrapeCourseDetailAndSave(t *testing.T) {
 	repo, timetableRepo, _ := newUsecaseTestRepository(t)
 	detailURL := "https://example.com/courses/2025/LAH.S101"
-	detailURLEnglish := buildEnglishDetailURL(detailURL)
+	detailURLEnglish := scraper.NewISCTSite(nil).EnglishURL(detailURL)
 
 	fetcher := newMockFetcher(map[string]string{
 		detailURL:        readFixture(t, "course_detail.html"),
@@ -84,7 +84,7 @@ func TestScraperUsecaseScrapeCourseListAndSave(t *testing.T) {
 
 	listURL := "https://example.com/list"
 	detailURL := "https://example.com/courses/2025/LAH.S101"
-	detailURLEnglish := buildEnglishDetailURL(detailURL)
+	detailURLEnglish := scraper.NewISCTSite(nil).EnglishURL(detailURL)
 	baseURL := "https://example.com"
 
 	listHTML := `
@@ -264,7 +264,7 @@ func TestScraperUsecaseScrapeTopPageAndSave(t *testing.T) {
 
 	listURL := scraper.TopPageURL + "/courses/2025/4/mock-list"
 	detailURL := scraper.TopPageURL + "/courses/2025/LAH.S101"
-	detailURLEnglish := buildEnglishDetailURL(detailURL)
+	detailURLEnglish := scraper.NewISCTSite(nil).EnglishURL(detailURL)
 
 	topPage := fmt.Sprintf(`
 <html><body>
@@ -285,10 +285,10 @@ func TestScraperUsecaseScrapeTopPageAndSave(t *testing.T) {
 </body></html>`
 
 	fetcher := newMockFetcher(map[string]string{
-		scraper.TopPageURL: topPage,
-		listURL:            listHTML,
-		detailURL:          readFixture(t, "course_detail.html"),
-		detailURLEnglish:   readFixture(t, "course_detail_en.html"),
+		scraper.TopPageURL + "/courses/2025": topPage,
+		listURL:                              listHTML,
+		detailURL:                            readFixture(t, "course_detail.html"),
+		detailURLEnglish:                     readFixture(t, "course_detail_en.html"),
 	})
 
 	usecase := NewScraperUsecase(fetcher, repo, timetableRepo, scraper.NewParser(), 0)
@@ -298,7 +298,7 @@ func TestScraperUsecaseScrapeTopPageAndSave(t *testing.T) {
 		usecase.SetProgressReporter(nil)
 	})
 
-	lectures, err := usecase.ScrapeTopPageAndSave(context.Background(), 2025)
+	lectures, err := usecase.ScrapeTopPageAndSave(context.Background(), scraper.DefaultSiteID, 2025)
 	if err != nil {
 		t.Fatalf("ScrapeTopPageAndSave returned error: %v", err)
 	}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/site"
+)
+
+// SiteOptions configures a generated syllabus site. Empty Years publishes every year; TemplateDir holds
+// templates and assets overriding the defaults.
+type SiteOptions struct {
+	Title       string
+	Years       []int
+	TemplateDir string
+}
+
+// TimetableReportRequest selects the lectures of a printable report, from LectureIDs in the given order,
+// or from the results of Query when LectureIDs is empty.
+type TimetableReportRequest struct {
+	Title       string
+	LectureIDs  []int
+	Query       domain.SearchQuery
+	TemplateDir string
+}
+
+// SiteUsecase publishes the stored syllabus as a static HTML site and printable reports.
+type SiteUsecase interface {
+	GenerateSite(outDir string, options SiteOptions) (*site.Report, error)
+	WriteTimetableReport(w io.Writer, request TimetableReportRequest) (int, error)
+}
+
+type siteUsecase struct {
+	lectureRepo domain.LectureRepository
+}
+
+func NewSiteUsecase(lectureRepo domain.LectureRepository) SiteUsecase {
+	return &siteUsecase{
+		lectureRepo: lectureRepo,
+	}
+}
+
+// GenerateSite renders every lecture of the selected years into outDir.
+func (uc *siteUsecase) GenerateSite(outDir string, options SiteOptions) (*site.Report, error) {
+	if uc == nil || uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+	if outDir == "" {
+		return nil, errors.New("output directory is empty")
+	}
+
+	generator, err := site.NewGenerator(options.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	years := options.Years
+	if len(years) == 0 {
+		years = []int{0}
+	}
+	ids := make([]int, 0)
+	seen := make(map[int]bool)
+	for _, year := range years {
+		summaries, err := uc.lectureRepo.Search(domain.SearchQuery{Year: year})
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range summaries {
+			if !seen[summary.ID] {
+				seen[summary.ID] = true
+				ids = append(ids, summary.ID)
+			}
+		}
+	}
+	lectures, err := uc.findLectures(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	title := options.Title
+	if title == "" {
+		title = "シラバス"
+	}
+	return generator.Generate(outDir, site.Site{Title: title, GeneratedAt: time.Now(), Lectures: lectures})
+}
+
+// WriteTimetableReport writes a printable HTML timetable of the selected lectures, with their syllabi as
+// an appendix, and returns how many lectures it holds.
+func (uc *siteUsecase) WriteTimetableReport(w io.Writer, request TimetableReportRequest) (int, error) {
+	if uc == nil || uc.lectureRepo == nil {
+		return 0, errors.New("lecture repository is not initialized")
+	}
+
+	generator, err := site.NewGenerator(request.TemplateDir)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := request.LectureIDs
+	if len(ids) == 0 {
+		summaries, err := uc.lectureRepo.Search(request.Query)
+		if err != nil {
+			return 0, err
+		}
+		for _, summary := range summaries {
+			ids = append(ids, summary.ID)
+		}
+	}
+	lectures, err := uc.findLectures(ids)
+	if err != nil {
+		return 0, err
+	}
+
+	title := request.Title
+	if title == "" {
+		title = "時間割"
+	}
+	if err := generator.WriteReport(w, domain.NewTimetableReport(title, lectures), time.Now()); err != nil {
+		return 0, err
+	}
+	return len(lectures), nil
+}
+
+func (uc *siteUsecase) findLectures(ids []int) ([]domain.Lecture, error) {
+	lectures := make([]domain.Lecture, 0, len(ids))
+	for _, id := range ids {
+		lecture, err := uc.lectureRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if lecture == nil {
+			return nil, fmt.Errorf("lecture %d not found", id)
+		}
+		lectures = append(lectures, *lecture)
+	}
+	return lectures, nil
+}
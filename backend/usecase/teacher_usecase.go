@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+type TeacherUsecase interface {
+	GetTeacherSchedule(teacher string, year int) (*domain.TeacherSchedule, error)
+}
+
+type teacherUsecase struct {
+	teacherRepo domain.TeacherRepository
+	lectureRepo domain.LectureRepository
+}
+
+func NewTeacherUsecase(teacherRepo domain.TeacherRepository, lectureRepo domain.LectureRepository) TeacherUsecase {
+	return &teacherUsecase{
+		teacherRepo: teacherRepo,
+		lectureRepo: lectureRepo,
+	}
+}
+
+// GetTeacherSchedule accepts either a teacher id or a name; names are matched ignoring spaces. A name
+// matching several teachers returns a *domain.AmbiguousTeacherError listing their ids.
+func (uc *teacherUsecase) GetTeacherSchedule(teacher string, year int) (*domain.TeacherSchedule, error) {
+	if uc == nil || uc.teacherRepo == nil {
+		return nil, errors.New("teacher repository is not initialized")
+	}
+	if uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	found, err := uc.findTeacher(teacher)
+	if err != nil {
+		return nil, err
+	}
+
+	lectures, err := uc.lectureRepo.FindByTeacher(found.ID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := domain.NewTeacherSchedule(*found, year, lectures)
+	return &schedule, nil
+}
+
+func (uc *teacherUsecase) findTeacher(teacher string) (*domain.Teacher, error) {
+	teacher = strings.TrimSpace(teacher)
+	if teacher == "" {
+		return nil, errors.New("teacher is required")
+	}
+
+	if id, err := strconv.Atoi(teacher); err == nil {
+		found, err := uc.teacherRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, fmt.Errorf("teacher not found: %d", id)
+		}
+		return found, nil
+	}
+
+	candidates, err := uc.teacherRepo.FindByName(teacher)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("teacher not found: %s", teacher)
+	}
+	if len(candidates) > 1 {
+		ids := make([]int, len(candidates))
+		for i, candidate := range candidates {
+			ids[i] = candidate.ID
+		}
+		return nil, &domain.AmbiguousTeacherError{Name: teacher, TeacherIDs: ids}
+	}
+
+	return &candidates[0], nil
+}
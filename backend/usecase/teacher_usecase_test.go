@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+)
+
+func TestTeacherUsecaseReportsAmbiguousNames(t *testing.T) {
+	lectureRepo, _, db := newUsecaseTestRepository(t)
+	teacherRepo, err := sqlite.NewTeacherRepository(db)
+	if err != nil {
+		t.Fatalf("NewTeacherRepository returned error: %v", err)
+	}
+	uc := NewTeacherUsecase(teacherRepo, lectureRepo)
+
+	// two pages spell the same name differently, so two teachers are stored
+	if err := lectureRepo.Creates([]domain.Lecture{
+		{University: "Test University", Title: "線形代数", Code: "MTH.A201", Year: 2025, Teachers: []domain.Teacher{{Name: "山田 太郎"}}},
+		{University: "Test University", Title: "解析学", Code: "MTH.A202", Year: 2025, Teachers: []domain.Teacher{{Name: "山田太郎"}}},
+	}); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+
+	_, err = uc.GetTeacherSchedule("山田太郎", 2025)
+	var ambiguous *domain.AmbiguousTeacherError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected an ambiguous teacher error, got %v", err)
+	}
+	if len(ambiguous.TeacherIDs) != 2 || ambiguous.TeacherIDs[0] == ambiguous.TeacherIDs[1] {
+		t.Fatalf("expected both teacher ids, got %v", ambiguous.TeacherIDs)
+	}
+
+	for _, id := range ambiguous.TeacherIDs {
+		schedule, err := uc.GetTeacherSchedule(strconv.Itoa(id), 2025)
+		if err != nil {
+			t.Fatalf("GetTeacherSchedule(%d) returned error: %v", id, err)
+		}
+		if schedule.Teacher.ID != id {
+			t.Errorf("expected teacher %d, got %+v", id, schedule.Teacher)
+		}
+	}
+}
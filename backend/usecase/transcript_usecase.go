@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// TranscriptImportResult reports how an imported transcript was matched onto stored lectures.
+type TranscriptImportResult struct {
+	Imported  int
+	Matched   int
+	Unmatched []domain.TranscriptEntry
+}
+
+// TranscriptUsecase imports and totals the courses the student has completed.
+type TranscriptUsecase interface {
+	ImportTranscript(r io.Reader) (*TranscriptImportResult, error)
+	ListEntries() ([]domain.TranscriptEntry, error)
+	DeleteEntry(id int) error
+	GetSummary() (*domain.TranscriptSummary, error)
+}
+
+type transcriptUsecase struct {
+	transcriptRepo domain.TranscriptRepository
+	lectureRepo    domain.LectureRepository
+}
+
+func NewTranscriptUsecase(transcriptRepo domain.TranscriptRepository, lectureRepo domain.LectureRepository) TranscriptUsecase {
+	return &transcriptUsecase{
+		transcriptRepo: transcriptRepo,
+		lectureRepo:    lectureRepo,
+	}
+}
+
+// ImportTranscript parses a CSV file or grade export, matches each entry onto a stored lecture by code,
+// or by a fuzzy title match when the code is unknown, and stores the entries.
+func (uc *transcriptUsecase) ImportTranscript(r io.Reader) (*TranscriptImportResult, error) {
+	if uc == nil || uc.transcriptRepo == nil {
+		return nil, errors.New("transcript repository is not initialized")
+	}
+	if uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	entries, err := domain.ParseTranscript(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TranscriptImportResult{Unmatched: make([]domain.TranscriptEntry, 0)}
+	titleCandidates := make(map[int][]domain.CourseTitleCandidate)
+	for i := range entries {
+		id, err := uc.matchLecture(entries[i], titleCandidates)
+		if err != nil {
+			return nil, err
+		}
+		entries[i].LectureID = id
+		if id == 0 {
+			result.Unmatched = append(result.Unmatched, entries[i])
+		} else {
+			result.Matched++
+		}
+	}
+
+	imported, err := uc.transcriptRepo.Save(entries)
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = imported
+	return result, nil
+}
+
+// matchLecture prefers the offering of the entry's year, then the closest year. Title candidates are cached per year.
+func (uc *transcriptUsecase) matchLecture(entry domain.TranscriptEntry, titleCandidates map[int][]domain.CourseTitleCandidate) (int, error) {
+	if entry.Code != "" {
+		lectures, err := uc.lectureRepo.FindAllByCode(entry.Code)
+		if err != nil {
+			return 0, err
+		}
+		best, bestDistance := 0, -1
+		for _, lecture := range lectures {
+			distance := lecture.Year - entry.Year
+			if distance < 0 {
+				distance = -distance
+			}
+			if bestDistance < 0 || distance <= bestDistance {
+				best, bestDistance = lecture.ID, distance
+			}
+		}
+		if best != 0 {
+			return best, nil
+		}
+	}
+	if entry.Title == "" {
+		return 0, nil
+	}
+
+	candidates, ok := titleCandidates[entry.Year]
+	if !ok {
+		summaries, err := uc.lectureRepo.Search(domain.SearchQuery{Year: entry.Year})
+		if err != nil {
+			return 0, err
+		}
+		candidates = make([]domain.CourseTitleCandidate, 0, len(summaries))
+		for _, summary := range summaries {
+			candidates = append(candidates, domain.CourseTitleCandidate{LectureID: summary.ID, Titles: []string{summary.Title}})
+		}
+		titleCandidates[entry.Year] = candidates
+	}
+	id, _ := domain.MatchCourseTitle(entry.Title, candidates)
+	return id, nil
+}
+
+func (uc *transcriptUsecase) ListEntries() ([]domain.TranscriptEntry, error) {
+	if uc == nil || uc.transcriptRepo == nil {
+		return nil, errors.New("transcript repository is not initialized")
+	}
+
+	return uc.transcriptRepo.FindAll()
+}
+
+func (uc *transcriptUsecase) DeleteEntry(id int) error {
+	if uc == nil || uc.transcriptRepo == nil {
+		return errors.New("transcript repository is not initialized")
+	}
+
+	return uc.transcriptRepo.Delete(id)
+}
+
+// GetSummary totals the earned and attempted credits of the stored transcript.
+func (uc *transcriptUsecase) GetSummary() (*domain.TranscriptSummary, error) {
+	entries, err := uc.ListEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := domain.SummarizeTranscript(entries)
+	return &summary, nil
+}
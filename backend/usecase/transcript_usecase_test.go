@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+)
+
+func TestTranscriptUsecaseImportMatchesLectures(t *testing.T) {
+	lectureRepo, _, db := newUsecaseTestRepository(t)
+	lectures := []domain.Lecture{
+		{University: "Test University", Department: "Test Department", Title: "線形代数第一", Code: "LAS.M101", Year: 2023, Credit: 2},
+		{University: "Test University", Department: "Test Department", Title: "線形代数第一", Code: "LAS.M101", Year: 2024, Credit: 2},
+		{University: "Test University", Department: "Test Department", Title: "確率と統計", Code: "MTH.B201", Year: 2024, Credit: 2},
+	}
+	if err := lectureRepo.Creates(lectures); err != nil {
+		t.Fatalf("Creates returned error: %v", err)
+	}
+	transcriptRepo, err := sqlite.NewTranscriptRepository(db)
+	if err != nil {
+		t.Fatalf("NewTranscriptRepository returned error: %v", err)
+	}
+
+	uc := NewTranscriptUsecase(transcriptRepo, lectureRepo)
+	raw := "科目コード,科目名,単位数,評価,年度\n" +
+		"LAS.M101,線形代数第一,2,85,2024\n" +
+		",確率と統計,2,A,2024\n" +
+		"XYZ.A999,存在しない科目,1,B,2024\n"
+	result, err := uc.ImportTranscript(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ImportTranscript returned error: %v", err)
+	}
+	if result.Imported != 3 || result.Matched != 2 || len(result.Unmatched) != 1 || result.Unmatched[0].Code != "XYZ.A999" {
+		t.Fatalf("unexpected import result: %+v", result)
+	}
+
+	entries, err := uc.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	matched := make(map[string]int)
+	for _, entry := range entries {
+		matched[entry.Title] = entry.LectureID
+	}
+	if matched["線形代数第一"] != 2 || matched["確率と統計"] != 3 {
+		t.Errorf("expected same-year code and title matches, got %v", matched)
+	}
+
+	summary, err := uc.GetSummary()
+	if err != nil {
+		t.Fatalf("GetSummary returned error: %v", err)
+	}
+	if summary.EarnedCredits != 5 {
+		t.Errorf("expected 5 earned credits, got %+v", summary)
+	}
+}
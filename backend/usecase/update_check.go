@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/scraper"
+)
+
+// listFetchConcurrency is the number of course list pages an update check fetches at once.
+const listFetchConcurrency = 4
+
+// StaleLecture is a course list row whose detail page has to be fetched: it is not stored yet (New),
+// or its list update date, title, open term or year differs from the stored lecture.
+type StaleLecture struct {
+	Code            string
+	Title           string
+	OpenTerm        string
+	Year            int
+	DetailURL       string
+	ListUpdatedAt   time.Time
+	StoredUpdatedAt time.Time
+	New             bool
+}
+
+// UpdateCheck reports an update check of one year. Lectures holds the lectures fetched and stored,
+// and stays empty on a dry run.
+type UpdateCheck struct {
+	SiteID    string
+	Year      int
+	DryRun    bool
+	ListPages int
+	Listed    int
+	Stale     []StaleLecture
+	Lectures  []domain.Lecture
+}
+
+// CheckForUpdates fetches only the course list pages of year and compares every row against the stored
+// lectures in one query, so the stale detail pages are known before any of them is fetched. Unless dryRun
+// is set, exactly those detail pages are then scraped and stored.
+func (uc *scraperUsecase) CheckForUpdates(ctx context.Context, siteID string, year int, dryRun bool) (*UpdateCheck, error) {
+	if uc.fetcher == nil {
+		return nil, errors.New("scraper fetcher is not initialized")
+	}
+	if uc.lectureRepo == nil {
+		return nil, errors.New("lecture repository is not initialized")
+	}
+
+	site, err := uc.sites.Site(siteID)
+	if err != nil {
+		return nil, err
+	}
+	urls, err := site.ListPages(ctx, uc.fetcher, year)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.fetchListPages(ctx, site, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := uc.updateIndex()
+	if err != nil {
+		return nil, err
+	}
+	check := &UpdateCheck{
+		SiteID:    site.ID(),
+		Year:      year,
+		DryRun:    dryRun,
+		ListPages: len(urls),
+		Listed:    len(items),
+		Stale:     make([]StaleLecture, 0),
+		Lectures:  make([]domain.Lecture, 0),
+	}
+	staleItems := make([]scraper.CourseListItem, 0)
+	for _, item := range items {
+		existing, err := uc.storedLecture(index, item)
+		if err != nil {
+			return nil, err
+		}
+		if shouldSkipLecture(existing, item) {
+			continue
+		}
+		stale := StaleLecture{
+			Code:          strings.TrimSpace(item.Code),
+			Title:         strings.TrimSpace(item.Title),
+			OpenTerm:      strings.TrimSpace(item.OpenTerm),
+			Year:          item.Year,
+			DetailURL:     item.DetailURL,
+			ListUpdatedAt: item.UpdatedAt,
+			New:           existing == nil,
+		}
+		if existing != nil {
+			stale.StoredUpdatedAt = existing.UpdatedAt
+		}
+		check.Stale = append(check.Stale, stale)
+		staleItems = append(staleItems, item)
+	}
+	if dryRun || len(staleItems) == 0 {
+		return check, nil
+	}
+
+	defer uc.beginRun(site.ID())()
+	total := len(staleItems)
+	uc.reportProgress(ScrapeProgress{Total: total})
+	for idx, item := range staleItems {
+		if ctx != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if idx > 0 {
+			if err := uc.sleep(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		log.Printf("Scraping detail page: %s %s: %s", item.Code, item.Title, item.DetailURL)
+		uc.reportProgress(ScrapeProgress{Total: total, Current: idx + 1, Code: strings.TrimSpace(item.Code), Title: strings.TrimSpace(item.Title)})
+		lecture, err := uc.scrapeListedDetail(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		if lecture != nil {
+			check.Lectures = append(check.Lectures, *lecture)
+		}
+	}
+
+	if len(check.Lectures) > 0 {
+		if err := uc.lectureRepo.Creates(check.Lectures); err != nil {
+			return nil, err
+		}
+		if err := uc.resolveStoredLectures(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return check, nil
+}
+
+// fetchListPages fetches the list pages with listFetchConcurrency workers, each waiting the scrape delay
+// between its own requests, and returns their rows in page order without duplicate detail pages.
+func (uc *scraperUsecase) fetchListPages(ctx context.Context, site scraper.Site, urls []string) ([]scraper.CourseListItem, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]scraper.CourseListItem, len(urls))
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for w := 0; w < listFetchConcurrency && w < len(urls); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			first := true
+			for i := range jobs {
+				if !first {
+					if err := uc.sleep(ctx); err != nil {
+						return
+					}
+				}
+				first = false
+
+				items, err := uc.ScrapeCourseList(ctx, urls[i], site.BaseURL())
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("scrape course list %s: %w", urls[i], err)
+						cancel()
+					}
+					mu.Unlock()
+					return
+				}
+				pages[i] = items
+			}
+		}()
+	}
+
+	for i := range urls {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	items := make([]scraper.CourseListItem, 0)
+	seen := make(map[string]struct{})
+	for _, page := range pages {
+		for _, item := range page {
+			item.DetailURL = strings.TrimSpace(item.DetailURL)
+			if item.DetailURL == "" {
+				continue
+			}
+			if _, ok := seen[item.DetailURL]; ok {
+				continue
+			}
+			seen[item.DetailURL] = struct{}{}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/scraper"
+)
+
+func updateCheckListPage(rows ...[3]string) string {
+	body := ""
+	for _, row := range rows {
+		body += fmt.Sprintf(`<tr><td>%s</td><td><a href="/courses/2025/%s">%s</a></td><td>教員</td><td>講義</td><td>2025 3Q</td><td>%s</td></tr>`, row[0], row[0], row[1], row[2])
+	}
+	return `<html><body><table class="c-table"><tbody>` + body + `</tbody></table></body></html>`
+}
+
+func TestScraperUsecaseCheckForUpdatesDryRun(t *testing.T) {
+	repo, timetableRepo, _ := newUsecaseTestRepository(t)
+	seeded := []domain.Lecture{
+		{University: "Institute of Science Tokyo", Code: "LAH.S101", Title: "法学（憲法）Ａ", OpenTerm: "2025 3Q", Year: 2025, UpdatedAt: time.Date(2025, 3, 19, 0, 0, 0, 0, time.UTC)},
+		{University: "Institute of Science Tokyo", Code: "LAH.S102", Title: "法学（憲法）Ｂ", OpenTerm: "2025 3Q", Year: 2025, UpdatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if err := repo.Creates(seeded); err != nil {
+		t.Fatalf("seed lectures: %v", err)
+	}
+
+	listA := scraper.TopPageURL + "/courses/2025/4/list-a"
+	listB := scraper.TopPageURL + "/courses/2025/4/list-b"
+	topPage := fmt.Sprintf(`<html><body><a href="%s">A</a><a href="%s">B</a><a href="%s">A again</a></body></html>`, listA, listB, listA)
+	fetcher := newMockFetcher(map[string]string{
+		scraper.TopPageURL + "/courses/2025": topPage,
+		listA:                                updateCheckListPage([3]string{"LAH.S101", "法学（憲法）Ａ", "2025/3/19"}, [3]string{"LAH.S102", "法学（憲法）Ｂ", "2025/4/2"}),
+		// the same course listed again on a second page is checked once
+		listB: updateCheckListPage([3]string{"LAH.S102", "法学（憲法）Ｂ", "2025/4/2"}, [3]string{"LAH.S103", "法学（憲法）Ｃ", "2025/3/19"}),
+	})
+
+	usecase := NewScraperUsecase(fetcher, repo, timetableRepo, scraper.NewParser(), 0)
+	check, err := usecase.CheckForUpdates(context.Background(), scraper.DefaultSiteID, 2025, true)
+	if err != nil {
+		t.Fatalf("CheckForUpdates returned error: %v", err)
+	}
+	if check.ListPages != 2 || check.Listed != 3 || !check.DryRun || len(check.Lectures) != 0 {
+		t.Fatalf("unexpected check: %+v", check)
+	}
+	if len(check.Stale) != 2 {
+		t.Fatalf("expected two stale lectures, got %+v", check.Stale)
+	}
+	changed, added := check.Stale[0], check.Stale[1]
+	if changed.Code != "LAH.S102" || changed.New || !changed.StoredUpdatedAt.Equal(seeded[1].UpdatedAt) || changed.DetailURL != scraper.TopPageURL+"/courses/2025/LAH.S102" {
+		t.Fatalf("unexpected changed lecture: %+v", changed)
+	}
+	if added.Code != "LAH.S103" || !added.New {
+		t.Fatalf("unexpected new lecture: %+v", added)
+	}
+}
+
+func TestScraperUsecaseCheckForUpdatesReportsListFailure(t *testing.T) {
+	repo, timetableRepo, _ := newUsecaseTestRepository(t)
+	listURL := scraper.TopPageURL + "/courses/2025/4/missing"
+	fetcher := newMockFetcher(map[string]string{
+		scraper.TopPageURL + "/courses/2025": fmt.Sprintf(`<html><body><a href="%s">A</a></body></html>`, listURL),
+	})
+
+	usecase := NewScraperUsecase(fetcher, repo, timetableRepo, scraper.NewParser(), 0)
+	_, err := usecase.CheckForUpdates(context.Background(), scraper.DefaultSiteID, 2025, true)
+	if err == nil || !strings.Contains(err.Error(), "scrape course list "+listURL) {
+		t.Fatalf("expected the failed list page to be reported, got %v", err)
+	}
+}
+
+func updateCheckDetailPage(code, title, updatedAt string) string {
+	return fmt.Sprintf(`<html><body><h1 class="c-h1">%s</h1>
+<div class="c-dl-2col__item"><dt>科目コード</dt><dd>%s</dd></div>
+<div class="c-dl-2col__item"><dt>開講時期</dt><dd>2025年度</dd></div>
+<div class="c-dl-2col__item"><dt>開講クォーター</dt><dd>3Q</dd></div>
+<div class="c-dl-2col__item"><dt>シラバス更新日</dt><dd>%s</dd></div>
+</body></html>`, title, code, updatedAt)
+}
+
+func TestScraperUsecaseCheckForUpdatesStoresStaleLectures(t *testing.T) {
+	repo, timetableRepo, _ := newUsecaseTestRepository(t)
+	seeded := domain.Lecture{University: "Institute of Science Tokyo", Code: "LAH.S101", Title: "法学（憲法）Ａ", OpenTerm: "2025 3Q", Year: 2025, UpdatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}
+	if err := repo.Create(&seeded); err != nil {
+		t.Fatalf("seed lecture: %v", err)
+	}
+
+	site := scraper.NewISCTSite(nil)
+	listURL := scraper.TopPageURL + "/courses/2025/4/list"
+	detailURL := scraper.TopPageURL + "/courses/2025/LAH.S101"
+	fetcher := newMockFetcher(map[string]string{
+		scraper.TopPageURL + "/courses/2025": fmt.Sprintf(`<html><body><a href="%s">A</a></body></html>`, listURL),
+		listURL:                              updateCheckListPage([3]string{"LAH.S101", "法学（憲法）Ａ", "2025/3/19"}),
+		detailURL:                            updateCheckDetailPage("LAH.S101", "法学（憲法）Ａ", "2025/3/19"),
+		site.EnglishURL(detailURL):           updateCheckDetailPage("LAH.S101", "Constitutional Law A", "2025/3/19"),
+	})
+
+	usecase := NewScraperUsecase(fetcher, repo, timetableRepo, scraper.NewParser(), 0)
+	check, err := usecase.CheckForUpdates(context.Background(), scraper.DefaultSiteID, 2025, false)
+	if err != nil {
+		t.Fatalf("CheckForUpdates returned error: %v", err)
+	}
+	if len(check.Stale) != 1 || check.Stale[0].New || len(check.Lectures) != 1 {
+		t.Fatalf("expected the changed lecture to be fetched and stored, got %+v", check)
+	}
+	if check.Lectures[0].ID == 0 || check.Lectures[0].ID == seeded.ID {
+		t.Fatalf("expected the lecture to be stored as a new row, got id %d", check.Lectures[0].ID)
+	}
+
+	// the re-scraped row is newer than the seeded one, which stays stored
+	again, err := usecase.CheckForUpdates(context.Background(), scraper.DefaultSiteID, 2025, false)
+	if err != nil {
+		t.Fatalf("CheckForUpdates returned error on the second run: %v", err)
+	}
+	if len(again.Stale) != 0 || len(again.Lectures) != 0 {
+		t.Fatalf("expected nothing stale after storing the update, got %+v", again)
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kavos113/desy/backend/domain"
+)
+
+// UserDataUsecase manages the user's bookmarks, tags and private notes on lectures. The data is stored
+// against the course identity of the lecture, so it stays attached when the lecture is scraped again.
+type UserDataUsecase interface {
+	SetBookmark(lectureID int, bookmarked bool) error
+	ListBookmarks() ([]domain.LectureSummary, error)
+	AddTag(lectureID int, tag string) error
+	RemoveTag(lectureID int, tag string) error
+	GetTags(lectureID int) ([]string, error)
+	ListTags() ([]string, error)
+	SaveNote(lectureID int, body string) error
+	GetNote(lectureID int) (*domain.CourseNote, error)
+}
+
+type userDataUsecase struct {
+	userDataRepo domain.UserDataRepository
+	lectureRepo  domain.LectureRepository
+}
+
+func NewUserDataUsecase(userDataRepo domain.UserDataRepository, lectureRepo domain.LectureRepository) UserDataUsecase {
+	return &userDataUsecase{
+		userDataRepo: userDataRepo,
+		lectureRepo:  lectureRepo,
+	}
+}
+
+func (uc *userDataUsecase) SetBookmark(lectureID int, bookmarked bool) error {
+	course, err := uc.identityOf(lectureID)
+	if err != nil {
+		return err
+	}
+	return uc.userDataRepo.SetBookmark(course, bookmarked)
+}
+
+// ListBookmarks returns the stored lectures of every bookmarked course, newest year first.
+func (uc *userDataUsecase) ListBookmarks() ([]domain.LectureSummary, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+	return uc.lectureRepo.Search(domain.SearchQuery{BookmarkedOnly: true})
+}
+
+func (uc *userDataUsecase) AddTag(lectureID int, tag string) error {
+	if domain.NormalizeTag(tag) == "" {
+		return errors.New("tag is empty")
+	}
+	course, err := uc.identityOf(lectureID)
+	if err != nil {
+		return err
+	}
+	return uc.userDataRepo.AddTag(course, tag)
+}
+
+func (uc *userDataUsecase) RemoveTag(lectureID int, tag string) error {
+	course, err := uc.identityOf(lectureID)
+	if err != nil {
+		return err
+	}
+	return uc.userDataRepo.RemoveTag(course, tag)
+}
+
+func (uc *userDataUsecase) GetTags(lectureID int) ([]string, error) {
+	course, err := uc.identityOf(lectureID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.userDataRepo.FindTags(course)
+}
+
+func (uc *userDataUsecase) ListTags() ([]string, error) {
+	if err := uc.ready(); err != nil {
+		return nil, err
+	}
+	return uc.userDataRepo.ListTags()
+}
+
+// SaveNote replaces the note of the lecture's course; a blank body deletes it.
+func (uc *userDataUsecase) SaveNote(lectureID int, body string) error {
+	course, err := uc.identityOf(lectureID)
+	if err != nil {
+		return err
+	}
+	return uc.userDataRepo.SaveNote(course, body)
+}
+
+// GetNote returns the note of the lecture's course, or nil when there is none.
+func (uc *userDataUsecase) GetNote(lectureID int) (*domain.CourseNote, error) {
+	course, err := uc.identityOf(lectureID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.userDataRepo.FindNote(course)
+}
+
+func (uc *userDataUsecase) ready() error {
+	if uc == nil || uc.userDataRepo == nil {
+		return errors.New("user data repository is not initialized")
+	}
+	if uc.lectureRepo == nil {
+		return errors.New("lecture repository is not initialized")
+	}
+	return nil
+}
+
+func (uc *userDataUsecase) identityOf(lectureID int) (domain.CourseIdentity, error) {
+	if err := uc.ready(); err != nil {
+		return domain.CourseIdentity{}, err
+	}
+	lecture, err := uc.lectureRepo.FindByID(lectureID)
+	if err != nil {
+		return domain.CourseIdentity{}, err
+	}
+	if lecture == nil {
+		return domain.CourseIdentity{}, fmt.Errorf("lecture %d not found", lectureID)
+	}
+	return domain.IdentityOf(lecture.University, lecture.Code, lecture.Title, lecture.Year), nil
+}
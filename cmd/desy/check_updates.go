@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+func runCheckUpdates(args []string) error {
+	fs := flag.NewFlagSet("check-updates", flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDatabasePath, "syllabus database")
+	siteID := fs.String("site", "", "site to check (defaults to the default site)")
+	year := fs.Int("year", domain.AcademicYear(time.Now()), "academic year to check")
+	dryRun := fs.Bool("dry-run", false, "only list the stale lectures without fetching their detail pages")
+	delay := fs.Duration("delay", 3*time.Second, "delay between page fetches")
+	selectors := fs.String("selectors", defaultSelectorConfigPath, "parser selector config override (the embedded config is used when the default file is missing)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	sites, err := siteRegistry(*selectors)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	lectureRepo, err := sqlite.NewLectureRepository(db)
+	if err != nil {
+		return err
+	}
+	timetableRepo, err := sqlite.NewTimetableRepository(db)
+	if err != nil {
+		return err
+	}
+	scrapeRunRepo, err := sqlite.NewScrapeRunRepository(db)
+	if err != nil {
+		return err
+	}
+	followUp, err := newScrapeFollowUp(db, lectureRepo)
+	if err != nil {
+		return err
+	}
+
+	fetcher := usecase.NewHTTPFetcher(&http.Client{Timeout: 15 * time.Second})
+	scraperUsecase := usecase.NewScraperUsecaseWithSites(fetcher, lectureRepo, timetableRepo, sites, *delay)
+	scraperUsecase.SetScrapeRunRepository(scrapeRunRepo)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	check, err := scraperUsecase.CheckForUpdates(ctx, *siteID, *year, *dryRun)
+	if err != nil {
+		return err
+	}
+	for _, stale := range check.Stale {
+		state := "updated"
+		if stale.New {
+			state = "new"
+		}
+		fmt.Printf("%-8s %-12s %s\t%s\n", state, stale.Code, stale.Title, stale.DetailURL)
+	}
+	if check.DryRun {
+		fmt.Fprintf(os.Stderr, "%d of %d listed lectures on %d list pages are stale\n", len(check.Stale), check.Listed, check.ListPages)
+	} else {
+		fmt.Fprintf(os.Stderr, "%d of %d listed lectures on %d list pages were stale; stored %d\n", len(check.Stale), check.Listed, check.ListPages, len(check.Lectures))
+	}
+	if len(check.Lectures) > 0 {
+		for _, notification := range followUp.run(ctx) {
+			fmt.Fprintf(os.Stderr, "saved search %q: %d changes\n", notification.SearchName, len(notification.Changes))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+// defaultRefreshSpec refreshes at 3am when neither -schedule nor a stored schedule is given.
+const defaultRefreshSpec = "0 3 * * *"
+
+// daemonEvent is one line of the event stream the daemon writes to stdout.
+type daemonEvent struct {
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+func runDaemon(args []string) error {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDatabasePath, "syllabus database")
+	siteID := fs.String("site", "", "site to refresh (defaults to the stored schedule's site, then the default site)")
+	spec := fs.String("schedule", "", "cron-style schedule, e.g. \"0 3 * * *\" (defaults to the schedule set in the app, then "+defaultRefreshSpec+")")
+	once := fs.Bool("once", false, "refresh once now and exit")
+	delay := fs.Duration("delay", 3*time.Second, "delay between page fetches")
+	selectors := fs.String("selectors", defaultSelectorConfigPath, "parser selector config override (the embedded config is used when the default file is missing)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	sites, err := siteRegistry(*selectors)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	lectureRepo, err := sqlite.NewLectureRepository(db)
+	if err != nil {
+		return err
+	}
+	timetableRepo, err := sqlite.NewTimetableRepository(db)
+	if err != nil {
+		return err
+	}
+	scrapeRunRepo, err := sqlite.NewScrapeRunRepository(db)
+	if err != nil {
+		return err
+	}
+	settingRepo, err := sqlite.NewRefreshSettingRepository(db)
+	if err != nil {
+		return err
+	}
+	followUp, err := newScrapeFollowUp(db, lectureRepo)
+	if err != nil {
+		return err
+	}
+
+	fetcher := usecase.NewHTTPFetcher(&http.Client{Timeout: 15 * time.Second})
+	scraperUsecase := usecase.NewScraperUsecaseWithSites(fetcher, lectureRepo, timetableRepo, sites, *delay)
+	scraperUsecase.SetScrapeRunRepository(scrapeRunRepo)
+	refreshUsecase := usecase.NewRefreshUsecase(settingRepo, scraperUsecase, nil)
+
+	status, err := refreshUsecase.GetStatus()
+	if err != nil {
+		return err
+	}
+	if status.Setting != nil {
+		if *siteID == "" {
+			*siteID = status.Setting.SiteID
+		}
+		if *spec == "" {
+			*spec = status.Setting.Spec
+		}
+	}
+	if *spec == "" {
+		*spec = defaultRefreshSpec
+	}
+	schedule, err := domain.ParseRefreshSchedule(*spec)
+	if err != nil {
+		return err
+	}
+
+	events := json.NewEncoder(os.Stdout)
+	emit := func(event string, data any) {
+		if err := events.Encode(daemonEvent{Event: event, Data: data}); err != nil {
+			log.Printf("write %s event: %v", event, err)
+		}
+	}
+	notify := func(ctx context.Context, outcome usecase.RefreshOutcome) {
+		emit("scheduled_refresh", outcome)
+		if outcome.Updated == 0 {
+			return
+		}
+		for _, notification := range followUp.run(ctx) {
+			emit("saved_search_changes", notification)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *once {
+		outcome, err := refreshUsecase.RefreshCurrentYear(ctx, *siteID)
+		notify(ctx, outcome)
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "refreshing on %q, next at %s\n", schedule.Spec, schedule.Next(time.Now()).Format(time.RFC3339))
+	err = refreshUsecase.RunSchedule(ctx, schedule, *siteID, func(outcome usecase.RefreshOutcome) {
+		notify(ctx, outcome)
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+
+	_ "modernc.org/sqlite"
+)
+
+// defaultDatabasePath is the database the desktop app uses when started from the same directory.
+const defaultDatabasePath = "desy.db"
+
+// openDatabase opens the syllabus database at path and brings its schema up to date.
+func openDatabase(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	if _, err := sqlite.NewLectureRepository(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init schema: %w", err)
+	}
+	return db, nil
+}
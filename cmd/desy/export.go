@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDatabasePath, "syllabus database")
+	format := fs.String("format", "csv", "output format: csv, jsonl or xlsx")
+	lectures := fs.String("lectures", "", "comma separated lecture IDs to export (defaults to the search results)")
+	columns := fs.String("columns", "", "comma separated column IDs (use -columns list to show them)")
+	year := fs.Int("year", 0, "search: lectures of this year")
+	title := fs.String("title", "", "search: title contains")
+	departments := fs.String("departments", "", "search: comma separated departments")
+	teacher := fs.String("teacher", "", "search: teacher name contains")
+	keywords := fs.String("keywords", "", "search: comma separated keywords")
+	output := fs.String("o", "", "output file (defaults to stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *columns == "list" {
+		for _, column := range domain.ExportColumns {
+			fmt.Printf("%-20s %s\n", column.ID, column.Header)
+		}
+		return nil
+	}
+
+	lectureIDs, err := parseIDList(*lectures)
+	if err != nil {
+		return err
+	}
+	request := usecase.LectureExportRequest{
+		LectureIDs: lectureIDs,
+		Query: domain.SearchQuery{
+			Year:        *year,
+			Title:       *title,
+			Departments: splitFlagList(*departments),
+			TeacherName: *teacher,
+			Keywords:    splitFlagList(*keywords),
+		},
+		Columns: splitFlagList(*columns),
+	}
+
+	db, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	lectureRepo, err := sqlite.NewLectureRepository(db)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	count, err := usecase.NewExportUsecase(lectureRepo).ExportLectures(out, usecase.ExportFormat(*format), request)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d lectures\n", count)
+	return nil
+}
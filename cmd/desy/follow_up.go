@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+// scrapeFollowUp brings the recommendation index and the saved search inbox up to date after lectures
+// were stored, as the app does after a scrape.
+type scrapeFollowUp struct {
+	recommendations usecase.RecommendationUsecase
+	savedSearches   usecase.SavedSearchUsecase
+}
+
+func newScrapeFollowUp(db *sql.DB, lectureRepo domain.LectureRepository) (*scrapeFollowUp, error) {
+	recommendationRepo, err := sqlite.NewRecommendationRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	savedSearchRepo, err := sqlite.NewSavedSearchRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	return &scrapeFollowUp{
+		recommendations: usecase.NewRecommendationUsecase(recommendationRepo, lectureRepo),
+		savedSearches:   usecase.NewSavedSearchUsecase(savedSearchRepo, lectureRepo),
+	}, nil
+}
+
+// run refreshes the recommendation index and returns the notifications of saved searches whose result
+// changed. Failures are logged; the lectures are stored either way.
+func (f *scrapeFollowUp) run(ctx context.Context) []domain.SearchNotification {
+	if _, err := f.recommendations.RefreshIndex(ctx); err != nil {
+		log.Printf("refresh recommendation index: %v", err)
+	}
+	notifications, err := f.savedSearches.CheckSearches()
+	if err != nil {
+		log.Printf("check saved searches: %v", err)
+		return nil
+	}
+	return notifications
+}
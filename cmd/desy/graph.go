@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+func runGraph(args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDatabasePath, "syllabus database")
+	format := fs.String("format", "dot", "output format: dot or json")
+	year := fs.Int("year", 0, "only lectures of this year (0 for every year)")
+	lectures := fs.String("lectures", "", "comma separated lecture IDs whose prerequisites to export (defaults to the whole graph)")
+	kinds := fs.String("kinds", "", "comma separated relation kinds: prerequisite, recommended, related (defaults to all)")
+	output := fs.String("o", "", "output file (defaults to stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	lectureIDs, err := parseIDList(*lectures)
+	if err != nil {
+		return err
+	}
+	relationKinds := make([]domain.CourseRelationKind, 0)
+	for _, kind := range splitFlagList(*kinds) {
+		relationKinds = append(relationKinds, domain.CourseRelationKind(kind))
+	}
+
+	db, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	graphRepo, err := sqlite.NewCourseGraphRepository(db)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return usecase.NewCourseGraphUsecase(graphRepo).ExportGraph(out, usecase.GraphFormat(*format), *year, lectureIDs, relationKinds)
+}
+
+func splitFlagList(raw string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseIDList(raw string) ([]int, error) {
+	ids := make([]int, 0)
+	for _, item := range splitFlagList(raw) {
+		id, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lecture id %q", item)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
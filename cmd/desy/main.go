@@ -0,0 +1,83 @@
+// Command desy runs syllabus maintenance tasks without the desktop UI.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"check-updates": {
+		usage: "fetch only list pages and refresh the lectures whose list entry changed",
+		run:   runCheckUpdates,
+	},
+	"daemon": {
+		usage: "refresh the current year on a cron-style schedule",
+		run:   runDaemon,
+	},
+	"export": {
+		usage: "export lectures to CSV, JSON Lines or Excel",
+		run:   runExport,
+	},
+	"graph": {
+		usage: "export the course relation graph as Graphviz DOT or JSON",
+		run:   runGraph,
+	},
+	"report": {
+		usage: "write a printable timetable and syllabus report as HTML",
+		run:   runReport,
+	},
+	"site": {
+		usage: "generate a static HTML syllabus site",
+		run:   runSite,
+	},
+	"validate-selectors": {
+		usage: "check a sample syllabus page against the parser selector config",
+		run:   runValidateSelectors,
+	},
+}
+
+// errFailed reports a command that already printed its outcome and only needs a nonzero exit.
+var errFailed = errors.New("failed")
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if !errors.Is(err, errFailed) {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+		}
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: desy <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", name, commands[name].usage)
+	}
+}
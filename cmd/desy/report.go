@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kavos113/desy/backend/domain"
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDatabasePath, "syllabus database")
+	lectures := fs.String("lectures", "", "comma separated lecture IDs to include (defaults to the search results)")
+	title := fs.String("title", "", "report title")
+	year := fs.Int("year", 0, "search: lectures of this year")
+	departments := fs.String("departments", "", "search: comma separated departments")
+	teacher := fs.String("teacher", "", "search: teacher name contains")
+	templates := fs.String("templates", "", "directory holding a report.html overriding the default")
+	output := fs.String("o", "", "output HTML file (defaults to stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	lectureIDs, err := parseIDList(*lectures)
+	if err != nil {
+		return err
+	}
+	request := usecase.TimetableReportRequest{
+		Title:      *title,
+		LectureIDs: lectureIDs,
+		Query: domain.SearchQuery{
+			Year:        *year,
+			Departments: splitFlagList(*departments),
+			TeacherName: *teacher,
+		},
+		TemplateDir: *templates,
+	}
+
+	db, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	lectureRepo, err := sqlite.NewLectureRepository(db)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	count, err := usecase.NewSiteUsecase(lectureRepo).WriteTimetableReport(out, request)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "reported %d lectures\n", count)
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/kavos113/desy/backend/presentation/scraper"
+)
+
+// defaultSelectorConfigPath is the selector override the desktop app reads from the same directory.
+const defaultSelectorConfigPath = "selectors.json"
+
+// siteRegistry builds the site registry, parsing pages with the selector override at path. A missing
+// default override falls back to the embedded selectors like the app does; any other path must load.
+func siteRegistry(path string) (*scraper.Registry, error) {
+	if path == "" {
+		return scraper.DefaultRegistry(), nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) && path == defaultSelectorConfigPath {
+		return scraper.DefaultRegistry(), nil
+	}
+
+	config, err := scraper.LoadSelectorConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := scraper.NewParserWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return scraper.NewRegistry(scraper.NewISCTSite(parser))
+}
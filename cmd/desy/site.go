@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kavos113/desy/backend/presentation/repository/sqlite"
+	"github.com/kavos113/desy/backend/usecase"
+)
+
+func runSite(args []string) error {
+	fs := flag.NewFlagSet("site", flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDatabasePath, "syllabus database")
+	output := fs.String("o", "site", "output directory")
+	years := fs.String("years", "", "comma separated years to publish (defaults to every year)")
+	title := fs.String("title", "", "site title")
+	templates := fs.String("templates", "", "directory of templates and assets overriding the defaults")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	yearList, err := parseIDList(*years)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	lectureRepo, err := sqlite.NewLectureRepository(db)
+	if err != nil {
+		return err
+	}
+
+	report, err := usecase.NewSiteUsecase(lectureRepo).GenerateSite(*output, usecase.SiteOptions{
+		Title:       *title,
+		Years:       yearList,
+		TemplateDir: *templates,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "generated %d pages for %d lectures and %d teachers in %s\n", report.Pages, report.Lectures, report.Teachers, *output)
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kavos113/desy/backend/presentation/scraper"
+)
+
+func runValidateSelectors(args []string) error {
+	fs := flag.NewFlagSet("validate-selectors", flag.ContinueOnError)
+	configPath := fs.String("config", "", "selector config override (defaults to the embedded config)")
+	pagePath := fs.String("page", "", "saved syllabus page to check")
+	kind := fs.String("kind", "detail", "page kind: detail or list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *pagePath == "" {
+		return errors.New("-page is required")
+	}
+
+	config := scraper.DefaultSelectorConfig()
+	if *configPath != "" {
+		loaded, err := scraper.LoadSelectorConfig(*configPath)
+		if err != nil {
+			return err
+		}
+		config = loaded
+	}
+
+	page, err := os.Open(*pagePath)
+	if err != nil {
+		return fmt.Errorf("open page: %w", err)
+	}
+	defer page.Close()
+
+	var report scraper.SelectorReport
+	switch *kind {
+	case "detail":
+		report, err = config.ValidateDetailPage(page)
+	case "list":
+		report, err = config.ValidateListPage(page)
+	default:
+		return fmt.Errorf("unknown page kind: %s", *kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, check := range report.Checks {
+		status := "ok"
+		switch {
+		case !check.Found && check.Required:
+			status = "MISSING"
+		case !check.Found:
+			status = "missing"
+		}
+		fmt.Printf("%-8s %-28s %s\n", status, check.Field, check.Matched)
+	}
+
+	if !report.OK() {
+		fmt.Println("required fields are missing")
+		return errFailed
+	}
+	return nil
+}
@@ -63,7 +63,7 @@ const ListTable = ({ items, onSort, className }: ListTableProps) => {
       const results = await Promise.allSettled(
         targets.map(async (relatedId) => {
           try {
-            const detail = await GetLectureDetails(relatedId);
+            const detail = await GetLectureDetails(relatedId, '');
             return detail;
           } catch (error) {
             console.error('GetLectureDetails failed for related lecture', relatedId, error);
@@ -99,7 +99,7 @@ const ListTable = ({ items, onSort, className }: ListTableProps) => {
 
         let lecture = lectureCache[id];
         if (!lecture) {
-          lecture = await GetLectureDetails(id);
+          lecture = await GetLectureDetails(id, '');
         }
 
         if (!lecture) {
@@ -111,7 +111,7 @@ const FetchButton = () => {
     setIsFetching(true);
     setStatus('Fetching...');
     try {
-      await Scrape();
+      await Scrape('');
       setStatus(COMPLETE_STATUS);
     } catch (error) {
       console.error('Scrape failed', error);
@@ -149,7 +149,7 @@ const FetchButton = () => {
     setIsFetching(true);
     setStatus('Fetch-All...');
     try {
-      await ScrapeAll();
+      await ScrapeAll('');
       setStatus(COMPLETE_STATUS);
     } catch (error) {
       console.error('ScrapeAll failed', error);
@@ -36,6 +36,7 @@ const createInitialState = (): SearchState => ({
 });
 
 const buildSearchQuery = (state: SearchState) => {
+  const university = state.university[0] ?? '';
   const title = state.title[0] ?? '';
   const teacherName = state.lecturer[0] ?? '';
   const room = state.room[0] ?? '';
@@ -63,6 +64,7 @@ const buildSearchQuery = (state: SearchState) => {
     .filter((value): value is string => typeof value === 'string');
 
   return domain.SearchQuery.createFrom({
+    University: university,
     Title: title,
     Keywords: keywords,
     Departments: state.department,
@@ -1,4 +1,4 @@
-import { render, screen, waitFor } from '@testing-library/react';
+import { fireEvent, render, screen, waitFor } from '@testing-library/react';
 import { act } from 'react';
 import userEvent from '@testing-library/user-event';
 import { beforeEach, describe, expect, it, vi } from 'vitest';
@@ -57,6 +57,30 @@ describe('Search', () => {
     expect(query.Room ?? '').toBe('本館');
   });
 
+  it('大学を検索条件として送信できる', async () => {
+    const user = userEvent.setup();
+    render(<Search />);
+
+    const menuButton = screen.getByRole('button', { name: '大学を選択' });
+    const menuItem = menuButton.closest('li');
+    if (!menuItem) {
+      throw new Error('大学メニューのリスト要素が見つかりません');
+    }
+
+    fireEvent.mouseEnter(menuItem);
+    await act(async () => {
+      await user.click(screen.getByText('東京科学大学'));
+      await user.click(screen.getByRole('button', { name: 'Search' }));
+    });
+
+    await waitFor(() => {
+      expect(searchLecturesMock).toHaveBeenCalledTimes(1);
+    });
+
+    const [[query]] = searchLecturesMock.mock.calls;
+    expect(query.University ?? '').toBe('東京科学大学');
+  });
+
   it('リセットボタンで検索条件を初期化できる', async () => {
     const user = userEvent.setup();
     render(<Search />);
@@ -137,7 +137,7 @@ export const GRADE_LABELS = LEVEL_OPTIONS.map((option) => option.label);
 export const QUARTER_LABELS = ['1Q', '2Q', '3Q', '4Q'];
 
 export const UNIVERSITIES_MENU: Menu = {
-  大学を選択: ['東京工業大学', '一橋大学']
+  大学を選択: ['東京科学大学', '一橋大学']
 };
 
 export const DEPARTMENTS_MENU: Menu = {
@@ -1,17 +1,132 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {usecase} from '../models';
 import {domain} from '../models';
 
-export function GetLectureDetails(arg1:number):Promise<domain.Lecture>;
+export function AddLectureTag(arg1:number,arg2:string):Promise<void>;
+
+export function CheckForUpdates(arg1:string,arg2:boolean):Promise<usecase.UpdateCheck>;
+
+export function CheckRequirementSource(arg1:string,arg2:Array<number>,arg3:Array<number>,arg4:number):Promise<domain.RequirementReport>;
+
+export function CheckRequirements(arg1:string,arg2:Array<number>,arg3:Array<number>,arg4:number):Promise<domain.RequirementReport>;
+
+export function CheckSavedSearches():Promise<Array<domain.SearchNotification>>;
+
+export function CompareCourseOfferings(arg1:string):Promise<Array<domain.OfferingComparison>>;
+
+export function CreateResearchFilterRule(arg1:domain.ResearchFilterRule):Promise<domain.ResearchFilterRule>;
+
+export function DeleteRequirementSet(arg1:string):Promise<void>;
+
+export function DeleteResearchFilterRule(arg1:number):Promise<void>;
+
+export function DeleteSavedSearch(arg1:number):Promise<void>;
+
+export function DeleteTranscriptEntry(arg1:number):Promise<void>;
+
+export function DisableRefreshSchedule():Promise<void>;
+
+export function ExportCourseGraph(arg1:string,arg2:number,arg3:Array<number>,arg4:Array<domain.CourseRelationKind>):Promise<string>;
+
+export function ExportDataset(arg1:string):Promise<domain.DatasetManifest>;
+
+export function ExportLectures(arg1:string,arg2:usecase.LectureExportRequest):Promise<string>;
+
+export function ExportTimetableReport(arg1:usecase.TimetableReportRequest):Promise<string>;
+
+export function FindCourseCycles(arg1:number,arg2:Array<domain.CourseRelationKind>):Promise<Array<any>>;
+
+export function FindCoursePath(arg1:number,arg2:number,arg3:Array<domain.CourseRelationKind>):Promise<Array<domain.CourseNode>>;
+
+export function FindFreeRooms(arg1:number,arg2:domain.Semester,arg3:domain.DayOfWeek,arg4:domain.Period,arg5:string):Promise<Array<domain.Room>>;
+
+export function GetCourseOfferings(arg1:string):Promise<Array<domain.CourseOffering>>;
+
+export function GetLectureDetails(arg1:number,arg2:string):Promise<domain.Lecture>;
+
+export function GetLectureNote(arg1:number):Promise<domain.CourseNote>;
+
+export function GetLectureSessions(arg1:Array<number>,arg2:Array<domain.LecturePlanKind>):Promise<Array<domain.LectureSession>>;
+
+export function GetLectureTags(arg1:number):Promise<Array<string>>;
+
+export function GetOfficeHoursToday(arg1:number):Promise<Array<domain.OfficeHour>>;
+
+export function GetPrerequisiteGraph(arg1:number,arg2:Array<domain.CourseRelationKind>):Promise<domain.CourseGraph>;
+
+export function GetRefreshSchedule():Promise<usecase.RefreshStatus>;
+
+export function GetRoomSchedule(arg1:number,arg2:number):Promise<domain.RoomSchedule>;
+
+export function GetScrapeRunReport(arg1:number):Promise<usecase.ScrapeRunReport>;
+
+export function GetStudyOrder(arg1:Array<number>,arg2:Array<domain.CourseRelationKind>):Promise<Array<domain.CourseNode>>;
+
+export function GetTeacherSchedule(arg1:string,arg2:number):Promise<domain.TeacherSchedule>;
+
+export function GetTranscriptSummary():Promise<domain.TranscriptSummary>;
 
 export function Greet(arg1:string):Promise<string>;
 
+export function ImportDataset(arg1:string):Promise<domain.DatasetImportResult>;
+
+export function ImportTranscript():Promise<usecase.TranscriptImportResult>;
+
+export function ImportTranscriptText(arg1:string):Promise<usecase.TranscriptImportResult>;
+
+export function ListBookmarks():Promise<Array<domain.LectureSummary>>;
+
+export function ListExportColumns():Promise<Array<domain.ExportColumn>>;
+
+export function ListRequirementSets():Promise<Array<domain.RequirementSet>>;
+
+export function ListResearchFilterRules():Promise<Array<domain.ResearchFilterRule>>;
+
+export function ListRooms():Promise<Array<domain.Room>>;
+
+export function ListSavedSearches():Promise<Array<domain.SavedSearch>>;
+
+export function ListScrapeRuns(arg1:string):Promise<Array<domain.ScrapeRun>>;
+
+export function ListSearchNotifications(arg1:boolean):Promise<Array<domain.SearchNotification>>;
+
+export function ListSites():Promise<Array<usecase.SiteInfo>>;
+
+export function ListTags():Promise<Array<string>>;
+
+export function ListTranscriptEntries():Promise<Array<domain.TranscriptEntry>>;
+
+export function MarkSearchNotificationRead(arg1:number):Promise<void>;
+
+export function MigrateCourseReferences():Promise<number>;
+
 export function MigrateRelatedCourses():Promise<number>;
 
-export function Scrape():Promise<void>;
+export function RecommendLectures(arg1:Array<number>,arg2:number,arg3:domain.SearchQuery):Promise<Array<domain.Recommendation>>;
+
+export function RefreshRecommendationIndex():Promise<number>;
+
+export function RemoveLectureTag(arg1:number,arg2:string):Promise<void>;
+
+export function RunSavedSearch(arg1:number):Promise<Array<domain.LectureSummary>>;
 
-export function ScrapeAll():Promise<void>;
+export function SaveLectureNote(arg1:number,arg2:string):Promise<void>;
+
+export function SaveRequirementSet(arg1:string,arg2:string):Promise<domain.RequirementSet>;
+
+export function SaveSearch(arg1:string,arg2:domain.SearchQuery):Promise<domain.SavedSearch>;
+
+export function Scrape(arg1:string):Promise<void>;
+
+export function ScrapeAll(arg1:string):Promise<void>;
 
 export function ScrapeTest():Promise<void>;
 
 export function SearchLectures(arg1:domain.SearchQuery):Promise<Array<domain.LectureSummary>>;
+
+export function SetBookmark(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetRefreshSchedule(arg1:string,arg2:string):Promise<usecase.RefreshStatus>;
+
+export function UpdateResearchFilterRule(arg1:domain.ResearchFilterRule):Promise<void>;
@@ -2,24 +2,240 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function GetLectureDetails(arg1) {
-  return window['go']['main']['App']['GetLectureDetails'](arg1);
+export function AddLectureTag(arg1, arg2) {
+  return window['go']['main']['App']['AddLectureTag'](arg1, arg2);
+}
+
+export function CheckForUpdates(arg1, arg2) {
+  return window['go']['main']['App']['CheckForUpdates'](arg1, arg2);
+}
+
+export function CheckRequirementSource(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CheckRequirementSource'](arg1, arg2, arg3, arg4);
+}
+
+export function CheckRequirements(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CheckRequirements'](arg1, arg2, arg3, arg4);
+}
+
+export function CheckSavedSearches() {
+  return window['go']['main']['App']['CheckSavedSearches']();
+}
+
+export function CompareCourseOfferings(arg1) {
+  return window['go']['main']['App']['CompareCourseOfferings'](arg1);
+}
+
+export function CreateResearchFilterRule(arg1) {
+  return window['go']['main']['App']['CreateResearchFilterRule'](arg1);
+}
+
+export function DeleteRequirementSet(arg1) {
+  return window['go']['main']['App']['DeleteRequirementSet'](arg1);
+}
+
+export function DeleteResearchFilterRule(arg1) {
+  return window['go']['main']['App']['DeleteResearchFilterRule'](arg1);
+}
+
+export function DeleteSavedSearch(arg1) {
+  return window['go']['main']['App']['DeleteSavedSearch'](arg1);
+}
+
+export function DeleteTranscriptEntry(arg1) {
+  return window['go']['main']['App']['DeleteTranscriptEntry'](arg1);
+}
+
+export function DisableRefreshSchedule() {
+  return window['go']['main']['App']['DisableRefreshSchedule']();
+}
+
+export function ExportCourseGraph(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportCourseGraph'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportDataset(arg1) {
+  return window['go']['main']['App']['ExportDataset'](arg1);
+}
+
+export function ExportLectures(arg1, arg2) {
+  return window['go']['main']['App']['ExportLectures'](arg1, arg2);
+}
+
+export function ExportTimetableReport(arg1) {
+  return window['go']['main']['App']['ExportTimetableReport'](arg1);
+}
+
+export function FindCourseCycles(arg1, arg2) {
+  return window['go']['main']['App']['FindCourseCycles'](arg1, arg2);
+}
+
+export function FindCoursePath(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FindCoursePath'](arg1, arg2, arg3);
+}
+
+export function FindFreeRooms(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['FindFreeRooms'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GetCourseOfferings(arg1) {
+  return window['go']['main']['App']['GetCourseOfferings'](arg1);
+}
+
+export function GetLectureDetails(arg1, arg2) {
+  return window['go']['main']['App']['GetLectureDetails'](arg1, arg2);
+}
+
+export function GetLectureNote(arg1) {
+  return window['go']['main']['App']['GetLectureNote'](arg1);
+}
+
+export function GetLectureSessions(arg1, arg2) {
+  return window['go']['main']['App']['GetLectureSessions'](arg1, arg2);
+}
+
+export function GetLectureTags(arg1) {
+  return window['go']['main']['App']['GetLectureTags'](arg1);
+}
+
+export function GetOfficeHoursToday(arg1) {
+  return window['go']['main']['App']['GetOfficeHoursToday'](arg1);
+}
+
+export function GetPrerequisiteGraph(arg1, arg2) {
+  return window['go']['main']['App']['GetPrerequisiteGraph'](arg1, arg2);
+}
+
+export function GetRefreshSchedule() {
+  return window['go']['main']['App']['GetRefreshSchedule']();
+}
+
+export function GetRoomSchedule(arg1, arg2) {
+  return window['go']['main']['App']['GetRoomSchedule'](arg1, arg2);
+}
+
+export function GetScrapeRunReport(arg1) {
+  return window['go']['main']['App']['GetScrapeRunReport'](arg1);
+}
+
+export function GetStudyOrder(arg1, arg2) {
+  return window['go']['main']['App']['GetStudyOrder'](arg1, arg2);
+}
+
+export function GetTeacherSchedule(arg1, arg2) {
+  return window['go']['main']['App']['GetTeacherSchedule'](arg1, arg2);
+}
+
+export function GetTranscriptSummary() {
+  return window['go']['main']['App']['GetTranscriptSummary']();
 }
 
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function ImportDataset(arg1) {
+  return window['go']['main']['App']['ImportDataset'](arg1);
+}
+
+export function ImportTranscript() {
+  return window['go']['main']['App']['ImportTranscript']();
+}
+
+export function ImportTranscriptText(arg1) {
+  return window['go']['main']['App']['ImportTranscriptText'](arg1);
+}
+
+export function ListBookmarks() {
+  return window['go']['main']['App']['ListBookmarks']();
+}
+
+export function ListExportColumns() {
+  return window['go']['main']['App']['ListExportColumns']();
+}
+
+export function ListRequirementSets() {
+  return window['go']['main']['App']['ListRequirementSets']();
+}
+
+export function ListResearchFilterRules() {
+  return window['go']['main']['App']['ListResearchFilterRules']();
+}
+
+export function ListRooms() {
+  return window['go']['main']['App']['ListRooms']();
+}
+
+export function ListSavedSearches() {
+  return window['go']['main']['App']['ListSavedSearches']();
+}
+
+export function ListScrapeRuns(arg1) {
+  return window['go']['main']['App']['ListScrapeRuns'](arg1);
+}
+
+export function ListSearchNotifications(arg1) {
+  return window['go']['main']['App']['ListSearchNotifications'](arg1);
+}
+
+export function ListSites() {
+  return window['go']['main']['App']['ListSites']();
+}
+
+export function ListTags() {
+  return window['go']['main']['App']['ListTags']();
+}
+
+export function ListTranscriptEntries() {
+  return window['go']['main']['App']['ListTranscriptEntries']();
+}
+
+export function MarkSearchNotificationRead(arg1) {
+  return window['go']['main']['App']['MarkSearchNotificationRead'](arg1);
+}
+
+export function MigrateCourseReferences() {
+  return window['go']['main']['App']['MigrateCourseReferences']();
+}
+
 export function MigrateRelatedCourses() {
   return window['go']['main']['App']['MigrateRelatedCourses']();
 }
 
-export function Scrape() {
-  return window['go']['main']['App']['Scrape']();
+export function RecommendLectures(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RecommendLectures'](arg1, arg2, arg3);
+}
+
+export function RefreshRecommendationIndex() {
+  return window['go']['main']['App']['RefreshRecommendationIndex']();
+}
+
+export function RemoveLectureTag(arg1, arg2) {
+  return window['go']['main']['App']['RemoveLectureTag'](arg1, arg2);
+}
+
+export function RunSavedSearch(arg1) {
+  return window['go']['main']['App']['RunSavedSearch'](arg1);
 }
 
-export function ScrapeAll() {
-  return window['go']['main']['App']['ScrapeAll']();
+export function SaveLectureNote(arg1, arg2) {
+  return window['go']['main']['App']['SaveLectureNote'](arg1, arg2);
+}
+
+export function SaveRequirementSet(arg1, arg2) {
+  return window['go']['main']['App']['SaveRequirementSet'](arg1, arg2);
+}
+
+export function SaveSearch(arg1, arg2) {
+  return window['go']['main']['App']['SaveSearch'](arg1, arg2);
+}
+
+export function Scrape(arg1) {
+  return window['go']['main']['App']['Scrape'](arg1);
+}
+
+export function ScrapeAll(arg1) {
+  return window['go']['main']['App']['ScrapeAll'](arg1);
 }
 
 export function ScrapeTest() {
@@ -29,3 +245,15 @@ export function ScrapeTest() {
 export function SearchLectures(arg1) {
   return window['go']['main']['App']['SearchLectures'](arg1);
 }
+
+export function SetBookmark(arg1, arg2) {
+  return window['go']['main']['App']['SetBookmark'](arg1, arg2);
+}
+
+export function SetRefreshSchedule(arg1, arg2) {
+  return window['go']['main']['App']['SetRefreshSchedule'](arg1, arg2);
+}
+
+export function UpdateResearchFilterRule(arg1) {
+  return window['go']['main']['App']['UpdateResearchFilterRule'](arg1);
+}
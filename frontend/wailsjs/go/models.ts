@@ -1,9 +1,327 @@
 export namespace domain {
 	
+	export class ContactEntry {
+	    Kind: string;
+	    Value: string;
+	    TeacherName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContactEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Value = source["Value"];
+	        this.TeacherName = source["TeacherName"];
+	    }
+	}
+	export class CourseEdge {
+	    From: number;
+	    To: number;
+	    Kind: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseEdge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.From = source["From"];
+	        this.To = source["To"];
+	        this.Kind = source["Kind"];
+	    }
+	}
+	export class CourseNode {
+	    LectureID: number;
+	    Code: string;
+	    Title: string;
+	    Level: number;
+	    Year: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Level = source["Level"];
+	        this.Year = source["Year"];
+	    }
+	}
+	export class CourseGraph {
+	    Nodes: CourseNode[];
+	    Edges: CourseEdge[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseGraph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Nodes = this.convertValues(source["Nodes"], CourseNode);
+	        this.Edges = this.convertValues(source["Edges"], CourseEdge);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CourseIdentity {
+	    University: string;
+	    Code: string;
+	    Title: string;
+	    Year: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseIdentity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.University = source["University"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Year = source["Year"];
+	    }
+	}
+	
+	export class CourseNote {
+	    Course: CourseIdentity;
+	    Body: string;
+	    // Go type: time
+	    UpdatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseNote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Course = this.convertValues(source["Course"], CourseIdentity);
+	        this.Body = source["Body"];
+	        this.UpdatedAt = this.convertValues(source["UpdatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CourseOffering {
+	    CourseKey: string;
+	    LectureID: number;
+	    Year: number;
+	    Code: string;
+	    Title: string;
+	    Quarters: string[];
+	    Teachers: string[];
+	    Rooms: string[];
+	    Credit: number;
+	    Assessment: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseOffering(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.CourseKey = source["CourseKey"];
+	        this.LectureID = source["LectureID"];
+	        this.Year = source["Year"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Quarters = source["Quarters"];
+	        this.Teachers = source["Teachers"];
+	        this.Rooms = source["Rooms"];
+	        this.Credit = source["Credit"];
+	        this.Assessment = source["Assessment"];
+	    }
+	}
+	export class CourseReference {
+	    Kind: string;
+	    Code: string;
+	    Title: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseReference(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	    }
+	}
+	export class CourseRelation {
+	    LectureID: number;
+	    RelatedLectureID: number;
+	    Kind: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CourseRelation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.RelatedLectureID = source["RelatedLectureID"];
+	        this.Kind = source["Kind"];
+	    }
+	}
+	export class DatasetManifest {
+	    schema_version: number;
+	    sites: string[];
+	    universities: string[];
+	    years: number[];
+	    // Go type: time
+	    scraped_at: any;
+	    // Go type: time
+	    exported_at: any;
+	    lectures: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatasetManifest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema_version = source["schema_version"];
+	        this.sites = source["sites"];
+	        this.universities = source["universities"];
+	        this.years = source["years"];
+	        this.scraped_at = this.convertValues(source["scraped_at"], null);
+	        this.exported_at = this.convertValues(source["exported_at"], null);
+	        this.lectures = source["lectures"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DatasetImportResult {
+	    Manifest: DatasetManifest;
+	    Imported: number;
+	    Updated: number;
+	    Skipped: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatasetImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Manifest = this.convertValues(source["Manifest"], DatasetManifest);
+	        this.Imported = source["Imported"];
+	        this.Updated = source["Updated"];
+	        this.Skipped = source["Skipped"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ExportColumn {
+	    ID: string;
+	    Header: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Header = source["Header"];
+	    }
+	}
+	export class OfficeHour {
+	    DayOfWeek: string;
+	    Start: string;
+	    End: string;
+	    Note: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OfficeHour(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.DayOfWeek = source["DayOfWeek"];
+	        this.Start = source["Start"];
+	        this.End = source["End"];
+	        this.Note = source["Note"];
+	    }
+	}
 	export class LecturePlan {
 	    Count: number;
 	    Plan: string;
 	    Assignment: string;
+	    Goal: string;
+	    // Go type: time
+	    Date: any;
+	    Kind: string;
+	    Exam: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new LecturePlan(source);
@@ -14,7 +332,29 @@ export namespace domain {
 	        this.Count = source["Count"];
 	        this.Plan = source["Plan"];
 	        this.Assignment = source["Assignment"];
+	        this.Goal = source["Goal"];
+	        this.Date = this.convertValues(source["Date"], null);
+	        this.Kind = source["Kind"];
+	        this.Exam = source["Exam"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class Teacher {
 	    ID: number;
@@ -35,6 +375,10 @@ export namespace domain {
 	export class Room {
 	    ID: number;
 	    Name: string;
+	    Building: string;
+	    Campus: string;
+	    Number: string;
+	    Virtual: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Room(source);
@@ -44,14 +388,23 @@ export namespace domain {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.ID = source["ID"];
 	        this.Name = source["Name"];
+	        this.Building = source["Building"];
+	        this.Campus = source["Campus"];
+	        this.Number = source["Number"];
+	        this.Virtual = source["Virtual"];
 	    }
 	}
 	export class TimeTable {
 	    LectureID: number;
+	    Kind: string;
 	    Semester: string;
 	    Room: Room;
 	    DayOfWeek: string;
 	    Period: number;
+	    // Go type: time
+	    StartDate: any;
+	    // Go type: time
+	    EndDate: any;
 	
 	    static createFrom(source: any = {}) {
 	        return new TimeTable(source);
@@ -60,10 +413,13 @@ export namespace domain {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.LectureID = source["LectureID"];
+	        this.Kind = source["Kind"];
 	        this.Semester = source["Semester"];
 	        this.Room = this.convertValues(source["Room"], Room);
 	        this.DayOfWeek = source["DayOfWeek"];
 	        this.Period = source["Period"];
+	        this.StartDate = this.convertValues(source["StartDate"], null);
+	        this.EndDate = this.convertValues(source["EndDate"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -118,6 +474,17 @@ export namespace domain {
 	    Keywords: string[];
 	    RelatedCourseCodes: string[];
 	    RelatedCourses: number[];
+	    CourseReferences: CourseReference[];
+	    CourseRelations: CourseRelation[];
+	    Contacts: ContactEntry[];
+	    OfficeHourSlots: OfficeHour[];
+	    EnglishDepartment: string;
+	    EnglishAbstract: string;
+	    EnglishGoal: string;
+	    EnglishFlow: string;
+	    EnglishAssessment: string;
+	    EnglishKeywords: string[];
+	    EnglishLecturePlans: LecturePlan[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Lecture(source);
@@ -157,6 +524,17 @@ export namespace domain {
 	        this.Keywords = source["Keywords"];
 	        this.RelatedCourseCodes = source["RelatedCourseCodes"];
 	        this.RelatedCourses = source["RelatedCourses"];
+	        this.CourseReferences = this.convertValues(source["CourseReferences"], CourseReference);
+	        this.CourseRelations = this.convertValues(source["CourseRelations"], CourseRelation);
+	        this.Contacts = this.convertValues(source["Contacts"], ContactEntry);
+	        this.OfficeHourSlots = this.convertValues(source["OfficeHourSlots"], OfficeHour);
+	        this.EnglishDepartment = source["EnglishDepartment"];
+	        this.EnglishAbstract = source["EnglishAbstract"];
+	        this.EnglishGoal = source["EnglishGoal"];
+	        this.EnglishFlow = source["EnglishFlow"];
+	        this.EnglishAssessment = source["EnglishAssessment"];
+	        this.EnglishKeywords = source["EnglishKeywords"];
+	        this.EnglishLecturePlans = this.convertValues(source["EnglishLecturePlans"], LecturePlan);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -178,17 +556,57 @@ export namespace domain {
 		}
 	}
 	
-	export class LectureSummary {
-	    ID: number;
-	    University: string;
-	    Title: string;
-	    Department: string;
+	export class LectureSession {
+	    LectureID: number;
 	    Code: string;
-	    Level: number;
+	    Title: string;
+	    Year: number;
+	    Plan: LecturePlan;
+	
+	    static createFrom(source: any = {}) {
+	        return new LectureSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Year = source["Year"];
+	        this.Plan = this.convertValues(source["Plan"], LecturePlan);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LectureSummary {
+	    ID: number;
+	    University: string;
+	    Title: string;
+	    Department: string;
+	    Code: string;
+	    Level: number;
 	    Credit: number;
 	    Year: number;
+	    OpenTerm: string;
 	    Timetables: TimeTable[];
 	    Teachers: Teacher[];
+	    Bookmarked: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new LectureSummary(source);
@@ -204,8 +622,10 @@ export namespace domain {
 	        this.Level = source["Level"];
 	        this.Credit = source["Credit"];
 	        this.Year = source["Year"];
+	        this.OpenTerm = source["OpenTerm"];
 	        this.Timetables = this.convertValues(source["Timetables"], TimeTable);
 	        this.Teachers = this.convertValues(source["Teachers"], Teacher);
+	        this.Bookmarked = source["Bookmarked"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -226,35 +646,260 @@ export namespace domain {
 		    return a;
 		}
 	}
+	export class OfferingChange {
+	    Field: string;
+	    Before: string;
+	    After: string;
+	    Added: string[];
+	    Removed: string[];
 	
-	export class SearchQuery {
+	    static createFrom(source: any = {}) {
+	        return new OfferingChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Field = source["Field"];
+	        this.Before = source["Before"];
+	        this.After = source["After"];
+	        this.Added = source["Added"];
+	        this.Removed = source["Removed"];
+	    }
+	}
+	export class OfferingComparison {
+	    CourseKey: string;
+	    FromYear: number;
+	    ToYear: number;
+	    FromLectureID: number;
+	    ToLectureID: number;
+	    Changes: OfferingChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OfferingComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.CourseKey = source["CourseKey"];
+	        this.FromYear = source["FromYear"];
+	        this.ToYear = source["ToYear"];
+	        this.FromLectureID = source["FromLectureID"];
+	        this.ToLectureID = source["ToLectureID"];
+	        this.Changes = this.convertValues(source["Changes"], OfferingChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ParseIssue {
+	    Kind: string;
+	    Detail: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParseIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Detail = source["Detail"];
+	    }
+	}
+	export class ParseDiagnostic {
+	    URL: string;
+	    Code: string;
+	    Title: string;
+	    Issues: ParseIssue[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ParseDiagnostic(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.URL = source["URL"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Issues = this.convertValues(source["Issues"], ParseIssue);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ParseIssueSummary {
+	    Kind: string;
+	    Detail: string;
+	    Count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParseIssueSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Detail = source["Detail"];
+	        this.Count = source["Count"];
+	    }
+	}
+	export class RecommendationReason {
+	    Kind: string;
+	    Detail: string;
+	    Score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecommendationReason(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Detail = source["Detail"];
+	        this.Score = source["Score"];
+	    }
+	}
+	export class Recommendation {
+	    LectureID: number;
+	    Code: string;
 	    Title: string;
-	    Keywords: string[];
-	    Departments: string[];
 	    Year: number;
-	    TeacherName: string;
-	    Room: string;
-	    Semester: string[];
-	    TimeTables: TimeTable[];
-	    Levels: number[];
-	    FilterNotResearch: boolean;
+	    Score: number;
+	    Reasons: RecommendationReason[];
 	
 	    static createFrom(source: any = {}) {
-	        return new SearchQuery(source);
+	        return new Recommendation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
 	        this.Title = source["Title"];
-	        this.Keywords = source["Keywords"];
-	        this.Departments = source["Departments"];
 	        this.Year = source["Year"];
-	        this.TeacherName = source["TeacherName"];
-	        this.Room = source["Room"];
-	        this.Semester = source["Semester"];
-	        this.TimeTables = this.convertValues(source["TimeTables"], TimeTable);
-	        this.Levels = source["Levels"];
-	        this.FilterNotResearch = source["FilterNotResearch"];
+	        this.Score = source["Score"];
+	        this.Reasons = this.convertValues(source["Reasons"], RecommendationReason);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RefreshSetting {
+	    SiteID: string;
+	    Spec: string;
+	    // Go type: time
+	    UpdatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefreshSetting(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SiteID = source["SiteID"];
+	        this.Spec = source["Spec"];
+	        this.UpdatedAt = this.convertValues(source["UpdatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RequirementCondition {
+	    Field: string;
+	    Op: string;
+	    Values: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RequirementCondition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Field = source["Field"];
+	        this.Op = source["Op"];
+	        this.Values = source["Values"];
+	    }
+	}
+	export class Requirement {
+	    Name: string;
+	    Kind: string;
+	    Minimum: number;
+	    Courses: string[];
+	    Conditions: RequirementCondition[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Requirement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Kind = source["Kind"];
+	        this.Minimum = source["Minimum"];
+	        this.Courses = source["Courses"];
+	        this.Conditions = this.convertValues(source["Conditions"], RequirementCondition);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -276,6 +921,1019 @@ export namespace domain {
 		}
 	}
 	
+	export class RequirementCourse {
+	    LectureID: number;
+	    Code: string;
+	    Title: string;
+	    Department: string;
+	    Level: number;
+	    Credit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequirementCourse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Department = source["Department"];
+	        this.Level = source["Level"];
+	        this.Credit = source["Credit"];
+	    }
+	}
+	export class RequirementResult {
+	    Name: string;
+	    Kind: string;
+	    Minimum: number;
+	    Completed: number;
+	    Planned: number;
+	    Met: boolean;
+	    MetByCompleted: boolean;
+	    CountedCourses: number[];
+	    MissingCourses: string[];
+	    Suggestions: RequirementCourse[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RequirementResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Kind = source["Kind"];
+	        this.Minimum = source["Minimum"];
+	        this.Completed = source["Completed"];
+	        this.Planned = source["Planned"];
+	        this.Met = source["Met"];
+	        this.MetByCompleted = source["MetByCompleted"];
+	        this.CountedCourses = source["CountedCourses"];
+	        this.MissingCourses = source["MissingCourses"];
+	        this.Suggestions = this.convertValues(source["Suggestions"], RequirementCourse);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RequirementReport {
+	    SetName: string;
+	    Met: boolean;
+	    Results: RequirementResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RequirementReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SetName = source["SetName"];
+	        this.Met = source["Met"];
+	        this.Results = this.convertValues(source["Results"], RequirementResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RequirementSet {
+	    Name: string;
+	    Source: string;
+	    Requirements: Requirement[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RequirementSet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Source = source["Source"];
+	        this.Requirements = this.convertValues(source["Requirements"], Requirement);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ResearchFilterRule {
+	    ID: number;
+	    Field: string;
+	    Match: string;
+	    Pattern: string;
+	    Enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResearchFilterRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Field = source["Field"];
+	        this.Match = source["Match"];
+	        this.Pattern = source["Pattern"];
+	        this.Enabled = source["Enabled"];
+	    }
+	}
+	
+	export class RoomBooking {
+	    LectureID: number;
+	    Code: string;
+	    Title: string;
+	    Year: number;
+	    Semester: string;
+	    DayOfWeek: string;
+	    Period: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomBooking(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Year = source["Year"];
+	        this.Semester = source["Semester"];
+	        this.DayOfWeek = source["DayOfWeek"];
+	        this.Period = source["Period"];
+	    }
+	}
+	export class RoomSlot {
+	    Semester: string;
+	    DayOfWeek: string;
+	    Period: number;
+	    Bookings: RoomBooking[];
+	    DoubleBooked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomSlot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Semester = source["Semester"];
+	        this.DayOfWeek = source["DayOfWeek"];
+	        this.Period = source["Period"];
+	        this.Bookings = this.convertValues(source["Bookings"], RoomBooking);
+	        this.DoubleBooked = source["DoubleBooked"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoomSchedule {
+	    Room: Room;
+	    Year: number;
+	    Slots: RoomSlot[];
+	    DoubleBookings: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoomSchedule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Room = this.convertValues(source["Room"], Room);
+	        this.Year = source["Year"];
+	        this.Slots = this.convertValues(source["Slots"], RoomSlot);
+	        this.DoubleBookings = source["DoubleBookings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SearchQuery {
+	    University: string;
+	    Title: string;
+	    Keywords: string[];
+	    Departments: string[];
+	    Year: number;
+	    TeacherName: string;
+	    Room: string;
+	    Semester: string[];
+	    TimeTables: TimeTable[];
+	    TimeTableKinds: string[];
+	    ExcludeKinds: string[];
+	    Levels: number[];
+	    PlanTopic: string;
+	    FilterNotResearch: boolean;
+	    ExcludePassed: boolean;
+	    BookmarkedOnly: boolean;
+	    Tags: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.University = source["University"];
+	        this.Title = source["Title"];
+	        this.Keywords = source["Keywords"];
+	        this.Departments = source["Departments"];
+	        this.Year = source["Year"];
+	        this.TeacherName = source["TeacherName"];
+	        this.Room = source["Room"];
+	        this.Semester = source["Semester"];
+	        this.TimeTables = this.convertValues(source["TimeTables"], TimeTable);
+	        this.TimeTableKinds = source["TimeTableKinds"];
+	        this.ExcludeKinds = source["ExcludeKinds"];
+	        this.Levels = source["Levels"];
+	        this.PlanTopic = source["PlanTopic"];
+	        this.FilterNotResearch = source["FilterNotResearch"];
+	        this.ExcludePassed = source["ExcludePassed"];
+	        this.BookmarkedOnly = source["BookmarkedOnly"];
+	        this.Tags = source["Tags"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SavedSearch {
+	    ID: number;
+	    Name: string;
+	    Query: SearchQuery;
+	    // Go type: time
+	    CreatedAt: any;
+	    // Go type: time
+	    CheckedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SavedSearch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Name = source["Name"];
+	        this.Query = this.convertValues(source["Query"], SearchQuery);
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], null);
+	        this.CheckedAt = this.convertValues(source["CheckedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScrapeRun {
+	    ID: number;
+	    SiteID: string;
+	    // Go type: time
+	    StartedAt: any;
+	    // Go type: time
+	    FinishedAt: any;
+	    Lectures: number;
+	    MissingLectures: number;
+	    Diagnostics: ParseDiagnostic[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScrapeRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.SiteID = source["SiteID"];
+	        this.StartedAt = this.convertValues(source["StartedAt"], null);
+	        this.FinishedAt = this.convertValues(source["FinishedAt"], null);
+	        this.Lectures = source["Lectures"];
+	        this.MissingLectures = source["MissingLectures"];
+	        this.Diagnostics = this.convertValues(source["Diagnostics"], ParseDiagnostic);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SearchChange {
+	    Kind: string;
+	    LectureID: number;
+	    Code: string;
+	    Title: string;
+	    Year: number;
+	    Before: string;
+	    After: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Year = source["Year"];
+	        this.Before = source["Before"];
+	        this.After = source["After"];
+	    }
+	}
+	export class SearchNotification {
+	    ID: number;
+	    SearchID: number;
+	    SearchName: string;
+	    Changes: SearchChange[];
+	    // Go type: time
+	    CreatedAt: any;
+	    Read: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchNotification(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.SearchID = source["SearchID"];
+	        this.SearchName = source["SearchName"];
+	        this.Changes = this.convertValues(source["Changes"], SearchChange);
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], null);
+	        this.Read = source["Read"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class TeacherLecture {
+	    LectureID: number;
+	    Code: string;
+	    Title: string;
+	    Credit: number;
+	    Kind: string;
+	    Room: Room;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeacherLecture(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureID = source["LectureID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Credit = source["Credit"];
+	        this.Kind = source["Kind"];
+	        this.Room = this.convertValues(source["Room"], Room);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TeacherWorkload {
+	    Courses: number;
+	    Credits: number;
+	    Sessions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeacherWorkload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Courses = source["Courses"];
+	        this.Credits = source["Credits"];
+	        this.Sessions = source["Sessions"];
+	    }
+	}
+	export class TeacherSlot {
+	    DayOfWeek: string;
+	    Period: number;
+	    Lectures: TeacherLecture[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TeacherSlot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.DayOfWeek = source["DayOfWeek"];
+	        this.Period = source["Period"];
+	        this.Lectures = this.convertValues(source["Lectures"], TeacherLecture);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TeacherQuarter {
+	    Semester: string;
+	    Slots: TeacherSlot[];
+	    Unscheduled: TeacherLecture[];
+	    Workload: TeacherWorkload;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeacherQuarter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Semester = source["Semester"];
+	        this.Slots = this.convertValues(source["Slots"], TeacherSlot);
+	        this.Unscheduled = this.convertValues(source["Unscheduled"], TeacherLecture);
+	        this.Workload = this.convertValues(source["Workload"], TeacherWorkload);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TeacherSchedule {
+	    Teacher: Teacher;
+	    Year: number;
+	    Quarters: TeacherQuarter[];
+	    Workload: TeacherWorkload;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeacherSchedule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Teacher = this.convertValues(source["Teacher"], Teacher);
+	        this.Year = source["Year"];
+	        this.Quarters = this.convertValues(source["Quarters"], TeacherQuarter);
+	        this.Workload = this.convertValues(source["Workload"], TeacherWorkload);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class TranscriptEntry {
+	    ID: number;
+	    Code: string;
+	    Title: string;
+	    Credit: number;
+	    Grade: string;
+	    Year: number;
+	    Passed: boolean;
+	    LectureID: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.Credit = source["Credit"];
+	        this.Grade = source["Grade"];
+	        this.Year = source["Year"];
+	        this.Passed = source["Passed"];
+	        this.LectureID = source["LectureID"];
+	    }
+	}
+	export class TranscriptSummary {
+	    EarnedCredits: number;
+	    AttemptedCredits: number;
+	    PassedCourses: number;
+	    FailedCourses: number;
+	    InProgressCourses: number;
+	    CreditsByYear: Record<number, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.EarnedCredits = source["EarnedCredits"];
+	        this.AttemptedCredits = source["AttemptedCredits"];
+	        this.PassedCourses = source["PassedCourses"];
+	        this.FailedCourses = source["FailedCourses"];
+	        this.InProgressCourses = source["InProgressCourses"];
+	        this.CreditsByYear = source["CreditsByYear"];
+	    }
+	}
+
+}
+
+export namespace usecase {
+	
+	export class LectureExportRequest {
+	    LectureIDs: number[];
+	    Query: domain.SearchQuery;
+	    Columns: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LectureExportRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LectureIDs = source["LectureIDs"];
+	        this.Query = this.convertValues(source["Query"], domain.SearchQuery);
+	        this.Columns = source["Columns"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RefreshOutcome {
+	    SiteID: string;
+	    Year: number;
+	    // Go type: time
+	    StartedAt: any;
+	    // Go type: time
+	    FinishedAt: any;
+	    Stale: number;
+	    Updated: number;
+	    Skipped: boolean;
+	    Error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefreshOutcome(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SiteID = source["SiteID"];
+	        this.Year = source["Year"];
+	        this.StartedAt = this.convertValues(source["StartedAt"], null);
+	        this.FinishedAt = this.convertValues(source["FinishedAt"], null);
+	        this.Stale = source["Stale"];
+	        this.Updated = source["Updated"];
+	        this.Skipped = source["Skipped"];
+	        this.Error = source["Error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RefreshStatus {
+	    Setting?: domain.RefreshSetting;
+	    // Go type: time
+	    NextRun: any;
+	    LastOutcome?: RefreshOutcome;
+	
+	    static createFrom(source: any = {}) {
+	        return new RefreshStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Setting = this.convertValues(source["Setting"], domain.RefreshSetting);
+	        this.NextRun = this.convertValues(source["NextRun"], null);
+	        this.LastOutcome = this.convertValues(source["LastOutcome"], RefreshOutcome);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScrapeRunReport {
+	    Run: domain.ScrapeRun;
+	    MissingFieldRate: number;
+	    Issues: domain.ParseIssueSummary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScrapeRunReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Run = this.convertValues(source["Run"], domain.ScrapeRun);
+	        this.MissingFieldRate = source["MissingFieldRate"];
+	        this.Issues = this.convertValues(source["Issues"], domain.ParseIssueSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SiteInfo {
+	    ID: string;
+	    University: string;
+	    BaseURL: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SiteInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.University = source["University"];
+	        this.BaseURL = source["BaseURL"];
+	    }
+	}
+	export class StaleLecture {
+	    Code: string;
+	    Title: string;
+	    OpenTerm: string;
+	    Year: number;
+	    DetailURL: string;
+	    // Go type: time
+	    ListUpdatedAt: any;
+	    // Go type: time
+	    StoredUpdatedAt: any;
+	    New: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StaleLecture(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Code = source["Code"];
+	        this.Title = source["Title"];
+	        this.OpenTerm = source["OpenTerm"];
+	        this.Year = source["Year"];
+	        this.DetailURL = source["DetailURL"];
+	        this.ListUpdatedAt = this.convertValues(source["ListUpdatedAt"], null);
+	        this.StoredUpdatedAt = this.convertValues(source["StoredUpdatedAt"], null);
+	        this.New = source["New"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimetableReportRequest {
+	    Title: string;
+	    LectureIDs: number[];
+	    Query: domain.SearchQuery;
+	    TemplateDir: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimetableReportRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Title = source["Title"];
+	        this.LectureIDs = source["LectureIDs"];
+	        this.Query = this.convertValues(source["Query"], domain.SearchQuery);
+	        this.TemplateDir = source["TemplateDir"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TranscriptImportResult {
+	    Imported: number;
+	    Matched: number;
+	    Unmatched: domain.TranscriptEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Imported = source["Imported"];
+	        this.Matched = source["Matched"];
+	        this.Unmatched = this.convertValues(source["Unmatched"], domain.TranscriptEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateCheck {
+	    SiteID: string;
+	    Year: number;
+	    DryRun: boolean;
+	    ListPages: number;
+	    Listed: number;
+	    Stale: StaleLecture[];
+	    Lectures: domain.Lecture[];
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SiteID = source["SiteID"];
+	        this.Year = source["Year"];
+	        this.DryRun = source["DryRun"];
+	        this.ListPages = source["ListPages"];
+	        this.Listed = source["Listed"];
+	        this.Stale = this.convertValues(source["Stale"], StaleLecture);
+	        this.Lectures = this.convertValues(source["Lectures"], domain.Lecture);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 